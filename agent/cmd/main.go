@@ -38,8 +38,20 @@ func main() {
 		orchestratorCfg.MaxRetries,
 		time.Second*time.Duration(orchestratorCfg.BaseRetryDelay),
 	)
+	defer orchestratorAdapter.Close() //nolint
 
-	agentService := service.NewAgentService(orchestratorAdapter)
+	agentID := agentCfg.ID
+	if agentID == "" {
+		if agentID, err = os.Hostname(); err != nil {
+			log.Fatalf("failed to get agent id from hostname: %v", err)
+		}
+	}
+
+	if err = service.CheckOperations(agentCfg.Operations); err != nil {
+		log.Fatalf("invalid agent operations: %v", err)
+	}
+
+	agentService := service.NewAgentService(orchestratorAdapter, agentID, agentCfg.Operations)
 	server.RunAgentService(ctx, agentService, agentCfg.ComputingPower, agentCfg.WaitTime)
 
 	select {
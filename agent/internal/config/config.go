@@ -21,8 +21,12 @@ type AgentConfig struct {
 	Host string `yaml:"host" env:"HOST" env-default:"localhost"`
 	Port int    `yaml:"port" env:"PORT" env-default:"50053"`
 
-	ComputingPower int `yaml:"computing_power" env:"COMPUTING_POWER" env-default:"5"`
-	WaitTime       int `yaml:"wait_time" env:"WAIT_TIME_MS" env-default:"500"`
+	// ID под которым агент регистрируется в оркестраторе, по умолчанию имя хоста
+	ID string `yaml:"id" env:"ID"`
+	// Operations операции, задачи с которыми берёт агент, по умолчанию все, что он умеет
+	Operations     []string `yaml:"operations" env:"OPERATIONS" env-separator:","`
+	ComputingPower int      `yaml:"computing_power" env:"COMPUTING_POWER" env-default:"5"`
+	WaitTime       int      `yaml:"wait_time" env:"WAIT_TIME_MS" env-default:"500"`
 }
 
 type Config struct {
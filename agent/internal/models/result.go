@@ -4,4 +4,8 @@ type Result struct {
 	ExpressionID string
 	TaskID       int     `json:"id"`
 	Result       float64 `json:"result"`
+	// Decimal точный результат задачи, вычисленной в десятичной арифметике
+	Decimal string `json:"decimal,omitempty"`
+	// Err ошибка вычисления, о которой нужно сообщить оркестратору
+	Err error `json:"-"`
 }
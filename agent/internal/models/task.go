@@ -5,8 +5,12 @@ import "time"
 type Task struct {
 	ExpressionID  string
 	TaskID        int           `json:"id"`
-	Arg1          float64       `json:"arg1"`
-	Arg2          float64       `json:"arg2"`
+	Args          []float64     `json:"args"`
 	Operation     string        `json:"operation"`
 	OperationTime time.Duration `json:"operation_time"`
+	// Decimal задачу нужно вычислить в десятичной арифметике над DecimalArgs
+	Decimal     bool     `json:"decimal"`
+	DecimalArgs []string `json:"decimal_args,omitempty"`
+	// Cancel это не задача, а отмена выражения ExpressionID: его задачи нужно бросить
+	Cancel bool `json:"-"`
 }
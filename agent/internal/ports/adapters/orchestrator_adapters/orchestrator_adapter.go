@@ -2,13 +2,16 @@ package orchestrator_adapters
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/jaam8/web_calculator/agent/internal/models"
+	"github.com/jaam8/web_calculator/agent/internal/ports"
 	"github.com/jaam8/web_calculator/common-lib/callers"
-	"github.com/jaam8/web_calculator/common-lib/errors"
+	errs "github.com/jaam8/web_calculator/common-lib/errors"
 	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"sync"
 	"time"
 )
 
@@ -18,6 +21,9 @@ type OrchestratorAdapter struct {
 	Timeout        time.Duration
 	MaxRetries     uint
 	BaseRetryDelay time.Duration
+
+	mu   sync.Mutex
+	conn *grpc.ClientConn
 }
 
 func NewOrchestratorAdapter(
@@ -35,25 +41,44 @@ func NewOrchestratorAdapter(
 	}
 }
 
+// GetGRPCClient returns a client on the connection shared by all calls,
+// the connection is created on first use and reconnects by itself
 func (o *OrchestratorAdapter) GetGRPCClient() (*grpc.ClientConn, *orchestrator.OrchestratorServiceClient, error) {
-	grpcConn, err := grpc.NewClient(o.Address, o.DialOptions...)
-	if err != nil {
-		return nil, nil, fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.conn == nil {
+		grpcConn, err := grpc.NewClient(o.Address, o.DialOptions...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
+		}
+		o.conn = grpcConn
 	}
 
-	client := orchestrator.NewOrchestratorServiceClient(grpcConn)
+	client := orchestrator.NewOrchestratorServiceClient(o.conn)
 
-	return grpcConn, &client, nil
+	return o.conn, &client, nil
+}
+
+// Close closes the shared connection
+func (o *OrchestratorAdapter) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.conn == nil {
+		return nil
+	}
+	err := o.conn.Close()
+	o.conn = nil
+	return err
 }
 
 func (o *OrchestratorAdapter) GetTask() (models.Task, error) {
-	conn, clientPointer, err := o.GetGRPCClient()
+	_, clientPointer, err := o.GetGRPCClient()
 	if err != nil {
 		return models.Task{}, fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
 	}
 
-	defer conn.Close() //nolint
-
 	resultChan := make(chan *orchestrator.Task, 1)
 
 	err = callers.Timeout(func() error {
@@ -71,34 +96,27 @@ func (o *OrchestratorAdapter) GetTask() (models.Task, error) {
 
 	responseTask := <-resultChan
 	close(resultChan)
-	task := models.Task{
-		ExpressionID:  responseTask.GetExpressionId(),
-		TaskID:        int(responseTask.GetId()),
-		Arg1:          responseTask.GetArg1(),
-		Arg2:          responseTask.GetArg2(),
-		Operation:     responseTask.GetOperation(),
-		OperationTime: responseTask.GetOperationTime().AsDuration(),
-	}
-	return task, nil
+	return taskFromProto(responseTask), nil
 }
 
 func (o *OrchestratorAdapter) ResultTask(
-	expressionID string, taskID int, result float64,
+	expressionID string, taskID int, result float64, decimalResult string, taskErr error,
 ) (string, error) {
-	conn, clientPointer, err := o.GetGRPCClient()
+	_, clientPointer, err := o.GetGRPCClient()
 	if err != nil {
 		return "", fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
 	}
-	defer conn.Close() //nolint
 
 	resultChan := make(chan string, 1)
 	err = callers.Retry(func() error {
 		err = callers.Timeout(func() error {
-			request := &orchestrator.ResultTaskRequest{
-				ExpressionId: expressionID,
-				Id:           int64(taskID),
+			request := resultRequest(models.Result{
+				ExpressionID: expressionID,
+				TaskID:       taskID,
 				Result:       result,
-			}
+				Decimal:      decimalResult,
+				Err:          taskErr,
+			})
 			response, grpcErr := (*clientPointer).ResultTask(context.Background(), request)
 			if grpcErr != nil {
 				return fmt.Errorf("error in timeout gRPC caller: %w", grpcErr)
@@ -119,8 +137,162 @@ func (o *OrchestratorAdapter) ResultTask(
 	status := <-resultChan
 	close(resultChan)
 	if status == "task not found" {
-		return "", errors.ErrTaskNotFound
+		return "", errs.ErrTaskNotFound
 	}
 
 	return status, nil
 }
+
+func (o *OrchestratorAdapter) RegisterAgent(id string, capacity int, operations []string) (time.Duration, error) {
+	_, clientPointer, err := o.GetGRPCClient()
+	if err != nil {
+		return 0, fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
+	}
+
+	resultChan := make(chan time.Duration, 1)
+	err = callers.Retry(func() error {
+		err = callers.Timeout(func() error {
+			response, grpcErr := (*clientPointer).RegisterAgent(context.Background(), &orchestrator.RegisterAgentRequest{
+				Id:         id,
+				Capacity:   int32(capacity),
+				Operations: operations,
+			})
+			if grpcErr != nil {
+				return fmt.Errorf("error in timeout gRPC caller: %w", grpcErr)
+			}
+			resultChan <- response.GetHeartbeatInterval().AsDuration()
+			return nil
+		}, o.Timeout)
+		if err != nil {
+			return fmt.Errorf("error in retry gRPC caller: %w", err)
+		}
+		return nil
+	}, o.MaxRetries, o.BaseRetryDelay)
+
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get orchestrator.RegisterAgent gRPC response: %w", err)
+	}
+	return <-resultChan, nil
+}
+
+// Heartbeat returns errs.ErrAgentNotFound if the orchestrator forgot the agent
+func (o *OrchestratorAdapter) Heartbeat(id string) error {
+	_, clientPointer, err := o.GetGRPCClient()
+	if err != nil {
+		return fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
+	}
+
+	err = callers.Timeout(func() error {
+		_, grpcErr := (*clientPointer).Heartbeat(context.Background(), &orchestrator.HeartbeatRequest{Id: id})
+		return grpcErr
+	}, o.Timeout)
+	if errors.Is(errs.FromGRPC(err), errs.ErrAgentNotFound) {
+		return errs.ErrAgentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get orchestrator.Heartbeat gRPC response: %w", err)
+	}
+	return nil
+}
+
+// Work opens the bidirectional Work stream on the shared connection.
+// The stream lives until ctx is cancelled or the orchestrator closes it
+func (o *OrchestratorAdapter) Work(ctx context.Context, agentID string) (ports.WorkStream, error) {
+	_, clientPointer, err := o.GetGRPCClient()
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to orchestrator by gRPC: %w", err)
+	}
+	stream, err := (*clientPointer).Work(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open orchestrator.Work gRPC stream: %w", err)
+	}
+	return &workStream{agentID: agentID, stream: stream}, nil
+}
+
+// workStream serializes sends, gRPC streams can't be written from several goroutines at once
+type workStream struct {
+	mu      sync.Mutex
+	agentID string
+	stream  grpc.BidiStreamingClient[orchestrator.WorkRequest, orchestrator.WorkResponse]
+}
+
+func (w *workStream) Ready(slots int) error {
+	return w.send(&orchestrator.WorkRequest{
+		Payload: &orchestrator.WorkRequest_Ready{Ready: &orchestrator.WorkerReady{
+			Slots:   int32(slots),
+			AgentId: w.agentID,
+		}},
+	})
+}
+
+func (w *workStream) Recv() (models.Task, error) {
+	response, err := w.stream.Recv()
+	if err != nil {
+		return models.Task{}, fmt.Errorf("couldn't receive task from orchestrator.Work gRPC stream: %w", err)
+	}
+	if expressionID := response.GetCancelExpressionId(); expressionID != "" {
+		return models.Task{ExpressionID: expressionID, Cancel: true}, nil
+	}
+	return taskFromProto(response.GetTask()), nil
+}
+
+func (w *workStream) Send(result models.Result) error {
+	return w.send(&orchestrator.WorkRequest{
+		Payload: &orchestrator.WorkRequest_Result{Result: resultRequest(result)},
+	})
+}
+
+func (w *workStream) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stream.CloseSend()
+}
+
+func (w *workStream) send(request *orchestrator.WorkRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.stream.Send(request); err != nil {
+		return fmt.Errorf("couldn't send to orchestrator.Work gRPC stream: %w", err)
+	}
+	return nil
+}
+
+func taskFromProto(task *orchestrator.Task) models.Task {
+	return models.Task{
+		ExpressionID:  task.GetExpressionId(),
+		TaskID:        int(task.GetId()),
+		Args:          task.GetArgs(),
+		Operation:     task.GetOperation(),
+		OperationTime: task.GetOperationTime().AsDuration(),
+		Decimal:       task.GetPrecision() == orchestrator.Precision_PRECISION_DECIMAL,
+		DecimalArgs:   task.GetDecimalArgs(),
+	}
+}
+
+func resultRequest(result models.Result) *orchestrator.ResultTaskRequest {
+	request := &orchestrator.ResultTaskRequest{
+		ExpressionId:  result.ExpressionID,
+		Id:            int64(result.TaskID),
+		Result:        result.Result,
+		DecimalResult: result.Decimal,
+	}
+	if result.Err != nil {
+		request.ErrorCode = taskErrorCode(result.Err)
+		request.ErrorMessage = result.Err.Error()
+	}
+	return request
+}
+
+// taskErrorCode переводит ошибку вычисления в код, понятный оркестратору
+func taskErrorCode(err error) orchestrator.TaskErrorCode {
+	switch {
+	case errors.Is(err, errs.ErrDivideByZero):
+		return orchestrator.TaskErrorCode_DIVISION_BY_ZERO
+	case errors.Is(err, errs.ErrOutOfDomain):
+		return orchestrator.TaskErrorCode_OUT_OF_DOMAIN
+	case errors.Is(err, errs.ErrExpressionCancelled):
+		return orchestrator.TaskErrorCode_CANCELLED
+	default:
+		return orchestrator.TaskErrorCode_INVALID_OPERATION
+	}
+}
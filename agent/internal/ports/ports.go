@@ -1,10 +1,28 @@
 package ports
 
 import (
+	"context"
 	"github.com/jaam8/web_calculator/agent/internal/models"
+	"time"
 )
 
 type OrchestratorAdapter interface {
 	GetTask() (models.Task, error)
-	ResultTask(expressionID string, taskID int, result float64) (string, error)
+	ResultTask(expressionID string, taskID int, result float64, decimalResult string, taskErr error) (string, error)
+	// RegisterAgent регистрирует агента и возвращает, как часто присылать heartbeat
+	RegisterAgent(id string, capacity int, operations []string) (time.Duration, error)
+	Heartbeat(id string) error
+	// Work открывает долгоживущий поток задач с оркестратором
+	Work(ctx context.Context, agentID string) (WorkStream, error)
+}
+
+// WorkStream поток Work: оркестратор присылает задачи, пока у агента есть свободные
+// вычислители, каждый отправленный результат освобождает один вычислитель
+type WorkStream interface {
+	// Ready сообщает оркестратору о slots свободных вычислителях
+	Ready(slots int) error
+	// Recv возвращает следующую задачу или отмену выражения с Task.Cancel
+	Recv() (models.Task, error)
+	Send(result models.Result) error
+	Close() error
 }
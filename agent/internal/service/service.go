@@ -3,136 +3,472 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/jaam8/web_calculator/agent/internal/models"
 	"github.com/jaam8/web_calculator/agent/internal/ports"
+	"github.com/jaam8/web_calculator/common-lib/decimal"
 	errs "github.com/jaam8/web_calculator/common-lib/errors"
 	"github.com/jaam8/web_calculator/common-lib/logger"
 	"go.uber.org/zap"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 )
 
 type AgentService struct {
 	orchestratorAdapter ports.OrchestratorAdapter
+	id                  string
+	operations          []string
 }
 
-func NewAgentService(orchestratorAdapter ports.OrchestratorAdapter) *AgentService {
+// NewAgentService создаёт агента, id - под которым он регистрируется в оркестраторе,
+// operations - операции, задачи с которыми он берёт на себя, если пусто - все из Operations
+func NewAgentService(orchestratorAdapter ports.OrchestratorAdapter, id string, operations []string) *AgentService {
+	if len(operations) == 0 {
+		operations = Operations()
+	}
 	return &AgentService{
 		orchestratorAdapter: orchestratorAdapter,
+		id:                  id,
+		operations:          operations,
 	}
 }
 
-// Work делает постоянные запросы к оркестратору
-func (s *AgentService) Work(ctx context.Context, waitTime int) {
-	sleepTime := time.Duration(waitTime) * time.Millisecond
+// Work регистрирует агента в оркестраторе и держит поток задач: задачи приходят сразу
+// после создания и считаются computingPower вычислителями, результаты отправляются
+// в тот же поток. После обрыва агент регистрируется и открывает поток заново через waitTime миллисекунд
+func (s *AgentService) Work(ctx context.Context, computingPower int, waitTime int) {
+	retryDelay := time.Duration(waitTime) * time.Millisecond
 	for {
-		task, err := s.GetTask()
-		for err != nil || task.TaskID == 0 {
-			switch {
-			case errors.Is(err, errs.ErrTaskNotFound):
-				logger.GetLoggerFromCtx(ctx).Warn(ctx,
-					"Task not found",
-					zap.Error(err))
-				time.Sleep(sleepTime)
-			case errors.Is(err, errs.ErrInternalServerError):
-				logger.GetLoggerFromCtx(ctx).Error(ctx,
-					"Internal server error",
-					zap.Int("task_id", task.TaskID),
-					zap.Error(err))
-				time.Sleep(sleepTime)
-			default:
-				logger.GetLoggerFromCtx(ctx).Error(ctx,
-					"Unknown error",
-					zap.Int("task_id", task.TaskID),
-					zap.Error(err),
+		err := s.serve(ctx, computingPower)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"Work stream with orchestrator closed, reconnecting",
+			zap.Duration("retry_delay", retryDelay),
+			zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// serve обрабатывает задачи одного потока до его закрытия. Задачи, результаты которых
+// не удалось отправить, оркестратор выдаст снова по истечении аренды
+func (s *AgentService) serve(ctx context.Context, computingPower int) error {
+	interval, err := s.orchestratorAdapter.RegisterAgent(s.id, computingPower, s.operations)
+	if err != nil {
+		return err
+	}
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"Registered in orchestrator",
+		zap.String("agent_id", s.id),
+		zap.Strings("operations", s.operations),
+		zap.Duration("heartbeat_interval", interval))
+
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	go s.heartbeat(ctx, interval, stop)
+
+	stream, err := s.orchestratorAdapter.Work(ctx, s.id)
+	if err != nil {
+		return err
+	}
+	defer stream.Close() //nolint
+
+	if err = stream.Ready(computingPower); err != nil {
+		return err
+	}
+
+	tasks := make(chan models.Task, computingPower)
+	running := &runningTasks{tasks: make(map[taskKey]runningTask)}
+	var wg sync.WaitGroup
+	for i := 0; i < computingPower; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				result := s.run(ctx, running, task)
+				if errors.Is(result.Err, errs.ErrExpressionCancelled) {
+					logger.GetLoggerFromCtx(ctx).Info(ctx,
+						"Abandon task of cancelled expression",
+						zap.String("expression_id", result.ExpressionID),
+						zap.Int("task_id", result.TaskID))
+				}
+				if err := stream.Send(result); err != nil {
+					logger.GetLoggerFromCtx(ctx).Error(ctx,
+						"Error send result for task",
+						zap.String("expression_id", result.ExpressionID),
+						zap.Int("task_id", result.TaskID),
+						zap.Float64("result", result.Result),
+						zap.Error(err))
+					continue
+				}
+				logger.GetLoggerFromCtx(ctx).Info(ctx,
+					"Send result for task",
+					zap.String("expression_id", result.ExpressionID),
+					zap.Int("task_id", result.TaskID),
+					zap.Float64("result", result.Result),
 				)
 			}
-			task, err = s.GetTask()
-		}
+		}()
+	}
+	defer wg.Wait()
+	defer close(tasks)
 
+	for {
+		task, err := stream.Recv()
+		if err != nil {
+			if cause := context.Cause(ctx); ctx.Err() != nil && cause != nil {
+				return cause
+			}
+			return err
+		}
+		if task.Cancel {
+			running.cancelExpression(task.ExpressionID)
+			continue
+		}
+		running.add(ctx, task)
 		logger.GetLoggerFromCtx(ctx).Debug(ctx,
 			"GOT TASK",
 			zap.String("expression_id", task.ExpressionID),
 			zap.Int("task_id", task.TaskID),
 			zap.String("operation", task.Operation),
-			zap.Float64("arg1", task.Arg1),
-			zap.Float64("arg2", task.Arg2),
+			zap.Float64s("args", task.Args),
 			zap.Duration("operation_time", task.OperationTime),
 		)
+		tasks <- task
+	}
+}
 
-		result, err := DoTask(task)
-		if err != nil {
-			switch {
-			case errors.Is(err, errs.ErrDivideByZero):
-				logger.GetLoggerFromCtx(ctx).Error(ctx,
-					"Division by zero error",
-					zap.Int("task_id", task.TaskID),
-					zap.Error(err),
-				)
-				continue
-			case errors.Is(err, errs.ErrInvalidExpression):
-				logger.GetLoggerFromCtx(ctx).Error(ctx,
-					"Invalid expression error",
-					zap.Int("task_id", task.TaskID),
-					zap.Error(err))
-				continue
-			default:
-				logger.GetLoggerFromCtx(ctx).Error(ctx,
-					"Unknown error",
-					zap.Int("task_id", task.TaskID),
-					zap.Error(err),
-				)
-				continue
-			}
+type taskKey struct {
+	expressionID string
+	taskID       int
+}
+
+// runningTasks задачи, полученные агентом и ещё не посчитанные
+type runningTasks struct {
+	mu    sync.Mutex
+	tasks map[taskKey]runningTask
+}
+
+// runningTask контекст задачи отменяется с причиной errors.ErrExpressionCancelled вместе с её выражением
+type runningTask struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func (r *runningTasks) add(ctx context.Context, task models.Task) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ctx, cancel := context.WithCancelCause(ctx)
+	r.tasks[taskKey{task.ExpressionID, task.TaskID}] = runningTask{ctx: ctx, cancel: cancel}
+}
+
+// take возвращает контекст задачи и функцию, снимающую её с учёта после вычисления
+func (r *runningTasks) take(task models.Task) (context.Context, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := taskKey{task.ExpressionID, task.TaskID}
+	running, ok := r.tasks[key]
+	if !ok {
+		return context.Background(), func() {}
+	}
+	return running.ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		running.cancel(nil)
+		delete(r.tasks, key)
+	}
+}
+
+// cancelExpression отменяет все задачи выражения
+func (r *runningTasks) cancelExpression(expressionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, running := range r.tasks {
+		if key.expressionID == expressionID {
+			running.cancel(errs.ErrExpressionCancelled)
 		}
+	}
+}
 
-		Result := models.Result{
-			ExpressionID: task.ExpressionID,
-			TaskID:       task.TaskID,
-			Result:       result,
+// run вычисляет задачу, а если её выражение отменили раньше, чем она посчитана,
+// бросает её и возвращает результат с ошибкой errors.ErrExpressionCancelled
+func (s *AgentService) run(ctx context.Context, running *runningTasks, task models.Task) models.Result {
+	taskCtx, done := running.take(task)
+	defer done()
+	results := make(chan models.Result, 1)
+	go func() { results <- s.compute(ctx, task) }()
+	select {
+	case result := <-results:
+		return result
+	case <-taskCtx.Done():
+	}
+	// поток закрывается, а не выражение отменено: задачу всё равно нужно досчитать
+	if !errors.Is(context.Cause(taskCtx), errs.ErrExpressionCancelled) {
+		return <-results
+	}
+	return models.Result{
+		ExpressionID: task.ExpressionID,
+		TaskID:       task.TaskID,
+		Err:          errs.ErrExpressionCancelled,
+	}
+}
+
+// heartbeat периодически сообщает оркестратору, что агент жив. Если оркестратор забыл
+// агента, например после своего перезапуска, поток закрывается, чтобы зарегистрироваться заново
+func (s *AgentService) heartbeat(ctx context.Context, interval time.Duration, stop context.CancelCauseFunc) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.orchestratorAdapter.Heartbeat(s.id)
+			if errors.Is(err, errs.ErrAgentNotFound) {
+				stop(err)
+				return
+			}
+			if err != nil {
+				logger.GetLoggerFromCtx(ctx).Warn(ctx,
+					"Heartbeat failed",
+					zap.String("agent_id", s.id),
+					zap.Error(err))
+			}
 		}
+	}
+}
 
-		err = s.ResultTask(Result)
-		if err != nil {
+// compute вычисляет задачу. Об ошибке вычисления сообщаем оркестратору,
+// чтобы выражение не зависло в pending
+func (s *AgentService) compute(ctx context.Context, task models.Task) models.Result {
+	var result float64
+	var decimalResult string
+	var err error
+	if task.Decimal {
+		decimalResult, err = DoDecimalTask(task)
+		result, _ = strconv.ParseFloat(decimalResult, 64)
+	} else {
+		result, err = DoTask(task)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.ErrDivideByZero):
 			logger.GetLoggerFromCtx(ctx).Error(ctx,
-				"Error send result for task",
-				zap.String("expression_id", Result.ExpressionID),
-				zap.Int("task_id", Result.TaskID),
-				zap.Float64("result", Result.Result),
+				"Division by zero error",
+				zap.Int("task_id", task.TaskID),
+				zap.Error(err),
+			)
+		case errors.Is(err, errs.ErrInvalidExpression):
+			logger.GetLoggerFromCtx(ctx).Error(ctx,
+				"Invalid expression error",
+				zap.Int("task_id", task.TaskID),
 				zap.Error(err))
+		default:
+			logger.GetLoggerFromCtx(ctx).Error(ctx,
+				"Unknown error",
+				zap.Int("task_id", task.TaskID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return models.Result{
+		ExpressionID: task.ExpressionID,
+		TaskID:       task.TaskID,
+		Result:       result,
+		Decimal:      decimalResult,
+		Err:          err,
+	}
+}
+
+// arity допустимое число аргументов операции, max < 0 - без ограничения
+var arity = map[string][2]int{
+	"+": {2, 2}, "-": {2, 2}, "*": {2, 2}, "/": {2, 2},
+	"^": {2, 2}, "%": {2, 2}, "//": {2, 2},
+	"sqrt": {1, 1}, "abs": {1, 1}, "sin": {1, 1}, "cos": {1, 1},
+	"log": {1, 2}, "min": {1, -1}, "max": {1, -1},
+}
+
+// Operations возвращает операции и функции, которые умеет считать агент
+func Operations() []string {
+	operations := make([]string, 0, len(arity))
+	for operation := range arity {
+		operations = append(operations, operation)
+	}
+	slices.Sort(operations)
+	return operations
+}
+
+// CheckOperations проверяет, что агент умеет считать все операции из списка
+func CheckOperations(operations []string) error {
+	for _, operation := range operations {
+		if _, ok := arity[operation]; !ok {
+			return fmt.Errorf("unknown operation %q", operation)
 		}
-		logger.GetLoggerFromCtx(ctx).Info(ctx,
-			"Send result for task",
-			zap.String("expression_id", Result.ExpressionID),
-			zap.Int("task_id", Result.TaskID),
-			zap.Float64("result", Result.Result),
-		)
-		time.Sleep(sleepTime)
 	}
+	return nil
 }
 
 // DoTask вычисляет задачу
 func DoTask(task models.Task) (float64, error) {
+	bounds, ok := arity[task.Operation]
+	if !ok || len(task.Args) < bounds[0] || (bounds[1] >= 0 && len(task.Args) > bounds[1]) {
+		return 0, errs.ErrInvalidExpression
+	}
+	time.Sleep(task.OperationTime)
+
+	args := task.Args
 	switch task.Operation {
 	case "+":
-		time.Sleep(task.OperationTime)
-		return task.Arg1 + task.Arg2, nil
+		return args[0] + args[1], nil
 	case "-":
-		time.Sleep(task.OperationTime)
-		return task.Arg1 - task.Arg2, nil
+		return args[0] - args[1], nil
 	case "*":
-		time.Sleep(task.OperationTime)
-		return task.Arg1 * task.Arg2, nil
+		return args[0] * args[1], nil
 	case "/":
-		time.Sleep(task.OperationTime)
-		if task.Arg2 == 0 {
+		if args[1] == 0 {
+			return 0, errs.ErrDivideByZero
+		}
+		return args[0] / args[1], nil
+	case "^":
+		return math.Pow(args[0], args[1]), nil
+	case "%":
+		if args[1] == 0 {
 			return 0, errs.ErrDivideByZero
 		}
-		return task.Arg1 / task.Arg2, nil
+		return math.Mod(args[0], args[1]), nil
+	case "//":
+		if args[1] == 0 {
+			return 0, errs.ErrDivideByZero
+		}
+		return math.Floor(args[0] / args[1]), nil
+	case "sqrt":
+		if args[0] < 0 {
+			return 0, errs.ErrOutOfDomain
+		}
+		return math.Sqrt(args[0]), nil
+	case "abs":
+		return math.Abs(args[0]), nil
+	case "sin":
+		return math.Sin(args[0]), nil
+	case "cos":
+		return math.Cos(args[0]), nil
+	case "log":
+		if args[0] <= 0 {
+			return 0, errs.ErrOutOfDomain
+		}
+		if len(args) == 1 {
+			return math.Log(args[0]), nil
+		}
+		if args[1] <= 0 || args[1] == 1 {
+			return 0, errs.ErrOutOfDomain
+		}
+		return math.Log(args[0]) / math.Log(args[1]), nil
+	case "min":
+		return slices.Min(args), nil
+	case "max":
+		return slices.Max(args), nil
 	default:
 		return 0, errs.ErrInvalidExpression
 	}
 }
 
+// DoDecimalTask вычисляет задачу в десятичной арифметике и возвращает точный результат строкой.
+// Операторы, abs, min, max и целые степени считаются точно, частное округляется до
+// decimal.DivisionScale знаков. Остальные функции считаются в float64 и переводятся обратно
+func DoDecimalTask(task models.Task) (string, error) {
+	bounds, ok := arity[task.Operation]
+	if !ok || len(task.DecimalArgs) < bounds[0] || (bounds[1] >= 0 && len(task.DecimalArgs) > bounds[1]) {
+		return "", errs.ErrInvalidExpression
+	}
+	args := make([]decimal.Decimal, len(task.DecimalArgs))
+	for i, arg := range task.DecimalArgs {
+		value, err := decimal.Parse(arg)
+		if errors.Is(err, decimal.ErrTooLarge) {
+			return "", errs.ErrOutOfDomain
+		}
+		if err != nil {
+			return "", errs.ErrInvalidExpression
+		}
+		args[i] = value
+	}
+
+	var result decimal.Decimal
+	switch task.Operation {
+	case "+":
+		result = args[0].Add(args[1])
+	case "-":
+		result = args[0].Sub(args[1])
+	case "*":
+		result = args[0].Mul(args[1])
+	case "/", "%", "//":
+		if args[1].Sign() == 0 {
+			return "", errs.ErrDivideByZero
+		}
+		switch task.Operation {
+		case "/":
+			result = args[0].Div(args[1])
+		case "%":
+			result = args[0].Mod(args[1])
+		default:
+			result = args[0].FloorDiv(args[1])
+		}
+	case "^":
+		exponent, ok := args[1].Int()
+		if !ok || exponent > decimal.MaxExponent || exponent < -decimal.MaxExponent {
+			return doFloatTask(task, args)
+		}
+		if exponent < 0 && args[0].Sign() == 0 {
+			return "", errs.ErrDivideByZero
+		}
+		if !args[0].PowFits(exponent) {
+			return "", errs.ErrOutOfDomain
+		}
+		result = args[0].Pow(exponent)
+	case "abs":
+		result = args[0].Abs()
+	case "min":
+		result = slices.MinFunc(args, decimal.Decimal.Cmp)
+	case "max":
+		result = slices.MaxFunc(args, decimal.Decimal.Cmp)
+	default:
+		return doFloatTask(task, args)
+	}
+	if !result.Fits() {
+		return "", errs.ErrOutOfDomain
+	}
+	time.Sleep(task.OperationTime)
+	return result.String(), nil
+}
+
+// doFloatTask вычисляет задачу без точного десятичного алгоритма через DoTask
+func doFloatTask(task models.Task, args []decimal.Decimal) (string, error) {
+	task.Args = make([]float64, len(args))
+	for i, arg := range args {
+		task.Args[i] = arg.Float64()
+	}
+	value, err := DoTask(task)
+	if err != nil {
+		return "", err
+	}
+	result, err := decimal.FromFloat(value)
+	if err != nil {
+		return "", errs.ErrOutOfDomain
+	}
+	return result.String(), nil
+}
+
 // GetTask делает запрос к оркестратору и возвращает задачу
 func (s *AgentService) GetTask() (models.Task, error) {
 	task, err := s.orchestratorAdapter.GetTask()
@@ -147,7 +483,8 @@ func (s *AgentService) GetTask() (models.Task, error) {
 
 // ResultTask отправляет результат вычисления оркестратору
 func (s *AgentService) ResultTask(result models.Result) error {
-	_, err := s.orchestratorAdapter.ResultTask(result.ExpressionID, result.TaskID, result.Result)
+	_, err := s.orchestratorAdapter.ResultTask(result.ExpressionID, result.TaskID,
+		result.Result, result.Decimal, result.Err)
 	if err != nil {
 		if errors.Is(err, errs.ErrTaskNotFound) {
 			return err
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jaam8/web_calculator/agent/internal/models"
+	"github.com/jaam8/web_calculator/agent/internal/ports"
 	errs "github.com/jaam8/web_calculator/common-lib/errors"
 	"github.com/jaam8/web_calculator/common-lib/logger"
 	"github.com/stretchr/testify/assert"
@@ -25,8 +26,32 @@ func (m *MockOrchestratorAdapter) GetTask() (models.Task, error) {
 	return args.Get(0).(models.Task), args.Error(1)
 }
 
-func (m *MockOrchestratorAdapter) ResultTask(expressionID string, taskID int, result float64) (string, error) {
-	args := m.Called(expressionID, taskID, result)
+func (m *MockOrchestratorAdapter) RegisterAgent(id string, capacity int, operations []string) (time.Duration, error) {
+	args := m.Called(id, capacity, operations)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockOrchestratorAdapter) Heartbeat(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockOrchestratorAdapter) Work(ctx context.Context, agentID string) (ports.WorkStream, error) {
+	args := m.Called(ctx, agentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	// как и настоящий поток gRPC, поток закрывается с отменой ctx
+	if stream, ok := args.Get(0).(*mockWorkStream); ok {
+		stream.ctx = ctx
+	}
+	return args.Get(0).(ports.WorkStream), args.Error(1)
+}
+
+func (m *MockOrchestratorAdapter) ResultTask(
+	expressionID string, taskID int, result float64, decimalResult string, taskErr error,
+) (string, error) {
+	args := m.Called(expressionID, taskID, result, decimalResult, taskErr)
 	return args.String(0), args.Error(1)
 }
 
@@ -67,8 +92,7 @@ func TestDoTask(t *testing.T) {
 			task: models.Task{
 				ExpressionID:  "expr1",
 				TaskID:        1,
-				Arg1:          10,
-				Arg2:          5,
+				Args:          []float64{10, 5},
 				Operation:     "+",
 				OperationTime: 0, // Skip sleep in tests
 			},
@@ -80,8 +104,7 @@ func TestDoTask(t *testing.T) {
 			task: models.Task{
 				ExpressionID:  "expr2",
 				TaskID:        2,
-				Arg1:          10,
-				Arg2:          5,
+				Args:          []float64{10, 5},
 				Operation:     "-",
 				OperationTime: 0,
 			},
@@ -93,8 +116,7 @@ func TestDoTask(t *testing.T) {
 			task: models.Task{
 				ExpressionID:  "expr3",
 				TaskID:        3,
-				Arg1:          10,
-				Arg2:          5,
+				Args:          []float64{10, 5},
 				Operation:     "*",
 				OperationTime: 0,
 			},
@@ -106,8 +128,7 @@ func TestDoTask(t *testing.T) {
 			task: models.Task{
 				ExpressionID:  "expr4",
 				TaskID:        4,
-				Arg1:          10,
-				Arg2:          5,
+				Args:          []float64{10, 5},
 				Operation:     "/",
 				OperationTime: 0,
 			},
@@ -119,22 +140,135 @@ func TestDoTask(t *testing.T) {
 			task: models.Task{
 				ExpressionID:  "expr5",
 				TaskID:        5,
-				Arg1:          10,
-				Arg2:          0,
+				Args:          []float64{10, 0},
 				Operation:     "/",
 				OperationTime: 0,
 			},
 			expected:    0,
 			expectedErr: errs.ErrDivideByZero,
 		},
+		{
+			name: "Exponentiation",
+			task: models.Task{
+				ExpressionID:  "expr7",
+				TaskID:        7,
+				Args:          []float64{2, 10},
+				Operation:     "^",
+				OperationTime: 0,
+			},
+			expected:    1024,
+			expectedErr: nil,
+		},
+		{
+			name: "Modulo",
+			task: models.Task{
+				ExpressionID:  "expr8",
+				TaskID:        8,
+				Args:          []float64{-7, 3},
+				Operation:     "%",
+				OperationTime: 0,
+			},
+			expected:    -1,
+			expectedErr: nil,
+		},
+		{
+			name: "Modulo by zero",
+			task: models.Task{
+				ExpressionID:  "expr9",
+				TaskID:        9,
+				Args:          []float64{7, 0},
+				Operation:     "%",
+				OperationTime: 0,
+			},
+			expected:    0,
+			expectedErr: errs.ErrDivideByZero,
+		},
+		{
+			name: "Integer division",
+			task: models.Task{
+				ExpressionID:  "expr10",
+				TaskID:        10,
+				Args:          []float64{-7, 2},
+				Operation:     "//",
+				OperationTime: 0,
+			},
+			expected:    -4,
+			expectedErr: nil,
+		},
+		{
+			name: "Integer division by zero",
+			task: models.Task{
+				ExpressionID:  "expr11",
+				TaskID:        11,
+				Args:          []float64{7, 0},
+				Operation:     "//",
+				OperationTime: 0,
+			},
+			expected:    0,
+			expectedErr: errs.ErrDivideByZero,
+		},
+		{
+			name: "Square root",
+			task: models.Task{
+				ExpressionID: "expr12",
+				TaskID:       12,
+				Args:         []float64{16},
+				Operation:    "sqrt",
+			},
+			expected:    4,
+			expectedErr: nil,
+		},
+		{
+			name: "Square root of negative",
+			task: models.Task{
+				ExpressionID: "expr13",
+				TaskID:       13,
+				Args:         []float64{-1},
+				Operation:    "sqrt",
+			},
+			expected:    0,
+			expectedErr: errs.ErrOutOfDomain,
+		},
+		{
+			name: "Logarithm with base",
+			task: models.Task{
+				ExpressionID: "expr14",
+				TaskID:       14,
+				Args:         []float64{8, 2},
+				Operation:    "log",
+			},
+			expected:    3,
+			expectedErr: nil,
+		},
+		{
+			name: "Max of many arguments",
+			task: models.Task{
+				ExpressionID: "expr15",
+				TaskID:       15,
+				Args:         []float64{3, -1, 7, 2},
+				Operation:    "max",
+			},
+			expected:    7,
+			expectedErr: nil,
+		},
+		{
+			name: "Wrong number of arguments",
+			task: models.Task{
+				ExpressionID: "expr16",
+				TaskID:       16,
+				Args:         []float64{1, 2},
+				Operation:    "abs",
+			},
+			expected:    0,
+			expectedErr: errs.ErrInvalidExpression,
+		},
 		{
 			name: "Invalid operation",
 			task: models.Task{
 				ExpressionID:  "expr6",
 				TaskID:        6,
-				Arg1:          10,
-				Arg2:          5,
-				Operation:     "%",
+				Args:          []float64{10, 5},
+				Operation:     "&",
 				OperationTime: 0,
 			},
 			expected:    0,
@@ -156,6 +290,54 @@ func TestDoTask(t *testing.T) {
 	}
 }
 
+func TestDoDecimalTask(t *testing.T) {
+	tests := []struct {
+		name        string
+		operation   string
+		args        []string
+		expected    string
+		expectedErr error
+	}{
+		{name: "Addition is exact", operation: "+", args: []string{"0.1", "0.2"}, expected: "0.3"},
+		{name: "Subtraction", operation: "-", args: []string{"1", "0.9"}, expected: "0.1"},
+		{name: "Multiplication", operation: "*", args: []string{"1.1", "1.1"}, expected: "1.21"},
+		{name: "Division rounded", operation: "/", args: []string{"1", "3"}, expected: "0.33333333333333333333333333333333"},
+		{name: "Division by zero", operation: "/", args: []string{"1", "0"}, expectedErr: errs.ErrDivideByZero},
+		{name: "Modulo", operation: "%", args: []string{"5.5", "2"}, expected: "1.5"},
+		{name: "Integer division", operation: "//", args: []string{"-7", "2"}, expected: "-4"},
+		{name: "Integer power", operation: "^", args: []string{"1.1", "3"}, expected: "1.331"},
+		{name: "Negative power", operation: "^", args: []string{"2", "-2"}, expected: "0.25"},
+		{name: "Zero to negative power", operation: "^", args: []string{"0", "-1"}, expectedErr: errs.ErrDivideByZero},
+		{name: "Fractional power", operation: "^", args: []string{"4", "0.5"}, expected: "2"},
+		{name: "Power too large", operation: "^", args: []string{"1e300", "1000"}, expectedErr: errs.ErrOutOfDomain},
+		{name: "Product too large", operation: "*", args: []string{"1e6000", "1e6000"}, expectedErr: errs.ErrOutOfDomain},
+		{name: "Operand too large", operation: "+", args: []string{"1e100000", "1"}, expectedErr: errs.ErrOutOfDomain},
+		{name: "Abs", operation: "abs", args: []string{"-0.1"}, expected: "0.1"},
+		{name: "Max", operation: "max", args: []string{"0.1", "0.30", "0.2"}, expected: "0.3"},
+		{name: "Sqrt", operation: "sqrt", args: []string{"2.25"}, expected: "1.5"},
+		{name: "Sqrt out of domain", operation: "sqrt", args: []string{"-1"}, expectedErr: errs.ErrOutOfDomain},
+		{name: "Malformed argument", operation: "+", args: []string{"1", "x"}, expectedErr: errs.ErrInvalidExpression},
+		{name: "Wrong arity", operation: "+", args: []string{"1"}, expectedErr: errs.ErrInvalidExpression},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := DoDecimalTask(models.Task{
+				Operation:   tt.operation,
+				Decimal:     true,
+				DecimalArgs: tt.args,
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestAgentService_GetTask(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -169,8 +351,7 @@ func TestAgentService_GetTask(t *testing.T) {
 				m.On("GetTask").Return(models.Task{
 					ExpressionID:  "expr1",
 					TaskID:        1,
-					Arg1:          10,
-					Arg2:          5,
+					Args:          []float64{10, 5},
 					Operation:     "+",
 					OperationTime: time.Millisecond * 100,
 				}, nil)
@@ -178,8 +359,7 @@ func TestAgentService_GetTask(t *testing.T) {
 			expectedTask: models.Task{
 				ExpressionID:  "expr1",
 				TaskID:        1,
-				Arg1:          10,
-				Arg2:          5,
+				Args:          []float64{10, 5},
 				Operation:     "+",
 				OperationTime: time.Millisecond * 100,
 			},
@@ -208,7 +388,7 @@ func TestAgentService_GetTask(t *testing.T) {
 			mockAdapter := new(MockOrchestratorAdapter)
 			tt.mockSetup(mockAdapter)
 
-			service := NewAgentService(mockAdapter)
+			service := NewAgentService(mockAdapter, "agent-1", nil)
 			task, err := service.GetTask()
 
 			mockAdapter.AssertExpectations(t)
@@ -245,7 +425,7 @@ func TestAgentService_ResultTask(t *testing.T) {
 				Result:       15,
 			},
 			mockSetup: func(m *MockOrchestratorAdapter) {
-				m.On("ResultTask", "expr1", 1, 15.0).Return("ok", nil)
+				m.On("ResultTask", "expr1", 1, 15.0, "", nil).Return("ok", nil)
 			},
 			expectedError: nil,
 		},
@@ -257,7 +437,7 @@ func TestAgentService_ResultTask(t *testing.T) {
 				Result:       20,
 			},
 			mockSetup: func(m *MockOrchestratorAdapter) {
-				m.On("ResultTask", "expr2", 2, 20.0).Return("", errs.ErrTaskNotFound)
+				m.On("ResultTask", "expr2", 2, 20.0, "", nil).Return("", errs.ErrTaskNotFound)
 			},
 			expectedError: errs.ErrTaskNotFound,
 		},
@@ -269,7 +449,7 @@ func TestAgentService_ResultTask(t *testing.T) {
 				Result:       30,
 			},
 			mockSetup: func(m *MockOrchestratorAdapter) {
-				m.On("ResultTask", "expr3", 3, 30.0).Return("", errors.New("connection error"))
+				m.On("ResultTask", "expr3", 3, 30.0, "", nil).Return("", errors.New("connection error"))
 			},
 			expectedError: errors.New("connection error"),
 		},
@@ -280,7 +460,7 @@ func TestAgentService_ResultTask(t *testing.T) {
 			mockAdapter := new(MockOrchestratorAdapter)
 			tt.mockSetup(mockAdapter)
 
-			service := NewAgentService(mockAdapter)
+			service := NewAgentService(mockAdapter, "agent-1", nil)
 			err := service.ResultTask(tt.result)
 
 			mockAdapter.AssertExpectations(t)
@@ -295,32 +475,201 @@ func TestAgentService_ResultTask(t *testing.T) {
 	}
 }
 
-// TestAgentService_Work с помощью контекста с таймаутом
+// mockWorkStream отдаёт задачи из канала, пока не отменён контекст, с которым открыт поток
+type mockWorkStream struct {
+	mock.Mock
+	ctx   context.Context
+	tasks chan models.Task
+}
+
+func (m *mockWorkStream) Ready(slots int) error {
+	args := m.Called(slots)
+	return args.Error(0)
+}
+
+func (m *mockWorkStream) Recv() (models.Task, error) {
+	select {
+	case task := <-m.tasks:
+		return task, nil
+	case <-m.ctx.Done():
+		return models.Task{}, m.ctx.Err()
+	}
+}
+
+func (m *mockWorkStream) Send(result models.Result) error {
+	args := m.Called(result)
+	return args.Error(0)
+}
+
+func (m *mockWorkStream) Close() error {
+	return nil
+}
+
 func TestAgentService_Work(t *testing.T) {
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
-	defer cancel()
+	tests := []struct {
+		name           string
+		task           models.Task
+		expectedResult models.Result
+	}{
+		{
+			name: "float task",
+			task: models.Task{
+				ExpressionID: "expr1",
+				TaskID:       1,
+				Args:         []float64{10, 5},
+				Operation:    "+",
+			},
+			expectedResult: models.Result{ExpressionID: "expr1", TaskID: 1, Result: 15},
+		},
+		{
+			// задача в десятичном режиме возвращает точный результат
+			name: "decimal task",
+			task: models.Task{
+				ExpressionID: "expr1",
+				TaskID:       1,
+				Args:         []float64{0.1, 0.2},
+				Operation:    "+",
+				Decimal:      true,
+				DecimalArgs:  []string{"0.1", "0.2"},
+			},
+			expectedResult: models.Result{ExpressionID: "expr1", TaskID: 1, Result: 0.3, Decimal: "0.3"},
+		},
+		{
+			// ошибка вычисления отправляется оркестратору, а не теряется
+			name: "task error is reported",
+			task: models.Task{
+				ExpressionID: "expr1",
+				TaskID:       1,
+				Args:         []float64{10, 0},
+				Operation:    "/",
+			},
+			expectedResult: models.Result{ExpressionID: "expr1", TaskID: 1, Err: errs.ErrDivideByZero},
+		},
+	}
 
-	mockAdapter := new(MockOrchestratorAdapter)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			ctx, _ = logger.New(ctx)
 
-	mockAdapter.On("GetTask").Return(models.Task{
+			stream := &mockWorkStream{tasks: make(chan models.Task, 1)}
+			stream.On("Ready", 2).Return(nil).Once()
+			stream.On("Send", tt.expectedResult).Return(nil).Once()
+			stream.tasks <- tt.task
+
+			mockAdapter := new(MockOrchestratorAdapter)
+			mockAdapter.On("RegisterAgent", "agent-1", 2, Operations()).Return(time.Second, nil).Once()
+			mockAdapter.On("Work", mock.Anything, "agent-1").Return(stream, nil).Once()
+
+			service := NewAgentService(mockAdapter, "agent-1", nil)
+			service.Work(ctx, 2, 10)
+
+			mockAdapter.AssertExpectations(t)
+			stream.AssertExpectations(t)
+		})
+	}
+}
+
+// TestAgentService_Work_Cancel задача отменённого выражения бросается, не дожидаясь вычисления
+func TestAgentService_Work_Cancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	ctx, _ = logger.New(ctx)
+
+	stream := &mockWorkStream{tasks: make(chan models.Task, 2)}
+	stream.On("Ready", 1).Return(nil).Once()
+	stream.On("Send", models.Result{ExpressionID: "expr1", TaskID: 1, Err: errs.ErrExpressionCancelled}).
+		Return(nil).Once()
+	stream.tasks <- models.Task{
 		ExpressionID:  "expr1",
 		TaskID:        1,
-		Arg1:          10,
-		Arg2:          5,
+		Args:          []float64{10, 5},
 		Operation:     "+",
-		OperationTime: 0,
-	}, nil).Once()
+		OperationTime: time.Second,
+	}
+	stream.tasks <- models.Task{ExpressionID: "expr1", Cancel: true}
+
+	mockAdapter := new(MockOrchestratorAdapter)
+	mockAdapter.On("RegisterAgent", "agent-1", 1, Operations()).Return(time.Second, nil).Once()
+	mockAdapter.On("Work", mock.Anything, "agent-1").Return(stream, nil).Once()
+
+	service := NewAgentService(mockAdapter, "agent-1", nil)
+	service.Work(ctx, 1, 10)
+
+	mockAdapter.AssertExpectations(t)
+	stream.AssertExpectations(t)
+}
+
+// TestAgentService_Work_Reconnect после обрыва поток открывается заново
+func TestAgentService_Work_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	ctx, _ = logger.New(ctx)
 
-	mockAdapter.On("ResultTask", "expr1", 1, 15.0).Return("ok", nil).Once()
+	stream := &mockWorkStream{tasks: make(chan models.Task)}
+	stream.On("Ready", 1).Return(nil).Once()
 
-	mockAdapter.On("GetTask").Return(models.Task{}, errors.New("test timeout")).Maybe()
+	mockAdapter := new(MockOrchestratorAdapter)
+	mockAdapter.On("RegisterAgent", "agent-1", 1, Operations()).Return(time.Second, nil).Twice()
+	mockAdapter.On("Work", mock.Anything, "agent-1").Return(nil, errors.New("connection refused")).Once()
+	mockAdapter.On("Work", mock.Anything, "agent-1").Return(stream, nil).Once()
+
+	service := NewAgentService(mockAdapter, "agent-1", nil)
+	service.Work(ctx, 1, 10)
 
-	service := NewAgentService(mockAdapter)
+	mockAdapter.AssertExpectations(t)
+	stream.AssertExpectations(t)
+}
 
+// TestAgentService_Work_Operations агент регистрируется только с выбранными операциями
+func TestAgentService_Work_Operations(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
 	ctx, _ = logger.New(ctx)
-	go service.Work(ctx, 1)
 
-	<-ctx.Done()
+	stream := &mockWorkStream{tasks: make(chan models.Task)}
+	stream.On("Ready", 1).Return(nil).Once()
+
+	mockAdapter := new(MockOrchestratorAdapter)
+	mockAdapter.On("RegisterAgent", "agent-1", 1, []string{"+", "-"}).Return(time.Second, nil).Once()
+	mockAdapter.On("Work", mock.Anything, "agent-1").Return(stream, nil).Once()
+
+	service := NewAgentService(mockAdapter, "agent-1", []string{"+", "-"})
+	service.Work(ctx, 1, 10)
+
+	mockAdapter.AssertExpectations(t)
+	stream.AssertExpectations(t)
+}
+
+func TestCheckOperations(t *testing.T) {
+	assert.NoError(t, CheckOperations(nil))
+	assert.NoError(t, CheckOperations([]string{"+", "sqrt", "//"}))
+	assert.Error(t, CheckOperations([]string{"+", "tan"}))
+}
+
+// TestAgentService_Work_Reregister оркестратор забыл агента, агент регистрируется заново
+func TestAgentService_Work_Reregister(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	ctx, _ = logger.New(ctx)
+
+	forgotten := &mockWorkStream{tasks: make(chan models.Task)}
+	forgotten.On("Ready", 1).Return(nil).Once()
+	stream := &mockWorkStream{tasks: make(chan models.Task)}
+	stream.On("Ready", 1).Return(nil).Once()
+
+	mockAdapter := new(MockOrchestratorAdapter)
+	mockAdapter.On("RegisterAgent", "agent-1", 1, Operations()).Return(10*time.Millisecond, nil).Once()
+	mockAdapter.On("Work", mock.Anything, "agent-1").Return(forgotten, nil).Once()
+	mockAdapter.On("Heartbeat", "agent-1").Return(errs.ErrAgentNotFound).Once()
+	mockAdapter.On("RegisterAgent", "agent-1", 1, Operations()).Return(time.Second, nil).Once()
+	mockAdapter.On("Work", mock.Anything, "agent-1").Return(stream, nil).Once()
+
+	service := NewAgentService(mockAdapter, "agent-1", nil)
+	service.Work(ctx, 1, 10)
 
 	mockAdapter.AssertExpectations(t)
+	forgotten.AssertExpectations(t)
+	stream.AssertExpectations(t)
 }
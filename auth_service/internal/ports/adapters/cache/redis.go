@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/go-redis/redis/v7"
 	errs "github.com/jaam8/web_calculator/common-lib/errors"
+	"strconv"
 	"time"
 )
 
@@ -21,6 +22,22 @@ func accessKey(token string) string {
 	return fmt.Sprintf("access:%s", token)
 }
 
+// tokensKey is the index of the user's tokens: a sorted set of token keys scored by their expiration
+func tokensKey(userID string) string {
+	return fmt.Sprintf("tokens:%s", userID)
+}
+
+// revokeScript deletes every token from the index and the index itself, returns how many tokens were deleted
+var revokeScript = redis.NewScript(`
+local keys = redis.call("ZRANGE", KEYS[1], 0, -1)
+local revoked = 0
+for _, key in ipairs(keys) do
+    revoked = revoked + redis.call("DEL", key)
+end
+redis.call("DEL", KEYS[1])
+return revoked
+`)
+
 func NewAuthCacheAdapter(client *redis.Client, refreshExpiration, accessExpiration time.Duration) *AuthCacheAdapter {
 	return &AuthCacheAdapter{
 		client:            client,
@@ -29,18 +46,23 @@ func NewAuthCacheAdapter(client *redis.Client, refreshExpiration, accessExpirati
 	}
 }
 
+// SaveToken saves the token and adds it to the index of the user's tokens,
+// expired tokens are removed from the index on the way
 func (a *AuthCacheAdapter) SaveToken(token, userID string, refresh bool) error {
-	switch refresh {
-	case true:
-		result := a.client.Set(refreshKey(token), userID, a.RefreshExpiration)
-		if result.Err() != nil {
-			return fmt.Errorf("failed to save refresh token: %w", result.Err())
-		}
-	case false:
-		result := a.client.Set(accessKey(token), userID, a.AccessExpiration)
-		if result.Err() != nil {
-			return fmt.Errorf("failed to save access token: %w", result.Err())
-		}
+	key, ttl, kind := accessKey(token), a.AccessExpiration, "access"
+	if refresh {
+		key, ttl, kind = refreshKey(token), a.RefreshExpiration, "refresh"
+	}
+	now := time.Now()
+	index := tokensKey(userID)
+
+	pipe := a.client.TxPipeline()
+	pipe.Set(key, userID, ttl)
+	pipe.ZAdd(index, &redis.Z{Score: float64(now.Add(ttl).UnixMilli()), Member: key})
+	pipe.ZRemRangeByScore(index, "-inf", strconv.FormatInt(now.UnixMilli(), 10))
+	pipe.Expire(index, max(a.RefreshExpiration, a.AccessExpiration))
+	if _, err := pipe.Exec(); err != nil {
+		return fmt.Errorf("failed to save %s token: %w", kind, err)
 	}
 	return nil
 }
@@ -81,3 +103,12 @@ func (a *AuthCacheAdapter) DeleteToken(token string, refresh bool) error {
 	}
 	return nil
 }
+
+// DeleteUserTokens deletes every token of the user and returns how many of them were still valid
+func (a *AuthCacheAdapter) DeleteUserTokens(userID string) (int, error) {
+	revoked, err := revokeScript.Run(a.client, []string{tokensKey(userID)}).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tokens of user: %w", err)
+	}
+	return revoked, nil
+}
@@ -9,4 +9,6 @@ type CacheAdapter interface {
 	SaveToken(token, userID string, refresh bool) error
 	GetToken(token string, refresh bool) (string, error)
 	DeleteToken(token string, refresh bool) error
+	// DeleteUserTokens revokes every token of the user and returns how many of them were still valid
+	DeleteUserTokens(userID string) (int, error)
 }
@@ -245,3 +245,110 @@ func (s *AuthService) Refresh(ctx context.Context, req *auth_service.RefreshRequ
 		RefreshToken: refreshToken,
 	}, nil
 }
+
+func (s *AuthService) Logout(ctx context.Context, req *auth_service.LogoutRequest) (*auth_service.LogoutResponse, error) {
+	userID, err := s.authorize(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.cache.DeleteToken(req.AccessToken, false)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to delete access token from cache",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to delete access token from cache: %w", err)
+	}
+
+	// a refresh token of another user or an already revoked one is skipped
+	if req.GetRefreshToken() != "" {
+		owner, err := s.cache.GetToken(req.RefreshToken, true)
+		switch {
+		case errors.Is(err, errs.ErrTokenExpired):
+		case err != nil:
+			logger.GetLoggerFromCtx(ctx).Error(ctx,
+				"failed to get refresh_token from cache",
+				zap.String("user_id", userID),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to get refresh_token from cache: %w", err)
+		case owner == userID:
+			err = s.cache.DeleteToken(req.RefreshToken, true)
+			if err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx,
+					"failed to delete refresh token from cache",
+					zap.String("user_id", userID),
+					zap.Error(err))
+				return nil, fmt.Errorf("failed to delete refresh token from cache: %w", err)
+			}
+		}
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"user logged out successfully",
+		zap.String("user_id", userID))
+	return &auth_service.LogoutResponse{}, nil
+}
+
+func (s *AuthService) LogoutAll(ctx context.Context, req *auth_service.LogoutAllRequest) (*auth_service.LogoutAllResponse, error) {
+	userID, err := s.authorize(ctx, req.GetAccessToken())
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.cache.DeleteUserTokens(userID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to delete user tokens from cache",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to delete user tokens from cache: %w", err)
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		"user logged out from all devices successfully",
+		zap.String("user_id", userID),
+		zap.Int("revoked_tokens", revoked))
+	return &auth_service.LogoutAllResponse{RevokedTokens: int32(revoked)}, nil
+}
+
+// authorize checks that the access token is signed, not expired and not revoked and returns its user ID
+func (s *AuthService) authorize(ctx context.Context, accessToken string) (string, error) {
+	if accessToken == "" {
+		return "", errs.ErrInvalidToken
+	}
+
+	sub, isRefresh, _, err := utils.ParseJWT(accessToken, s.jwtSecret)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to parse access jwt token",
+			zap.Error(err))
+		return "", err
+	}
+	if isRefresh {
+		return "", errs.ErrInvalidToken
+	}
+
+	userID, err := s.cache.GetToken(accessToken, false)
+	if err != nil {
+		if errors.Is(err, errs.ErrTokenExpired) {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"access token is revoked",
+				zap.String("sub", sub))
+			return "", errs.ErrInvalidToken
+		}
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get access token from cache",
+			zap.String("sub", sub),
+			zap.Error(err))
+		return "", fmt.Errorf("failed to get access token from cache: %w", err)
+	}
+	if userID != sub {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"access token user id does not match",
+			zap.String("user_id", userID),
+			zap.String("sub", sub))
+		return "", errs.ErrInvalidToken
+	}
+	return userID, nil
+}
@@ -32,6 +32,11 @@ func (m *MockCacheAdapter) DeleteToken(token string, refresh bool) error {
 	return args.Error(0)
 }
 
+func (m *MockCacheAdapter) DeleteUserTokens(userID string) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
 type MockStorageAdapter struct {
 	mock.Mock
 }
@@ -285,3 +290,162 @@ func TestAuthService_Refresh(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_Logout(t *testing.T) {
+	accessToken, err := utils.GenerateJWT("user123", "secret", false, time.Minute)
+	require.NoError(t, err)
+	refreshToken, err := utils.GenerateJWT("user123", "secret", true, time.Minute)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		accessToken  string
+		refreshToken string
+		mockSetup    func(cache *MockCacheAdapter)
+		expectedErr  error
+	}{
+		{
+			name:        "empty token",
+			accessToken: "",
+			expectedErr: errs.ErrInvalidToken,
+		},
+		{
+			name:        "refresh token instead of access token",
+			accessToken: refreshToken,
+			expectedErr: errs.ErrInvalidToken,
+		},
+		{
+			name:        "revoked token",
+			accessToken: accessToken,
+			mockSetup: func(cache *MockCacheAdapter) {
+				cache.On("GetToken", accessToken, false).Return("", errs.ErrTokenExpired)
+			},
+			expectedErr: errs.ErrInvalidToken,
+		},
+		{
+			name:         "successful logout",
+			accessToken:  accessToken,
+			refreshToken: refreshToken,
+			mockSetup: func(cache *MockCacheAdapter) {
+				cache.On("GetToken", accessToken, false).Return("user123", nil)
+				cache.On("DeleteToken", accessToken, false).Return(nil)
+				cache.On("GetToken", refreshToken, true).Return("user123", nil)
+				cache.On("DeleteToken", refreshToken, true).Return(nil)
+			},
+		},
+		{
+			name:         "refresh token of another user is kept",
+			accessToken:  accessToken,
+			refreshToken: refreshToken,
+			mockSetup: func(cache *MockCacheAdapter) {
+				cache.On("GetToken", accessToken, false).Return("user123", nil)
+				cache.On("DeleteToken", accessToken, false).Return(nil)
+				cache.On("GetToken", refreshToken, true).Return("user456", nil)
+			},
+		},
+		{
+			name:         "already revoked refresh token",
+			accessToken:  accessToken,
+			refreshToken: refreshToken,
+			mockSetup: func(cache *MockCacheAdapter) {
+				cache.On("GetToken", accessToken, false).Return("user123", nil)
+				cache.On("DeleteToken", accessToken, false).Return(nil)
+				cache.On("GetToken", refreshToken, true).Return("", errs.ErrTokenExpired)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := new(MockCacheAdapter)
+			if tt.mockSetup != nil {
+				tt.mockSetup(cache)
+			}
+
+			service := NewAuthService(nil, cache, "secret",
+				time.Minute, time.Minute)
+
+			req := &auth_service.LogoutRequest{
+				AccessToken:  tt.accessToken,
+				RefreshToken: tt.refreshToken,
+			}
+
+			ctx := context.Background()
+			ctx, _ = logger.New(ctx)
+			resp, err := service.Logout(ctx, req)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+			}
+
+			cache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_LogoutAll(t *testing.T) {
+	accessToken, err := utils.GenerateJWT("user123", "secret", false, time.Minute)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		accessToken     string
+		mockSetup       func(cache *MockCacheAdapter)
+		expectedRevoked int32
+		expectedErr     error
+	}{
+		{
+			name:        "invalid token",
+			accessToken: "invalid",
+			expectedErr: errs.ErrInvalidToken,
+		},
+		{
+			name:        "token of another user",
+			accessToken: accessToken,
+			mockSetup: func(cache *MockCacheAdapter) {
+				cache.On("GetToken", accessToken, false).Return("user456", nil)
+			},
+			expectedErr: errs.ErrInvalidToken,
+		},
+		{
+			name:        "successful logout",
+			accessToken: accessToken,
+			mockSetup: func(cache *MockCacheAdapter) {
+				cache.On("GetToken", accessToken, false).Return("user123", nil)
+				cache.On("DeleteUserTokens", "user123").Return(4, nil)
+			},
+			expectedRevoked: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := new(MockCacheAdapter)
+			if tt.mockSetup != nil {
+				tt.mockSetup(cache)
+			}
+
+			service := NewAuthService(nil, cache, "secret",
+				time.Minute, time.Minute)
+
+			ctx := context.Background()
+			ctx, _ = logger.New(ctx)
+			resp, err := service.LogoutAll(ctx, &auth_service.LogoutAllRequest{AccessToken: tt.accessToken})
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				require.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedRevoked, resp.GetRevokedTokens())
+			}
+
+			cache.AssertExpectations(t)
+			cache.AssertNotCalled(t, "DeleteUserTokens", "user456")
+		})
+	}
+}
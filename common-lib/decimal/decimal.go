@@ -0,0 +1,273 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DivisionScale количество знаков после запятой, до которого округляется частное
+const DivisionScale = 32
+
+// MaxExponent наибольший по модулю целый показатель степени, который считается точно
+const MaxExponent = 1000
+
+// MaxDigits наибольшее количество цифр числа и знаков после запятой. Без ограничения одна
+// степень вроде (1e300^1000)^1000 заняла бы агент на минуты, а результат не влез бы в сообщение gRPC
+const MaxDigits = 10000
+
+// maxBits количество бит, которого хватает на MaxDigits десятичных цифр, maxCoef = 10^MaxDigits
+var (
+	maxBits = int(math.Ceil(MaxDigits * math.Log2(10)))
+	maxCoef = new(big.Int).Exp(big.NewInt(10), big.NewInt(MaxDigits), nil)
+)
+
+// ErrTooLarge число не помещается в MaxDigits цифр
+var ErrTooLarge = errors.New("decimal exceeds max digits")
+
+var ten = big.NewInt(10)
+
+// Decimal десятичное число произвольной точности: coef * 10^-scale
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// Parse разбирает число вида 12, -1.5, .5, 2., 1e-3
+func Parse(s string) (Decimal, error) {
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("malformed decimal %q", s)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+	// проверка до вычисления 10^exponent, иначе на 1e1000000000 уйдёт вся память
+	if exponent > MaxDigits || exponent < -MaxDigits || len(mantissa) > MaxDigits+2 {
+		return Decimal{}, ErrTooLarge
+	}
+
+	negative := false
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		negative = mantissa[0] == '-'
+		mantissa = mantissa[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("malformed decimal %q", s)
+	}
+
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		coef.Neg(coef)
+	}
+	scale := len(fracPart) - exponent
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	d := Decimal{coef: coef, scale: scale}.normalize()
+	if !d.Fits() {
+		return Decimal{}, ErrTooLarge
+	}
+	return d, nil
+}
+
+// FromFloat переводит float64 в десятичное число по кратчайшему точному представлению
+func FromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("%v cannot be represented as decimal", f)
+	}
+	return Parse(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// String возвращает число без экспоненты и без незначащих нулей
+func (d Decimal) String() string {
+	if d.coef == nil {
+		return "0"
+	}
+	digits := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+	point := len(digits) - d.scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 возвращает ближайшее к числу значение float64
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// IsInteger сообщает, что у числа нет дробной части
+func (d Decimal) IsInteger() bool {
+	return d.normalize().scale == 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Add(a, b), scale: scale}.normalize()
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Sub(a, b), scale: scale}.normalize()
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	coef := new(big.Int).Mul(d.int(), other.int())
+	return Decimal{coef: coef, scale: d.scale + other.scale}.normalize()
+}
+
+// Div делит число, округляя частное до DivisionScale знаков после запятой
+// (половина округляется от нуля). Делитель не должен быть равен нулю
+func (d Decimal) Div(other Decimal) Decimal {
+	num, den := d.ratio(other)
+	num.Mul(num, pow10(DivisionScale))
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		rem.Abs(rem).Lsh(rem, 1)
+		if rem.Cmp(new(big.Int).Abs(den)) >= 0 {
+			if num.Sign() == den.Sign() {
+				quo.Add(quo, big.NewInt(1))
+			} else {
+				quo.Sub(quo, big.NewInt(1))
+			}
+		}
+	}
+	return Decimal{coef: quo, scale: DivisionScale}.normalize()
+}
+
+// FloorDiv возвращает частное, округлённое вниз, как math.Floor(a / b).
+// Делитель не должен быть равен нулю
+func (d Decimal) FloorDiv(other Decimal) Decimal {
+	num, den := d.ratio(other)
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 && rem.Sign() != den.Sign() {
+		quo.Sub(quo, big.NewInt(1))
+	}
+	return Decimal{coef: quo}
+}
+
+// Mod возвращает остаток со знаком делимого, как math.Mod.
+// Делитель не должен быть равен нулю
+func (d Decimal) Mod(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Rem(a, b), scale: scale}.normalize()
+}
+
+// Fits сообщает, что у числа не больше MaxDigits цифр и не больше MaxDigits знаков после запятой
+func (d Decimal) Fits() bool {
+	coef := d.int()
+	if coef.BitLen() > maxBits || d.scale > MaxDigits {
+		return false
+	}
+	return coef.CmpAbs(maxCoef) < 0
+}
+
+// PowFits сообщает, поместится ли d^exponent в MaxDigits цифр, не вычисляя степень
+func (d Decimal) PowFits(exponent int) bool {
+	n := d.normalize()
+	if exponent < 0 {
+		exponent = -exponent
+	}
+	// |coef| >= 2^(BitLen-1), поэтому в степени у него не меньше (BitLen-1)*exponent бит
+	bits := max(n.int().BitLen()-1, 0)
+	return bits*exponent <= maxBits && n.scale*exponent <= MaxDigits
+}
+
+// Pow возводит число в целую степень, |exponent| не больше MaxExponent.
+// При отрицательном показателе основание не должно быть равно нулю
+func (d Decimal) Pow(exponent int) Decimal {
+	if exponent < 0 {
+		one := Decimal{coef: big.NewInt(1)}
+		return one.Div(d.Pow(-exponent))
+	}
+	coef := new(big.Int).Exp(d.int(), big.NewInt(int64(exponent)), nil)
+	return Decimal{coef: coef, scale: d.scale * exponent}.normalize()
+}
+
+// Int возвращает значение целого числа, если оно помещается в int
+func (d Decimal) Int() (int, bool) {
+	n := d.normalize()
+	if n.scale != 0 || !n.coef.IsInt64() {
+		return 0, false
+	}
+	value := n.coef.Int64()
+	return int(value), int64(int(value)) == value
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// ratio возвращает числитель и знаменатель дроби d / other
+func (d Decimal) ratio(other Decimal) (*big.Int, *big.Int) {
+	num := new(big.Int).Mul(d.int(), pow10(other.scale))
+	den := new(big.Int).Mul(other.int(), pow10(d.scale))
+	return num, den
+}
+
+// normalize убирает незначащие нули после запятой
+func (d Decimal) normalize() Decimal {
+	coef := new(big.Int).Set(d.int())
+	scale := d.scale
+	if coef.Sign() == 0 {
+		return Decimal{coef: coef}
+	}
+	rem := new(big.Int)
+	for scale > 0 {
+		quo, r := new(big.Int).QuoRem(coef, ten, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		coef = quo
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// align приводит числа к общему количеству знаков после запятой
+func align(a, b Decimal) (*big.Int, *big.Int, int) {
+	scale := max(a.scale, b.scale)
+	x := new(big.Int).Mul(a.int(), pow10(scale-a.scale))
+	y := new(big.Int).Mul(b.int(), pow10(scale-b.scale))
+	return x, y, scale
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
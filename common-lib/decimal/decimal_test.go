@@ -0,0 +1,129 @@
+package decimal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := Parse(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{input: "12", expected: "12"},
+		{input: "-1.50", expected: "-1.5"},
+		{input: ".5", expected: "0.5"},
+		{input: "2.", expected: "2"},
+		{input: "1e-3", expected: "0.001"},
+		{input: "1.25E+2", expected: "125"},
+		{input: "-0.000", expected: "0"},
+		{input: "", wantErr: true},
+		{input: "1.2.3", wantErr: true},
+		{input: "--1", wantErr: true},
+		{input: "1e", wantErr: true},
+		{input: "1e1000000000", wantErr: true},
+		{input: "1e-1000000000", wantErr: true},
+		{input: "1e10000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := Parse(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, d.String())
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b Decimal) Decimal
+		a, b     string
+		expected string
+	}{
+		{name: "add", op: Decimal.Add, a: "0.1", b: "0.2", expected: "0.3"},
+		{name: "sub", op: Decimal.Sub, a: "1", b: "0.9", expected: "0.1"},
+		{name: "mul", op: Decimal.Mul, a: "1.1", b: "1.1", expected: "1.21"},
+		{name: "div exact", op: Decimal.Div, a: "1", b: "8", expected: "0.125"},
+		{name: "div rounded", op: Decimal.Div, a: "2", b: "3", expected: "0.66666666666666666666666666666667"},
+		{name: "div negative rounded", op: Decimal.Div, a: "-2", b: "3", expected: "-0.66666666666666666666666666666667"},
+		{name: "floor div", op: Decimal.FloorDiv, a: "7.5", b: "2", expected: "3"},
+		{name: "floor div negative", op: Decimal.FloorDiv, a: "-7", b: "2", expected: "-4"},
+		{name: "mod", op: Decimal.Mod, a: "5.5", b: "2", expected: "1.5"},
+		{name: "mod negative", op: Decimal.Mod, a: "-7", b: "3", expected: "-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.op(mustParse(t, tt.a), mustParse(t, tt.b))
+			assert.Equal(t, tt.expected, result.String())
+		})
+	}
+}
+
+func TestPow(t *testing.T) {
+	assert.Equal(t, "1.21", mustParse(t, "1.1").Pow(2).String())
+	assert.Equal(t, "0.25", mustParse(t, "2").Pow(-2).String())
+	assert.Equal(t, "1", mustParse(t, "5").Pow(0).String())
+}
+
+func TestPowFits(t *testing.T) {
+	tests := []struct {
+		base     string
+		exponent int
+		fits     bool
+	}{
+		{base: "2", exponent: 1000, fits: true},
+		{base: "1e300", exponent: 30, fits: true},
+		{base: "1e300", exponent: 1000, fits: false},
+		{base: "1e300", exponent: -1000, fits: false},
+		{base: "0.001", exponent: 1000, fits: true},
+		{base: "1e-20", exponent: 1000, fits: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.base, func(t *testing.T) {
+			base := mustParse(t, tt.base)
+			assert.Equal(t, tt.fits, base.PowFits(tt.exponent))
+			if tt.fits && tt.exponent > 0 {
+				assert.True(t, base.Pow(tt.exponent).Fits())
+			}
+		})
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	d, err := FromFloat(0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.1", d.String())
+
+	d, err = FromFloat(1e21)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000", d.String())
+	assert.Equal(t, 1e21, d.Float64())
+
+	_, err = FromFloat(math.Inf(-1))
+	assert.Error(t, err)
+}
+
+func TestInt(t *testing.T) {
+	n, ok := mustParse(t, "3.0").Int()
+	assert.True(t, ok)
+	assert.Equal(t, 3, n)
+
+	_, ok = mustParse(t, "3.5").Int()
+	assert.False(t, ok)
+	assert.True(t, mustParse(t, "-2.000").IsInteger())
+}
@@ -10,6 +10,21 @@ var (
 	ErrInternalServerError = errors.New("internal server error")
 	ErrInvalidExpression   = errors.New("invalid expression")
 	ErrDivideByZero        = errors.New("division by zero")
+	ErrOutOfDomain         = errors.New("argument out of domain")
+	ErrVariableNotFound    = errors.New("variable not found")
+	ErrInvalidVariableName = errors.New("invalid variable name")
+	ErrAgentNotFound       = errors.New("agent not found")
+	ErrInvalidAgent        = errors.New("invalid agent")
+	ErrInvalidPriority     = errors.New("invalid priority")
+	ErrQueueFull           = errors.New("calculation queue is full")
+	ErrExpressionCancelled = errors.New("cancelled")
+	ErrExpressionFinished  = errors.New("expression is already finished")
+	ErrExpressionTimedOut  = errors.New("timed out")
+	ErrInvalidTimeout      = errors.New("invalid timeout")
+	ErrInvalidBatch        = errors.New("invalid batch")
+	ErrBatchNotFound       = errors.New("batch not found")
+	ErrInvalidPageSize     = errors.New("invalid page size")
+	ErrInvalidCursor       = errors.New("invalid cursor")
 	ErrInvalidToken        = errors.New("invalid token")
 	ErrTokenExpired        = errors.New("token expired")
 	ErrUserNotFound        = errors.New("user not found")
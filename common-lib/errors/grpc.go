@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"errors"
+	"google.golang.org/grpc/status"
+)
+
+var sentinels = []error{
+	ErrTaskNotFound,
+	ErrExpressionNotFound,
+	ErrInternalServerError,
+	ErrInvalidExpression,
+	ErrDivideByZero,
+	ErrOutOfDomain,
+	ErrVariableNotFound,
+	ErrInvalidVariableName,
+	ErrAgentNotFound,
+	ErrInvalidAgent,
+	ErrInvalidPriority,
+	ErrQueueFull,
+	ErrExpressionCancelled,
+	ErrExpressionFinished,
+	ErrExpressionTimedOut,
+	ErrInvalidTimeout,
+	ErrInvalidBatch,
+	ErrBatchNotFound,
+	ErrInvalidPageSize,
+	ErrInvalidCursor,
+	ErrInvalidToken,
+	ErrTokenExpired,
+	ErrUserNotFound,
+	ErrUserAlreadyExists,
+	ErrEmptyLogin,
+	ErrEmptyPassword,
+	ErrWrongPassword,
+}
+
+// FromGRPC returns the sentinel error sent by a gRPC server, since only the
+// message of the original error survives the call.
+//
+// returns err itself if it is not a gRPC error or its message is unknown
+func FromGRPC(err error) error {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return err
+	}
+	message := grpcErr.GRPCStatus().Message()
+	for _, sentinel := range sentinels {
+		if message == sentinel.Error() {
+			return sentinel
+		}
+	}
+	return err
+}
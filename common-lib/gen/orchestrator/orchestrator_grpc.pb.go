@@ -20,11 +20,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrchestratorService_Calculate_FullMethodName      = "/api.OrchestratorService/Calculate"
-	OrchestratorService_GetTask_FullMethodName        = "/api.OrchestratorService/GetTask"
-	OrchestratorService_ResultTask_FullMethodName     = "/api.OrchestratorService/ResultTask"
-	OrchestratorService_Expressions_FullMethodName    = "/api.OrchestratorService/Expressions"
-	OrchestratorService_ExpressionById_FullMethodName = "/api.OrchestratorService/ExpressionById"
+	OrchestratorService_Calculate_FullMethodName        = "/api.OrchestratorService/Calculate"
+	OrchestratorService_CalculateBatch_FullMethodName   = "/api.OrchestratorService/CalculateBatch"
+	OrchestratorService_BatchProgress_FullMethodName    = "/api.OrchestratorService/BatchProgress"
+	OrchestratorService_Explain_FullMethodName          = "/api.OrchestratorService/Explain"
+	OrchestratorService_GetTask_FullMethodName          = "/api.OrchestratorService/GetTask"
+	OrchestratorService_ResultTask_FullMethodName       = "/api.OrchestratorService/ResultTask"
+	OrchestratorService_Work_FullMethodName             = "/api.OrchestratorService/Work"
+	OrchestratorService_RegisterAgent_FullMethodName    = "/api.OrchestratorService/RegisterAgent"
+	OrchestratorService_Heartbeat_FullMethodName        = "/api.OrchestratorService/Heartbeat"
+	OrchestratorService_ListAgents_FullMethodName       = "/api.OrchestratorService/ListAgents"
+	OrchestratorService_CacheStats_FullMethodName       = "/api.OrchestratorService/CacheStats"
+	OrchestratorService_Expressions_FullMethodName      = "/api.OrchestratorService/Expressions"
+	OrchestratorService_ExpressionById_FullMethodName   = "/api.OrchestratorService/ExpressionById"
+	OrchestratorService_ExpressionTasks_FullMethodName  = "/api.OrchestratorService/ExpressionTasks"
+	OrchestratorService_WatchExpression_FullMethodName  = "/api.OrchestratorService/WatchExpression"
+	OrchestratorService_CancelExpression_FullMethodName = "/api.OrchestratorService/CancelExpression"
+	OrchestratorService_SetVariable_FullMethodName      = "/api.OrchestratorService/SetVariable"
+	OrchestratorService_Variables_FullMethodName        = "/api.OrchestratorService/Variables"
+	OrchestratorService_VariableByName_FullMethodName   = "/api.OrchestratorService/VariableByName"
+	OrchestratorService_DeleteVariable_FullMethodName   = "/api.OrchestratorService/DeleteVariable"
 )
 
 // OrchestratorServiceClient is the client API for OrchestratorService service.
@@ -32,10 +47,41 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrchestratorServiceClient interface {
 	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+	// saves all parsed expressions of the batch at once, expressions that cannot be parsed
+	// are reported per item and do not reject the whole batch
+	CalculateBatch(ctx context.Context, in *CalculateBatchRequest, opts ...grpc.CallOption) (*CalculateBatchResponse, error)
+	// counts expressions of a batch by status
+	BatchProgress(ctx context.Context, in *BatchProgressRequest, opts ...grpc.CallOption) (*BatchProgressResponse, error)
+	// shows how the expression would be split into tasks and how long it would take,
+	// nothing is saved or computed
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error)
+	// hands out the first queued task of any operation, agents should prefer Work
+	// which only gets tasks of the operations the agent registered with
 	GetTask(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTaskResponse, error)
 	ResultTask(ctx context.Context, in *ResultTaskRequest, opts ...grpc.CallOption) (*ResultTaskResponse, error)
+	// long-lived channel of an agent: the orchestrator pushes tasks as soon as they are created
+	// and the agent streams results back
+	Work(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkRequest, WorkResponse], error)
+	// an agent registers on startup and then keeps itself alive with heartbeats
+	RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// lists agents that sent a heartbeat recently
+	ListAgents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// reports how many tasks the result cache saved since the orchestrator started
+	CacheStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CacheStatsResponse, error)
 	Expressions(ctx context.Context, in *ExpressionsRequest, opts ...grpc.CallOption) (*ExpressionsResponse, error)
 	ExpressionById(ctx context.Context, in *ExpressionByIdRequest, opts ...grpc.CallOption) (*ExpressionByIdResponse, error)
+	// tasks the expression was computed with: operands, results, agents and timings
+	ExpressionTasks(ctx context.Context, in *ExpressionTasksRequest, opts ...grpc.CallOption) (*ExpressionTasksResponse, error)
+	// streams status transitions of the expression until it is done or failed
+	WatchExpression(ctx context.Context, in *WatchExpressionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExpressionEvent], error)
+	// stops a pending expression: its queued tasks are dropped, agents abandon
+	// the computing ones and the expression gets the cancelled status
+	CancelExpression(ctx context.Context, in *CancelExpressionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetVariable(ctx context.Context, in *SetVariableRequest, opts ...grpc.CallOption) (*SetVariableResponse, error)
+	Variables(ctx context.Context, in *VariablesRequest, opts ...grpc.CallOption) (*VariablesResponse, error)
+	VariableByName(ctx context.Context, in *VariableByNameRequest, opts ...grpc.CallOption) (*VariableByNameResponse, error)
+	DeleteVariable(ctx context.Context, in *DeleteVariableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type orchestratorServiceClient struct {
@@ -56,6 +102,36 @@ func (c *orchestratorServiceClient) Calculate(ctx context.Context, in *Calculate
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) CalculateBatch(ctx context.Context, in *CalculateBatchRequest, opts ...grpc.CallOption) (*CalculateBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateBatchResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_CalculateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) BatchProgress(ctx context.Context, in *BatchProgressRequest, opts ...grpc.CallOption) (*BatchProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchProgressResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_BatchProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*ExplainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExplainResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_Explain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orchestratorServiceClient) GetTask(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTaskResponse)
@@ -76,6 +152,59 @@ func (c *orchestratorServiceClient) ResultTask(ctx context.Context, in *ResultTa
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) Work(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[WorkRequest, WorkResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrchestratorService_ServiceDesc.Streams[0], OrchestratorService_Work_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WorkRequest, WorkResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrchestratorService_WorkClient = grpc.BidiStreamingClient[WorkRequest, WorkResponse]
+
+func (c *orchestratorServiceClient) RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterAgentResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_RegisterAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OrchestratorService_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) ListAgents(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAgentsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ListAgents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) CacheStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CacheStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CacheStatsResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_CacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orchestratorServiceClient) Expressions(ctx context.Context, in *ExpressionsRequest, opts ...grpc.CallOption) (*ExpressionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ExpressionsResponse)
@@ -96,15 +225,125 @@ func (c *orchestratorServiceClient) ExpressionById(ctx context.Context, in *Expr
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) ExpressionTasks(ctx context.Context, in *ExpressionTasksRequest, opts ...grpc.CallOption) (*ExpressionTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpressionTasksResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ExpressionTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) WatchExpression(ctx context.Context, in *WatchExpressionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExpressionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrchestratorService_ServiceDesc.Streams[1], OrchestratorService_WatchExpression_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchExpressionRequest, ExpressionEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrchestratorService_WatchExpressionClient = grpc.ServerStreamingClient[ExpressionEvent]
+
+func (c *orchestratorServiceClient) CancelExpression(ctx context.Context, in *CancelExpressionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OrchestratorService_CancelExpression_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) SetVariable(ctx context.Context, in *SetVariableRequest, opts ...grpc.CallOption) (*SetVariableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetVariableResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_SetVariable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) Variables(ctx context.Context, in *VariablesRequest, opts ...grpc.CallOption) (*VariablesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VariablesResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_Variables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) VariableByName(ctx context.Context, in *VariableByNameRequest, opts ...grpc.CallOption) (*VariableByNameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VariableByNameResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_VariableByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorServiceClient) DeleteVariable(ctx context.Context, in *DeleteVariableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, OrchestratorService_DeleteVariable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServiceServer is the server API for OrchestratorService service.
 // All implementations must embed UnimplementedOrchestratorServiceServer
 // for forward compatibility.
 type OrchestratorServiceServer interface {
 	Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error)
+	// saves all parsed expressions of the batch at once, expressions that cannot be parsed
+	// are reported per item and do not reject the whole batch
+	CalculateBatch(context.Context, *CalculateBatchRequest) (*CalculateBatchResponse, error)
+	// counts expressions of a batch by status
+	BatchProgress(context.Context, *BatchProgressRequest) (*BatchProgressResponse, error)
+	// shows how the expression would be split into tasks and how long it would take,
+	// nothing is saved or computed
+	Explain(context.Context, *ExplainRequest) (*ExplainResponse, error)
+	// hands out the first queued task of any operation, agents should prefer Work
+	// which only gets tasks of the operations the agent registered with
 	GetTask(context.Context, *emptypb.Empty) (*GetTaskResponse, error)
 	ResultTask(context.Context, *ResultTaskRequest) (*ResultTaskResponse, error)
+	// long-lived channel of an agent: the orchestrator pushes tasks as soon as they are created
+	// and the agent streams results back
+	Work(grpc.BidiStreamingServer[WorkRequest, WorkResponse]) error
+	// an agent registers on startup and then keeps itself alive with heartbeats
+	RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*emptypb.Empty, error)
+	// lists agents that sent a heartbeat recently
+	ListAgents(context.Context, *emptypb.Empty) (*ListAgentsResponse, error)
+	// reports how many tasks the result cache saved since the orchestrator started
+	CacheStats(context.Context, *emptypb.Empty) (*CacheStatsResponse, error)
 	Expressions(context.Context, *ExpressionsRequest) (*ExpressionsResponse, error)
 	ExpressionById(context.Context, *ExpressionByIdRequest) (*ExpressionByIdResponse, error)
+	// tasks the expression was computed with: operands, results, agents and timings
+	ExpressionTasks(context.Context, *ExpressionTasksRequest) (*ExpressionTasksResponse, error)
+	// streams status transitions of the expression until it is done or failed
+	WatchExpression(*WatchExpressionRequest, grpc.ServerStreamingServer[ExpressionEvent]) error
+	// stops a pending expression: its queued tasks are dropped, agents abandon
+	// the computing ones and the expression gets the cancelled status
+	CancelExpression(context.Context, *CancelExpressionRequest) (*emptypb.Empty, error)
+	SetVariable(context.Context, *SetVariableRequest) (*SetVariableResponse, error)
+	Variables(context.Context, *VariablesRequest) (*VariablesResponse, error)
+	VariableByName(context.Context, *VariableByNameRequest) (*VariableByNameResponse, error)
+	DeleteVariable(context.Context, *DeleteVariableRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedOrchestratorServiceServer()
 }
 
@@ -118,18 +357,63 @@ type UnimplementedOrchestratorServiceServer struct{}
 func (UnimplementedOrchestratorServiceServer) Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) CalculateBatch(context.Context, *CalculateBatchRequest) (*CalculateBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateBatch not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) BatchProgress(context.Context, *BatchProgressRequest) (*BatchProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchProgress not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) Explain(context.Context, *ExplainRequest) (*ExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) GetTask(context.Context, *emptypb.Empty) (*GetTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
 }
 func (UnimplementedOrchestratorServiceServer) ResultTask(context.Context, *ResultTaskRequest) (*ResultTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResultTask not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) Work(grpc.BidiStreamingServer[WorkRequest, WorkResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Work not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) Heartbeat(context.Context, *HeartbeatRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) ListAgents(context.Context, *emptypb.Empty) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) CacheStats(context.Context, *emptypb.Empty) (*CacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CacheStats not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) Expressions(context.Context, *ExpressionsRequest) (*ExpressionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Expressions not implemented")
 }
 func (UnimplementedOrchestratorServiceServer) ExpressionById(context.Context, *ExpressionByIdRequest) (*ExpressionByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExpressionById not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) ExpressionTasks(context.Context, *ExpressionTasksRequest) (*ExpressionTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpressionTasks not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) WatchExpression(*WatchExpressionRequest, grpc.ServerStreamingServer[ExpressionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchExpression not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) CancelExpression(context.Context, *CancelExpressionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExpression not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) SetVariable(context.Context, *SetVariableRequest) (*SetVariableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVariable not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) Variables(context.Context, *VariablesRequest) (*VariablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Variables not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) VariableByName(context.Context, *VariableByNameRequest) (*VariableByNameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VariableByName not implemented")
+}
+func (UnimplementedOrchestratorServiceServer) DeleteVariable(context.Context, *DeleteVariableRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVariable not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) mustEmbedUnimplementedOrchestratorServiceServer() {}
 func (UnimplementedOrchestratorServiceServer) testEmbeddedByValue()                             {}
 
@@ -169,6 +453,60 @@ func _OrchestratorService_Calculate_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_CalculateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).CalculateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_CalculateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).CalculateBatch(ctx, req.(*CalculateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_BatchProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).BatchProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_BatchProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).BatchProgress(ctx, req.(*BatchProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_Explain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).Explain(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrchestratorService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -205,6 +543,85 @@ func _OrchestratorService_ResultTask_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_Work_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OrchestratorServiceServer).Work(&grpc.GenericServerStream[WorkRequest, WorkResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrchestratorService_WorkServer = grpc.BidiStreamingServer[WorkRequest, WorkResponse]
+
+func _OrchestratorService_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).RegisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_RegisterAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).RegisterAgent(ctx, req.(*RegisterAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ListAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ListAgents(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_CacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).CacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_CacheStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).CacheStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _OrchestratorService_Expressions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExpressionsRequest)
 	if err := dec(in); err != nil {
@@ -241,6 +658,125 @@ func _OrchestratorService_ExpressionById_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_ExpressionTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExpressionTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ExpressionTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ExpressionTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ExpressionTasks(ctx, req.(*ExpressionTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_WatchExpression_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchExpressionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrchestratorServiceServer).WatchExpression(m, &grpc.GenericServerStream[WatchExpressionRequest, ExpressionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrchestratorService_WatchExpressionServer = grpc.ServerStreamingServer[ExpressionEvent]
+
+func _OrchestratorService_CancelExpression_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExpressionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).CancelExpression(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_CancelExpression_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).CancelExpression(ctx, req.(*CancelExpressionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_SetVariable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVariableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).SetVariable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_SetVariable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).SetVariable(ctx, req.(*SetVariableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_Variables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).Variables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_Variables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).Variables(ctx, req.(*VariablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_VariableByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariableByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).VariableByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_VariableByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).VariableByName(ctx, req.(*VariableByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrchestratorService_DeleteVariable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVariableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).DeleteVariable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_DeleteVariable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).DeleteVariable(ctx, req.(*DeleteVariableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrchestratorService_ServiceDesc is the grpc.ServiceDesc for OrchestratorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -252,6 +788,18 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Calculate",
 			Handler:    _OrchestratorService_Calculate_Handler,
 		},
+		{
+			MethodName: "CalculateBatch",
+			Handler:    _OrchestratorService_CalculateBatch_Handler,
+		},
+		{
+			MethodName: "BatchProgress",
+			Handler:    _OrchestratorService_BatchProgress_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _OrchestratorService_Explain_Handler,
+		},
 		{
 			MethodName: "GetTask",
 			Handler:    _OrchestratorService_GetTask_Handler,
@@ -260,6 +808,22 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResultTask",
 			Handler:    _OrchestratorService_ResultTask_Handler,
 		},
+		{
+			MethodName: "RegisterAgent",
+			Handler:    _OrchestratorService_RegisterAgent_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _OrchestratorService_Heartbeat_Handler,
+		},
+		{
+			MethodName: "ListAgents",
+			Handler:    _OrchestratorService_ListAgents_Handler,
+		},
+		{
+			MethodName: "CacheStats",
+			Handler:    _OrchestratorService_CacheStats_Handler,
+		},
 		{
 			MethodName: "Expressions",
 			Handler:    _OrchestratorService_Expressions_Handler,
@@ -268,7 +832,43 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExpressionById",
 			Handler:    _OrchestratorService_ExpressionById_Handler,
 		},
+		{
+			MethodName: "ExpressionTasks",
+			Handler:    _OrchestratorService_ExpressionTasks_Handler,
+		},
+		{
+			MethodName: "CancelExpression",
+			Handler:    _OrchestratorService_CancelExpression_Handler,
+		},
+		{
+			MethodName: "SetVariable",
+			Handler:    _OrchestratorService_SetVariable_Handler,
+		},
+		{
+			MethodName: "Variables",
+			Handler:    _OrchestratorService_Variables_Handler,
+		},
+		{
+			MethodName: "VariableByName",
+			Handler:    _OrchestratorService_VariableByName_Handler,
+		},
+		{
+			MethodName: "DeleteVariable",
+			Handler:    _OrchestratorService_DeleteVariable_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Work",
+			Handler:       _OrchestratorService_Work_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchExpression",
+			Handler:       _OrchestratorService_WatchExpression_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/orchestrator.proto",
 }
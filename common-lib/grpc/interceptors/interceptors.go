@@ -27,3 +27,32 @@ func AddLogMiddleware(
 	}
 	return reply, err
 }
+
+// loggedStream replaces the context of the stream with the one that holds the logger
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func AddStreamLogMiddleware(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, _ := logger.New(stream.Context())
+	ctx = context.WithValue(ctx, logger.KeyForRequestID, uuid.New().String())
+	logger.GetLoggerFromCtx(ctx).Info(ctx, "gRPC stream",
+		zap.String("method", info.FullMethod),
+		zap.Time("request time", time.Now()),
+	)
+	err := handler(srv, &loggedStream{ServerStream: stream, ctx: ctx})
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "gRPC stream handler returned an error", zap.Error(err))
+	}
+	return err
+}
@@ -0,0 +1,4 @@
+drop table if exists expressions.tasks;
+
+alter table expressions.expressions
+    drop column if exists rpn;
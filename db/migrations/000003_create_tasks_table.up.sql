@@ -0,0 +1,15 @@
+alter table expressions.expressions
+    add column if not exists rpn text[];
+
+create table if not exists expressions.tasks (
+    expression_id uuid not null
+     references expressions.expressions(id) on delete cascade,
+    task_id integer not null,
+    node_id integer not null,
+    arg1 double precision not null,
+    arg2 double precision not null,
+    operation text not null,
+    status text not null,
+    result double precision,
+    primary key (expression_id, task_id)
+);
@@ -0,0 +1,14 @@
+delete from expressions.tasks
+    where cardinality(args) <> 2;
+
+alter table expressions.tasks
+    add column if not exists arg1 double precision,
+    add column if not exists arg2 double precision;
+
+update expressions.tasks
+    set arg1 = args[1], arg2 = args[2];
+
+alter table expressions.tasks
+    alter column arg1 set not null,
+    alter column arg2 set not null,
+    drop column if exists args;
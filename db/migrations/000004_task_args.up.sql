@@ -0,0 +1,10 @@
+alter table expressions.tasks
+    add column if not exists args double precision[];
+
+update expressions.tasks
+    set args = array[arg1, arg2];
+
+alter table expressions.tasks
+    alter column args set not null,
+    drop column if exists arg1,
+    drop column if exists arg2;
@@ -0,0 +1,7 @@
+create table if not exists expressions.variables (
+    user_id uuid not null
+     references users.users(id) on delete cascade,
+    name text not null,
+    value double precision not null,
+    primary key (user_id, name)
+);
@@ -0,0 +1,7 @@
+alter table expressions.tasks
+    alter column args type double precision[] using args::double precision[],
+    alter column result type double precision using result::double precision;
+
+alter table expressions.expressions
+    alter column result type double precision using result::double precision,
+    drop column if exists precision_mode;
@@ -0,0 +1,7 @@
+alter table expressions.expressions
+    add column if not exists precision_mode text not null default 'float',
+    alter column result type numeric using result::numeric;
+
+alter table expressions.tasks
+    alter column args type numeric[] using args::numeric[],
+    alter column result type numeric using result::numeric;
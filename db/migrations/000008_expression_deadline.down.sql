@@ -0,0 +1,3 @@
+alter table expressions.expressions
+    drop column if exists deadline,
+    drop column if exists timeout_ms;
@@ -0,0 +1,3 @@
+alter table expressions.expressions
+    add column if not exists deadline timestamptz,
+    add column if not exists timeout_ms bigint;
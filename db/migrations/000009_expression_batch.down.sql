@@ -0,0 +1,4 @@
+drop index if exists expressions.expressions_batch_id_idx;
+
+alter table expressions.expressions
+    drop column if exists batch_id;
@@ -0,0 +1,6 @@
+alter table expressions.expressions
+    add column if not exists batch_id uuid;
+
+create index if not exists expressions_batch_id_idx
+    on expressions.expressions (batch_id)
+    where batch_id is not null;
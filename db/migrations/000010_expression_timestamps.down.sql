@@ -0,0 +1,5 @@
+drop index if exists expressions.expressions_user_created_idx;
+
+alter table expressions.expressions
+    drop column if exists created_at,
+    drop column if exists finished_at;
@@ -0,0 +1,6 @@
+alter table expressions.expressions
+    add column if not exists created_at timestamptz not null default now(),
+    add column if not exists finished_at timestamptz;
+
+create index if not exists expressions_user_created_idx
+    on expressions.expressions (user_id, created_at, id);
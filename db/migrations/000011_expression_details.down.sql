@@ -0,0 +1,5 @@
+alter table expressions.expressions
+    drop column if exists expression,
+    drop column if exists normalized,
+    drop column if exists error,
+    drop column if exists started_at;
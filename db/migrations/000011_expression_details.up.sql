@@ -0,0 +1,5 @@
+alter table expressions.expressions
+    add column if not exists expression text not null default '',
+    add column if not exists normalized text not null default '',
+    add column if not exists error text,
+    add column if not exists started_at timestamptz;
@@ -0,0 +1,2 @@
+alter table expressions.expressions
+    drop column if exists tasks_saved;
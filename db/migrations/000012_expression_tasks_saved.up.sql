@@ -0,0 +1,2 @@
+alter table expressions.expressions
+    add column if not exists tasks_saved integer not null default 0;
@@ -0,0 +1,7 @@
+alter table expressions.tasks
+    drop column if exists agent_id,
+    drop column if exists attempts,
+    drop column if exists error,
+    drop column if exists queued_at,
+    drop column if exists started_at,
+    drop column if exists finished_at;
@@ -0,0 +1,10 @@
+alter table expressions.tasks
+    add column if not exists agent_id text,
+    add column if not exists attempts integer not null default 0,
+    add column if not exists error text,
+    add column if not exists queued_at timestamptz,
+    add column if not exists started_at timestamptz,
+    add column if not exists finished_at timestamptz;
+
+alter table expressions.tasks
+    alter column queued_at set default now();
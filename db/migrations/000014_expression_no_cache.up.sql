@@ -0,0 +1,2 @@
+alter table expressions.expressions
+    add column if not exists no_cache boolean not null default false;
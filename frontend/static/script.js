@@ -15,11 +15,25 @@ document.addEventListener('DOMContentLoaded', async () => {
     document.getElementById('themeToggle').addEventListener('click', toggleTheme);
     document.getElementById('calculateBtn').addEventListener('click', sendExpression);
     document.getElementById('expression').addEventListener('keydown', handleEnterKey);
+    document.getElementById('logoutBtn').addEventListener('click', logout);
 
     // Загрузка истории
     await fetchExpressions();
 });
 
+// Выход: токены отзываются на сервере, после чего открывается страница входа
+async function logout() {
+    try {
+        await fetch('http://localhost:8080/api/v1/logout', {
+            method: 'POST',
+            credentials: 'include'
+        });
+    } catch (error) {
+        console.error('Logout error:', error);
+    }
+    window.location.href = 'auth/login.html';
+}
+
 // Обертка для запросов с авторизацией
 async function fetchWithAuth(url, options = {}) {
     try {
@@ -168,7 +182,7 @@ async function fetchExpressions() {
         `;
                 historyList.appendChild(header);
 
-                data.expressions.sort((a, b) => b.id - a.id).forEach(expr => {
+                data.expressions.forEach(expr => {
                     const listItem = document.createElement('li');
                     listItem.classList.add('history-item');
 
@@ -179,6 +193,14 @@ async function fetchExpressions() {
                     const infoDiv = document.createElement('div');
                     infoDiv.classList.add('item-info');
 
+                    if (expr.expression) {
+                        const expressionDiv = document.createElement('div');
+                        expressionDiv.classList.add('expression');
+                        expressionDiv.textContent = `expression: ${expr.expression}`;
+                        expressionDiv.title = expr.normalized;
+                        infoDiv.appendChild(expressionDiv);
+                    }
+
                     const statusDiv = document.createElement('div');
                     statusDiv.classList.add('status');
                     statusDiv.textContent = `status: ${expr.status}`;
@@ -191,6 +213,13 @@ async function fetchExpressions() {
                         infoDiv.appendChild(resultDiv);
                     }
 
+                    if (expr.error) {
+                        const errorDiv = document.createElement('div');
+                        errorDiv.classList.add('error');
+                        errorDiv.textContent = `error: ${expr.error}`;
+                        infoDiv.appendChild(errorDiv);
+                    }
+
                     listItem.appendChild(idSpan);
                     listItem.appendChild(infoDiv);
                     historyList.appendChild(listItem);
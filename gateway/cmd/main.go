@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/jaam8/web_calculator/common-lib/grpc/pool"
 	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/common-lib/redis"
 	_ "github.com/jaam8/web_calculator/gateway/docs"
 	"github.com/jaam8/web_calculator/gateway/internal/config"
 	grpc_servises "github.com/jaam8/web_calculator/gateway/internal/delivery/grpc"
@@ -14,6 +15,8 @@ import (
 
 	//"github.com/jaam8/web_calculator/gateway/internal/delivery/grpc"
 	"github.com/jaam8/web_calculator/gateway/internal/ports/adapters/orchestrator_adapters"
+	"github.com/jaam8/web_calculator/gateway/internal/ports/adapters/rate_limit_adapters"
+	"github.com/jaam8/web_calculator/gateway/internal/ports/adapters/token_adapters"
 	"github.com/labstack/echo/v4"
 	swagger "github.com/swaggo/echo-swagger"
 	"go.uber.org/zap"
@@ -107,17 +110,48 @@ func main() {
 		time.Duration(cfg.RefreshTTL)*time.Hour,
 	)
 
+	redisClient, err := redis.NewRedisClient(ctx, cfg.Redis, gatewayCfg.RedisDB)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal(ctx, "couldn't connect to redis for rate limiting", zap.Error(err))
+	}
+	rateLimitAdapter := rate_limit_adapters.NewRateLimitAdapter(redisClient)
+
+	authRedisClient, err := redis.NewRedisClient(ctx, cfg.Redis, authCfg.RedisDB)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal(ctx, "couldn't connect to redis for token revocation", zap.Error(err))
+	}
+	tokenAdapter := token_adapters.NewTokenAdapter(authRedisClient)
+
 	e := echo.New()
 
 	apiV1 := e.Group("/api/v1")
-	auth := apiV1.Group("/", middlewares.AuthMiddleware(cfg.JwtSecret))
+	auth := apiV1.Group("/",
+		middlewares.AuthMiddleware(cfg.JwtSecret, tokenAdapter),
+		middlewares.RateLimitMiddleware(rateLimitAdapter, gatewayCfg.RateLimit),
+	)
 
 	e.Use(middlewares.CORSMiddleware)
 	e.Use(middlewares.LogMiddleware)
 
-	auth.POST("calculate", orchestratorHandler.Calculate)
+	auth.POST("calculate", orchestratorHandler.Calculate,
+		middlewares.QuotaMiddleware(rateLimitAdapter, gatewayCfg.DailyQuota, nil))
+	// every expression of a batch counts against the quota
+	auth.POST("calculate/batch", orchestratorHandler.CalculateBatch,
+		middlewares.QuotaMiddleware(rateLimitAdapter, gatewayCfg.DailyQuota, middlewares.BatchCost))
+	auth.GET("calculate/batch/:id", orchestratorHandler.BatchProgress)
+	auth.POST("calculate/explain", orchestratorHandler.Explain)
+	auth.GET("cache/stats", orchestratorHandler.CacheStats)
 	auth.GET("expressions", orchestratorHandler.Expressions)
 	auth.GET("expressions/:id", orchestratorHandler.ExpressionByID)
+	auth.GET("expressions/:id/events", orchestratorHandler.WatchExpression)
+	auth.GET("expressions/:id/tasks", orchestratorHandler.ExpressionTasks)
+	auth.DELETE("expressions/:id", orchestratorHandler.CancelExpression)
+	auth.GET("variables", orchestratorHandler.Variables)
+	auth.GET("variables/:name", orchestratorHandler.VariableByName)
+	auth.PUT("variables/:name", orchestratorHandler.SetVariable)
+	auth.DELETE("variables/:name", orchestratorHandler.DeleteVariable)
+	auth.POST("logout", authHandler.Logout)
+	auth.POST("logout-all", authHandler.LogoutAll)
 	apiV1.POST("/refresh-token", authHandler.Refresh)
 	apiV1.POST("/login", authHandler.Login)
 	apiV1.POST("/register", authHandler.Register)
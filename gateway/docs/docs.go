@@ -15,6 +15,49 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/cache/stats": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Reports how many tasks the result cache of the orchestrator saved since it started",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orchestrator"
+                ],
+                "summary": "Get result cache statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CacheStatsResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
         "/calculate": {
             "post": {
                 "security": [
@@ -22,7 +65,7 @@ const docTemplate = `{
                         "Bearer \u003cjwt_access_token\u003e": []
                     }
                 ],
-                "description": "Evaluates a mathematical expression and returns the result",
+                "description": "Evaluates a mathematical expression and returns the result.\nWith \"decimal\" precision operands and the result are exact decimals, e.g. 0.1+0.2 = 0.3\nTasks of expressions with a higher priority are computed first, users with equal priority take turns\nAn expression not calculated within timeout_ms (or the server default) gets the \"timed out\" status\nResults of identical expressions and subexpressions are taken from the cache unless no_cache is set",
                 "consumes": [
                     "application/json"
                 ],
@@ -51,41 +94,103 @@ const docTemplate = `{
                             "$ref": "#/definitions/schemas.CalculateResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InvalidTimeout"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/schemas.CannotParseExpression"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.QuotaExceeded"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/schemas.InternalServerError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.QueueFull"
+                        }
                     }
                 }
             }
         },
-        "/expressions": {
-            "get": {
+        "/calculate/batch": {
+            "post": {
                 "security": [
                     {
                         "Bearer \u003cjwt_access_token\u003e": []
                     }
                 ],
-                "description": "Returns a list of all calculated expressions",
+                "description": "Accepts up to 1000 expressions at once, precision, priority and timeout_ms apply to each of them.\nExpressions that cannot be parsed are reported in their items and do not reject the batch,\nthe progress of the accepted ones is available by batch_id. Every expression counts against the daily quota",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Orchestrator"
                 ],
-                "summary": "Get all expressions",
+                "summary": "Calculate a batch of expressions",
+                "parameters": [
+                    {
+                        "description": "Expressions to calculate",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CalculateBatchRequest"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/schemas.ExpressionsResponse"
+                            "$ref": "#/definitions/schemas.CalculateBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InvalidBatch"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CalculateBatchResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.QuotaExceeded"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
                         }
                     },
                     "500": {
@@ -93,29 +198,35 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/schemas.InternalServerError"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.QueueFull"
+                        }
                     }
                 }
             }
         },
-        "/expressions/{id}": {
+        "/calculate/batch/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer \u003cjwt_access_token\u003e": []
                     }
                 ],
-                "description": "Returns a specific expression by its ID",
+                "description": "Counts expressions of a batch by status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Orchestrator"
                 ],
-                "summary": "Get expression by ID",
+                "summary": "Get batch progress",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Expression ID",
+                        "type": "string",
+                        "description": "Batch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -125,13 +236,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.ExpressionByIdResponse"
+                            "$ref": "#/definitions/schemas.BatchProgressResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/schemas.ExpressionNotFound"
+                            "$ref": "#/definitions/schemas.BatchNotFound"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
                         }
                     },
                     "500": {
@@ -143,9 +266,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
+        "/calculate/explain": {
             "post": {
-                "description": "Authenticates a user by login and password. Write access and refresh tokens to cookies.",
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Shows the RPN and the tasks the expression would be split into, when each of them can start,\nthe critical path and the time estimated from the operation times and the registered agents.\nNothing is saved or computed",
                 "consumes": [
                     "application/json"
                 ],
@@ -153,44 +281,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Orchestrator"
                 ],
-                "summary": "Login user",
+                "summary": "Explain how an expression would be calculated",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "login",
+                        "description": "Expression to explain",
+                        "name": "expression",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.LoginRequest"
+                            "$ref": "#/definitions/schemas.ExplainRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExplainResponse"
+                        }
                     },
                     "400": {
-                        "description": "Empty password",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/schemas.EmptyPassword"
+                            "$ref": "#/definitions/schemas.UnknownPrecision"
                         }
                     },
-                    "401": {
-                        "description": "Wrong credentials",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
-                            "$ref": "#/definitions/schemas.WrongCredentials"
+                            "$ref": "#/definitions/schemas.CannotParseExpression"
                         }
                     },
-                    "422": {
-                        "description": "Cannot parse request",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/schemas.CannotParseRequest"
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/schemas.InternalServerError"
                         }
@@ -198,31 +335,98 @@ const docTemplate = `{
                 }
             }
         },
-        "/refresh": {
-            "post": {
-                "description": "Refreshes access and refresh tokens using the refresh token from the cookie. Returns new tokens in cookies.",
-                "consumes": [
-                    "application/json"
+        "/expressions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
                 ],
+                "description": "Returns a page of expressions, newest first by default. Pass next_cursor of the response\nas cursor to get the next page, the filters and the order must stay the same",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Orchestrator"
+                ],
+                "summary": "Get all expressions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size from 1 to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only expressions with one of the statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only expressions created at or after, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only expressions created before, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "default": "newest",
+                        "description": "Sort order by creation time",
+                        "name": "order",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Refresh access and refresh tokens",
                 "responses": {
-                    "204": {
-                        "description": "Success generating new tokens"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionsResponse"
+                        }
                     },
-                    "401": {
-                        "description": "Token expired or invalid",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/schemas.TokenExpiredOrInvalid"
+                            "$ref": "#/definitions/schemas.InvalidPageSize"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/schemas.InternalServerError"
                         }
@@ -230,85 +434,921 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Registers a new user with login and password. Returns user ID in response body.",
-                "consumes": [
-                    "application/json"
+        "/expressions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
                 ],
+                "description": "Returns a specific expression by its ID. If the computation failed, the status holds the reason, e.g. \"division by zero\"",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Orchestrator"
                 ],
-                "summary": "Register new user",
+                "summary": "Get expression by ID",
                 "parameters": [
                     {
-                        "description": "Register credentials",
-                        "name": "register",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.RegisterRequest"
-                        }
+                        "type": "integer",
+                        "description": "Expression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schemas.RegisterResponse"
+                            "$ref": "#/definitions/schemas.ExpressionByIdResponse"
                         }
                     },
-                    "400": {
-                        "description": "Empty password\"// @Failure 409 {object} schemas.MessageResponse \"User already exists",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/schemas.EmptyPassword"
+                            "$ref": "#/definitions/schemas.ExpressionNotFound"
                         }
                     },
-                    "401": {
-                        "description": "Wrong credentials",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/schemas.WrongCredentials"
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
                         }
                     },
-                    "422": {
-                        "description": "Cannot parse request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/schemas.CannotParseRequest"
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Stops calculating a pending expression: its queued tasks are dropped,\nagents abandon the computing ones and the expression gets the \"cancelled\" status",
+                "tags": [
+                    "Orchestrator"
+                ],
+                "summary": "Cancel expression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionNotFound"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionFinished"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/schemas.InternalServerError"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "schemas.CalculateRequest": {
-            "type": "object",
-            "properties": {
-                "expression": {
-                    "type": "string",
-                    "example": "40+2"
-                }
-            }
         },
-        "schemas.CalculateResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+        "/expressions/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Streams status transitions of the expression (pending, in progress, done or the error)\nas Server-Sent Events until it is done or failed. Each event is \"status\" with the JSON in data",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Orchestrator"
+                ],
+                "summary": "Watch expression status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionEvent"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionNotFound"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/expressions/{id}/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Returns the tasks the expression was split into with their operands, results, errors,\nthe agents that computed them and when they were queued, handed out and finished",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orchestrator"
+                ],
+                "summary": "Get tasks of an expression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionTasksResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ExpressionNotFound"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticates a user by login and password. Write access and refresh tokens to cookies.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Empty password",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EmptyPassword"
+                        }
+                    },
+                    "401": {
+                        "description": "Wrong credentials",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.WrongCredentials"
+                        }
+                    },
+                    "422": {
+                        "description": "Cannot parse request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CannotParseRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Revokes the access token of the request and the refresh token from the cookie. Clears the token cookies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "204": {
+                        "description": "Tokens revoked"
+                    },
+                    "401": {
+                        "description": "Token expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TokenExpiredOrInvalid"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Revokes every access and refresh token of the user, including the ones of the request. Clears the token cookies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user on all devices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.LogoutAllResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Token expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TokenExpiredOrInvalid"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "Refreshes access and refresh tokens using the refresh token from the cookie. Returns new tokens in cookies.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access and refresh tokens",
+                "responses": {
+                    "204": {
+                        "description": "Success generating new tokens"
+                    },
+                    "401": {
+                        "description": "Token expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TokenExpiredOrInvalid"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Registers a new user with login and password. Returns user ID in response body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register new user",
+                "parameters": [
+                    {
+                        "description": "Register credentials",
+                        "name": "register",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RegisterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Empty password\"// @Failure 409 {object} schemas.MessageResponse \"User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EmptyPassword"
+                        }
+                    },
+                    "401": {
+                        "description": "Wrong credentials",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.WrongCredentials"
+                        }
+                    },
+                    "422": {
+                        "description": "Cannot parse request",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CannotParseRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variables": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Returns a list of the user's variables sorted by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Variables"
+                ],
+                "summary": "Get all variables",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.VariablesResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variables/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Returns a specific variable by its name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Variables"
+                ],
+                "summary": "Get variable by name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Variable name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.VariableByNameResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.VariableNotFound"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Creates or updates a named variable that can be used in expressions, e.g. \"rate * hours\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Variables"
+                ],
+                "summary": "Set variable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Variable name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variable value",
+                        "name": "variable",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SetVariableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SetVariableResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InvalidVariableName"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer \u003cjwt_access_token\u003e": []
+                    }
+                ],
+                "description": "Deletes a variable by its name",
+                "tags": [
+                    "Variables"
+                ],
+                "summary": "Delete variable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Variable name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.VariableNotFound"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TooManyRequests"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until the limit is reset"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InternalServerError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "schemas.BatchItem": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "error": {
+                    "type": "string",
+                    "example": "cannot parse expression"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "expected ')'"
+                }
+            }
+        },
+        "schemas.BatchNotFound": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "batch not found"
+                }
+            }
+        },
+        "schemas.BatchProgressResponse": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string",
+                    "example": "5b1e4a3c-7f6d-4b8e-9a2c-1d3f5e7a9b0c"
+                },
+                "done": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "failed": {
+                    "description": "Failed counts expressions that failed, were cancelled or timed out",
+                    "type": "integer",
+                    "example": 1
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "statuses": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "schemas.CacheStatsResponse": {
+            "type": "object",
+            "properties": {
+                "deduplicated": {
+                    "description": "Deduplicated counts tasks of identical subexpressions of one expression that were computed once",
+                    "type": "integer",
+                    "example": 2
+                },
+                "enabled": {
+                    "description": "Enabled is false when the orchestrator runs without a result cache",
+                    "type": "boolean",
+                    "example": true
+                },
+                "expressions": {
+                    "description": "Expressions counts expressions whose whole result was taken from the cache",
+                    "type": "integer",
+                    "example": 5
+                },
+                "hit_rate": {
+                    "type": "number",
+                    "example": 0.75
+                },
+                "hits": {
+                    "description": "Hits counts tasks whose results were taken from the cache",
+                    "type": "integer",
+                    "example": 30
+                },
+                "misses": {
+                    "description": "Misses counts tasks computed by agents, expressions calculated with no_cache are not counted",
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "schemas.CalculateBatchRequest": {
+            "type": "object",
+            "properties": {
+                "expressions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "40+2",
+                        "6*(7"
+                    ]
+                },
+                "no_cache": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "precision": {
+                    "description": "Precision, Priority, TimeoutMs and NoCache apply to every expression of the batch",
+                    "type": "string",
+                    "enum": [
+                        "float",
+                        "decimal"
+                    ],
+                    "example": "float"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "timeout_ms": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 60000
+                }
+            }
+        },
+        "schemas.CalculateBatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "description": "BatchId is empty when no expression of the batch was accepted",
+                    "type": "string",
+                    "example": "5b1e4a3c-7f6d-4b8e-9a2c-1d3f5e7a9b0c"
+                },
+                "items": {
+                    "description": "Items are in the order of the request expressions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.BatchItem"
+                    }
+                }
+            }
+        },
+        "schemas.CalculateRequest": {
+            "type": "object",
+            "properties": {
+                "expression": {
+                    "type": "string",
+                    "example": "40+2"
+                },
+                "no_cache": {
+                    "description": "NoCache computes every subexpression again instead of taking cached results",
+                    "type": "boolean",
+                    "example": false
+                },
+                "precision": {
+                    "description": "Precision \"decimal\" computes the expression exactly with decimal arithmetic",
+                    "type": "string",
+                    "enum": [
+                        "float",
+                        "decimal"
+                    ],
+                    "example": "float"
+                },
+                "priority": {
+                    "description": "Priority from 0 to 9, tasks of expressions with a higher priority are computed first",
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 0,
+                    "example": 0
+                },
+                "timeout_ms": {
+                    "description": "TimeoutMs after which a not yet calculated expression gets the \"timed out\" status, 0 means the server default",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 60000
+                }
+            }
+        },
+        "schemas.CalculateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
         "schemas.CannotParseExpression": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "error": {
+                    "type": "string",
+                    "example": "cannot parse expression"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unexpected '(', expected operator"
+                }
+            }
+        },
+        "schemas.CannotParseId": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "cannot parse id"
+                }
+            }
+        },
+        "schemas.CannotParseRequest": {
             "type": "object",
             "properties": {
                 "error": {
@@ -317,56 +1357,231 @@ const docTemplate = `{
                 }
             }
         },
-        "schemas.CannotParseRequest": {
+        "schemas.EmptyLogin": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "empty login"
+                }
+            }
+        },
+        "schemas.EmptyPassword": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "empty password"
+                }
+            }
+        },
+        "schemas.ExplainOperand": {
+            "type": "object",
+            "properties": {
+                "constant": {
+                    "type": "string",
+                    "example": "4"
+                },
+                "task_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "schemas.ExplainRequest": {
+            "type": "object",
+            "properties": {
+                "expression": {
+                    "type": "string",
+                    "example": "2*3+sqrt(x)"
+                },
+                "precision": {
+                    "description": "Precision \"decimal\" expressions are simplified less than \"float\" ones",
+                    "type": "string",
+                    "enum": [
+                        "float",
+                        "decimal"
+                    ],
+                    "example": "float"
+                }
+            }
+        },
+        "schemas.ExplainResponse": {
+            "type": "object",
+            "properties": {
+                "agents": {
+                    "description": "Agents registered and Slots, the number of tasks they compute at once",
+                    "type": "integer",
+                    "example": 2
+                },
+                "critical_path": {
+                    "description": "CriticalPath is the longest chain of dependent tasks, no number of agents computes the expression faster",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        4,
+                        5
+                    ]
+                },
+                "critical_path_time_ms": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "estimated_time_ms": {
+                    "description": "EstimatedTimeMs to compute the tasks with all slots, empty when no agent is registered.\nCached results and tasks of other expressions are not taken into account",
+                    "type": "integer",
+                    "example": 600
+                },
+                "rpn": {
+                    "description": "Rpn is what the expression is planned as, after simplification if it is enabled",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2",
+                        "3",
+                        "*",
+                        "4",
+                        "sqrt:1",
+                        "+"
+                    ]
+                },
+                "slots": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "tasks": {
+                    "description": "Tasks are in the order of rpn, the last one computes the result",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ExplainTask"
+                    }
+                },
+                "tasks_saved": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "schemas.ExplainTask": {
             "type": "object",
             "properties": {
-                "error": {
+                "earliest_start_ms": {
+                    "description": "EarliestStartMs is when the task can start if every ready task gets an agent at once",
+                    "type": "integer",
+                    "example": 500
+                },
+                "id": {
+                    "description": "Id is the position of the operation in rpn",
+                    "type": "integer",
+                    "example": 5
+                },
+                "operands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ExplainOperand"
+                    }
+                },
+                "operation": {
                     "type": "string",
-                    "example": "cannot parse expression"
+                    "example": "+"
+                },
+                "operation_time_ms": {
+                    "type": "integer",
+                    "example": 100
                 }
             }
         },
-        "schemas.EmptyLogin": {
+        "schemas.Expression": {
             "type": "object",
             "properties": {
-                "error": {
+                "created_at": {
                     "type": "string",
-                    "example": "empty login"
-                }
-            }
-        },
-        "schemas.EmptyPassword": {
-            "type": "object",
-            "properties": {
+                    "example": "2025-05-20T12:00:00Z"
+                },
+                "decimal_result": {
+                    "description": "DecimalResult is the exact result of an expression calculated with \"decimal\" precision",
+                    "type": "string",
+                    "example": "42.0"
+                },
                 "error": {
+                    "description": "Error details why the expression failed, e.g. the operation that divided by zero",
                     "type": "string",
-                    "example": "empty password"
-                }
-            }
-        },
-        "schemas.Expression": {
-            "type": "object",
-            "properties": {
+                    "example": "division by zero in 7 / 0"
+                },
+                "expression": {
+                    "description": "Expression is the text as it was submitted",
+                    "type": "string",
+                    "example": "x*(2+ 5)"
+                },
+                "finished_at": {
+                    "description": "FinishedAt is empty while the expression is pending",
+                    "type": "string",
+                    "example": "2025-05-20T12:00:03Z"
+                },
                 "id": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "string",
+                    "example": "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+                },
+                "normalized": {
+                    "description": "Normalized is the canonical form with variable values substituted, this is what was calculated",
+                    "type": "string",
+                    "example": "6 * (2 + 5)"
                 },
                 "result": {
                     "type": "number",
                     "example": 42
                 },
+                "started_at": {
+                    "description": "StartedAt is when an agent took the first task, empty while the tasks are queued",
+                    "type": "string",
+                    "example": "2025-05-20T12:00:01Z"
+                },
                 "status": {
                     "type": "string",
+                    "enum": [
+                        "pending",
+                        "done",
+                        "cancelled",
+                        "timed out",
+                        "invalid expression",
+                        "division by zero"
+                    ],
                     "example": "done"
+                },
+                "tasks_saved": {
+                    "description": "TasksSaved counts tasks that were not sent to agents because the expression was simplified",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
         "schemas.ExpressionByIdResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "expression": {
+                    "$ref": "#/definitions/schemas.Expression"
+                }
+            }
+        },
+        "schemas.ExpressionEvent": {
+            "type": "object",
+            "properties": {
+                "decimal_result": {
+                    "type": "string",
+                    "example": "42.0"
+                },
+                "elapsed_ms": {
+                    "description": "ElapsedMs from the creation of a timed out expression to its timeout",
                     "type": "integer",
-                    "example": 1
+                    "example": 60000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3fa85f64-5717-4562-b3fc-2c963f66afa6"
                 },
                 "result": {
                     "type": "number",
@@ -374,7 +1589,33 @@ const docTemplate = `{
                 },
                 "status": {
                     "type": "string",
-                    "example": "done"
+                    "enum": [
+                        "pending",
+                        "in progress",
+                        "done",
+                        "cancelled",
+                        "timed out",
+                        "invalid expression",
+                        "division by zero"
+                    ],
+                    "example": "in progress"
+                },
+                "tasks_done": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tasks_total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "schemas.ExpressionFinished": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "expression is already finished"
                 }
             }
         },
@@ -387,6 +1628,18 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.ExpressionTasksResponse": {
+            "type": "object",
+            "properties": {
+                "tasks": {
+                    "description": "Tasks are in the order they were created",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TaskTrace"
+                    }
+                }
+            }
+        },
         "schemas.ExpressionsResponse": {
             "type": "object",
             "properties": {
@@ -395,6 +1648,11 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/schemas.Expression"
                     }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is the cursor query param of the next page, empty on the last page",
+                    "type": "string",
+                    "example": "MTc0NzczMjQwMDAwMDAwMC4zZmE4NWY2NA"
                 }
             }
         },
@@ -407,6 +1665,69 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.InvalidBatch": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid batch, expected 1 to 1000 expressions"
+                }
+            }
+        },
+        "schemas.InvalidCursor": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid cursor"
+                }
+            }
+        },
+        "schemas.InvalidDate": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid date, expected RFC 3339"
+                }
+            }
+        },
+        "schemas.InvalidPageSize": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid limit, expected 1 to 100"
+                }
+            }
+        },
+        "schemas.InvalidPriority": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid priority, expected 0 to 9"
+                }
+            }
+        },
+        "schemas.InvalidTimeout": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid timeout, expected a positive number of milliseconds"
+                }
+            }
+        },
+        "schemas.InvalidVariableName": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid variable name"
+                }
+            }
+        },
         "schemas.LoginRequest": {
             "type": "object",
             "properties": {
@@ -420,6 +1741,34 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.LogoutAllResponse": {
+            "type": "object",
+            "properties": {
+                "revoked_tokens": {
+                    "description": "RevokedTokens is the number of access and refresh tokens that were still valid",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "schemas.QueueFull": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "calculation queue is full, try again later"
+                }
+            }
+        },
+        "schemas.QuotaExceeded": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "daily calculation quota exceeded"
+                }
+            }
+        },
         "schemas.RegisterRequest": {
             "type": "object",
             "properties": {
@@ -442,6 +1791,101 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.SetVariableRequest": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "type": "number",
+                    "example": 0.2
+                }
+            }
+        },
+        "schemas.SetVariableResponse": {
+            "type": "object",
+            "properties": {
+                "variable": {
+                    "$ref": "#/definitions/schemas.Variable"
+                }
+            }
+        },
+        "schemas.TaskTrace": {
+            "type": "object",
+            "properties": {
+                "agent_id": {
+                    "description": "AgentId is the agent the task was handed out to last, empty for tasks taken without the work stream",
+                    "type": "string",
+                    "example": "agent-1"
+                },
+                "args": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        0.1,
+                        0.2
+                    ]
+                },
+                "attempts": {
+                    "description": "Attempts counts how many times the task was handed out, more than one if a lease expired",
+                    "type": "integer",
+                    "example": 1
+                },
+                "decimal_args": {
+                    "description": "DecimalArgs are the exact operands of an expression calculated with \"decimal\" precision",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0.1",
+                        "0.2"
+                    ]
+                },
+                "decimal_result": {
+                    "type": "string",
+                    "example": "0.3"
+                },
+                "error": {
+                    "description": "Error is why the agent failed to compute the task",
+                    "type": "string",
+                    "example": "division by zero"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-05-20T12:00:02Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "operation": {
+                    "type": "string",
+                    "example": "+"
+                },
+                "queued_at": {
+                    "type": "string",
+                    "example": "2025-05-20T12:00:00Z"
+                },
+                "result": {
+                    "type": "number",
+                    "example": 0.3
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-05-20T12:00:01Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "done",
+                        "failed"
+                    ],
+                    "example": "done"
+                }
+            }
+        },
         "schemas.TokenExpired": {
             "type": "object",
             "properties": {
@@ -460,6 +1904,74 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.TooManyRequests": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "too many requests, try again later"
+                }
+            }
+        },
+        "schemas.UnknownOrder": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "unknown order, expected newest or oldest"
+                }
+            }
+        },
+        "schemas.UnknownPrecision": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "unknown precision, expected float or decimal"
+                }
+            }
+        },
+        "schemas.Variable": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "rate"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 0.2
+                }
+            }
+        },
+        "schemas.VariableByNameResponse": {
+            "type": "object",
+            "properties": {
+                "variable": {
+                    "$ref": "#/definitions/schemas.Variable"
+                }
+            }
+        },
+        "schemas.VariableNotFound": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "variable not found"
+                }
+            }
+        },
+        "schemas.VariablesResponse": {
+            "type": "object",
+            "properties": {
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Variable"
+                    }
+                }
+            }
+        },
         "schemas.WrongCredentials": {
             "type": "object",
             "properties": {
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/jaam8/web_calculator/common-lib/redis"
 )
 
 type OrchestratorConfig struct {
@@ -25,6 +26,8 @@ type AuthConfig struct {
 	Timeout        int    `yaml:"timeout" env:"TIMEOUT_MS" env-default:"500"`
 	MaxRetries     uint   `yaml:"max_retries" env:"MAX_RETRIES" env-default:"3"`
 	BaseRetryDelay int    `yaml:"base_retry_delay" env:"BASE_RETRY_DELAY" env-default:"100"`
+	// RedisDB is the database the auth service keeps tokens in, AuthMiddleware rejects tokens missing there
+	RedisDB int `yaml:"redis_db" env:"REDIS_DB" env-default:"0"`
 }
 
 type GrpcPoolConfig struct {
@@ -37,6 +40,12 @@ type GrpcPoolConfig struct {
 type GatewayConfig struct {
 	Host string `yaml:"host" env:"HOST" env-default:"localhost"`
 	Port int    `yaml:"port" env:"PORT" env-default:"8080"`
+
+	RedisDB int `yaml:"redis_db" env:"REDIS_DB" env-default:"1"`
+	// RateLimit is the number of requests a user may send per minute, 0 disables the limit
+	RateLimit int `yaml:"rate_limit" env:"RATE_LIMIT_PER_MINUTE" env-default:"120"`
+	// DailyQuota is the number of calculations a user may start per day (UTC), 0 disables the quota
+	DailyQuota int `yaml:"daily_quota" env:"DAILY_CALCULATION_QUOTA" env-default:"1000"`
 }
 
 type Config struct {
@@ -44,6 +53,7 @@ type Config struct {
 	Gateway      GatewayConfig      `yaml:"gateway" env-prefix:"GATEWAY_"`
 	GrpcPool     GrpcPoolConfig     `yaml:"grpc_pool" env-prefix:"GRPC_POOL_"`
 	AuthService  AuthConfig         `yaml:"auth_service" env-prefix:"AUTH_SERVICE_"`
+	Redis        redis.Config       `yaml:"redis" env-prefix:"REDIS_"`
 	LogLevel     string             `yaml:"log_level" env:"LOG_LEVEL" env-default:"info"`
 	JwtSecret    string             `yaml:"jwt_secret" env:"JWT_SECRET"`
 	AccessTTL    int                `yaml:"access_ttl" env:"ACCESS_EXPIRATION" env-default:"15"`
@@ -88,3 +88,39 @@ func (s *AuthService) Refresh(request *auth.RefreshRequest) (*auth.RefreshRespon
 
 	return response, nil
 }
+
+func (s *AuthService) Logout(request *auth.LogoutRequest) error {
+	err := callers.Retry(func() error {
+		if err := (*s.authAdapter).Logout(request); err != nil {
+			return fmt.Errorf("error in retry Logout caller: %w", err)
+		}
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return fmt.Errorf("couldn't call Logout: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthService) LogoutAll(request *auth.LogoutAllRequest) (*auth.LogoutAllResponse, error) {
+	resultChan := make(chan *auth.LogoutAllResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.authAdapter).LogoutAll(request)
+		if err != nil {
+			return fmt.Errorf("error in retry LogoutAll caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call LogoutAll: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"github.com/jaam8/web_calculator/common-lib/callers"
 	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
@@ -47,6 +48,82 @@ func (s *OrchestratorService) Calculate(request *orchestrator.CalculateRequest)
 	return response, nil
 }
 
+// CalculateBatch is not retried: the orchestrator may have saved and started the batch
+// before the call failed, and a retry would start every expression of it a second time
+func (s *OrchestratorService) CalculateBatch(request *orchestrator.CalculateBatchRequest) (*orchestrator.CalculateBatchResponse, error) {
+	response, err := (*s.orchestratorAdapter).CalculateBatch(request)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CalculateBatch: %w", err)
+	}
+	return response, nil
+}
+
+func (s *OrchestratorService) BatchProgress(request *orchestrator.BatchProgressRequest) (*orchestrator.BatchProgressResponse, error) {
+	resultChan := make(chan *orchestrator.BatchProgressResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).BatchProgress(request)
+		if err != nil {
+			return fmt.Errorf("error in retry BatchProgress caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call BatchProgress: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
+func (s *OrchestratorService) Explain(request *orchestrator.ExplainRequest) (*orchestrator.ExplainResponse, error) {
+	resultChan := make(chan *orchestrator.ExplainResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).Explain(request)
+		if err != nil {
+			return fmt.Errorf("error in retry Explain caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call Explain: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
+func (s *OrchestratorService) CacheStats() (*orchestrator.CacheStatsResponse, error) {
+	resultChan := make(chan *orchestrator.CacheStatsResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).CacheStats()
+		if err != nil {
+			return fmt.Errorf("error in retry CacheStats caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call CacheStats: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
 func (s *OrchestratorService) Expressions(request *orchestrator.ExpressionsRequest) (*orchestrator.ExpressionsResponse, error) {
 	resultChan := make(chan *orchestrator.ExpressionsResponse, 1)
 
@@ -90,3 +167,130 @@ func (s *OrchestratorService) ExpressionByID(request *orchestrator.ExpressionByI
 
 	return response, nil
 }
+
+func (s *OrchestratorService) ExpressionTasks(request *orchestrator.ExpressionTasksRequest) (*orchestrator.ExpressionTasksResponse, error) {
+	resultChan := make(chan *orchestrator.ExpressionTasksResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).ExpressionTasks(request)
+		if err != nil {
+			return fmt.Errorf("error in retry ExpressionTasks caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call ExpressionTasks: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
+// WatchExpression relays the events of the expression to onEvent. The stream is not retried:
+// a reconnect would replay events the client has already seen
+func (s *OrchestratorService) WatchExpression(ctx context.Context, request *orchestrator.WatchExpressionRequest,
+	onEvent func(event *orchestrator.ExpressionEvent) error,
+) error {
+	if err := (*s.orchestratorAdapter).WatchExpression(ctx, request, onEvent); err != nil {
+		return fmt.Errorf("couldn't call WatchExpression: %w", err)
+	}
+	return nil
+}
+
+func (s *OrchestratorService) CancelExpression(request *orchestrator.CancelExpressionRequest) error {
+	err := callers.Retry(func() error {
+		if err := (*s.orchestratorAdapter).CancelExpression(request); err != nil {
+			return fmt.Errorf("error in retry CancelExpression caller: %w", err)
+		}
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return fmt.Errorf("couldn't call CancelExpression: %w", err)
+	}
+	return nil
+}
+
+func (s *OrchestratorService) SetVariable(request *orchestrator.SetVariableRequest) (*orchestrator.SetVariableResponse, error) {
+	resultChan := make(chan *orchestrator.SetVariableResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).SetVariable(request)
+		if err != nil {
+			return fmt.Errorf("error in retry SetVariable caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call SetVariable: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
+func (s *OrchestratorService) Variables(request *orchestrator.VariablesRequest) (*orchestrator.VariablesResponse, error) {
+	resultChan := make(chan *orchestrator.VariablesResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).Variables(request)
+		if err != nil {
+			return fmt.Errorf("error in retry Variables caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call Variables: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
+func (s *OrchestratorService) VariableByName(request *orchestrator.VariableByNameRequest) (*orchestrator.VariableByNameResponse, error) {
+	resultChan := make(chan *orchestrator.VariableByNameResponse, 1)
+
+	err := callers.Retry(func() error {
+		response, err := (*s.orchestratorAdapter).VariableByName(request)
+		if err != nil {
+			return fmt.Errorf("error in retry VariableByName caller: %w", err)
+		}
+		resultChan <- response
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return nil, fmt.Errorf("couldn't call VariableByName: %w", err)
+	}
+
+	response := <-resultChan
+	close(resultChan)
+
+	return response, nil
+}
+
+func (s *OrchestratorService) DeleteVariable(request *orchestrator.DeleteVariableRequest) error {
+	err := callers.Retry(func() error {
+		if err := (*s.orchestratorAdapter).DeleteVariable(request); err != nil {
+			return fmt.Errorf("error in retry DeleteVariable caller: %w", err)
+		}
+		return nil
+	}, s.MaxRetries, s.BaseDelay)
+
+	if err != nil {
+		return fmt.Errorf("couldn't call DeleteVariable: %w", err)
+	}
+	return nil
+}
@@ -74,13 +74,13 @@ func (h *AuthServiceHandler) Login(c echo.Context) error {
 			AccessToken:  response.AccessToken,
 			RefreshToken: response.RefreshToken,
 		})
-	case errors.As(err, &errs.ErrWrongPassword):
+	case errors.Is(errs.FromGRPC(err), errs.ErrWrongPassword):
 		return c.JSON(http.StatusUnauthorized, schemas.WrongCredentialsMsg)
-	case errors.As(err, &errs.ErrUserNotFound):
+	case errors.Is(errs.FromGRPC(err), errs.ErrUserNotFound):
 		return c.JSON(http.StatusUnauthorized, schemas.WrongCredentialsMsg)
-	case errors.As(err, &errs.ErrEmptyLogin):
+	case errors.Is(errs.FromGRPC(err), errs.ErrEmptyLogin):
 		return c.JSON(http.StatusBadRequest, schemas.EmptyLoginMsg)
-	case errors.As(err, &errs.ErrEmptyPassword):
+	case errors.Is(errs.FromGRPC(err), errs.ErrEmptyPassword):
 		return c.JSON(http.StatusBadRequest, schemas.EmptyPasswordMsg)
 	default:
 		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
@@ -113,11 +113,11 @@ func (h *AuthServiceHandler) Register(c echo.Context) error {
 	switch {
 	case err == nil:
 		return c.JSON(http.StatusOK, schemas.RegisterResponse{UserId: response.UserId})
-	case errors.As(err, &errs.ErrUserAlreadyExists):
+	case errors.Is(errs.FromGRPC(err), errs.ErrUserAlreadyExists):
 		return c.JSON(http.StatusUnauthorized, schemas.WrongCredentialsMsg)
-	case errors.As(err, &errs.ErrEmptyLogin):
+	case errors.Is(errs.FromGRPC(err), errs.ErrEmptyLogin):
 		return c.JSON(http.StatusBadRequest, schemas.EmptyLoginMsg)
-	case errors.As(err, &errs.ErrEmptyPassword):
+	case errors.Is(errs.FromGRPC(err), errs.ErrEmptyPassword):
 		return c.JSON(http.StatusBadRequest, schemas.EmptyPasswordMsg)
 	default:
 		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
@@ -165,11 +165,82 @@ func (h *AuthServiceHandler) Refresh(c echo.Context) error {
 		})
 
 		return c.NoContent(http.StatusNoContent)
-	case errors.As(err, &errs.ErrTokenExpired):
+	case errors.Is(errs.FromGRPC(err), errs.ErrTokenExpired):
 		return c.JSON(http.StatusUnauthorized, schemas.TokenExpiredMsg)
-	case errors.As(err, &errs.ErrInvalidToken):
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidToken):
 		return c.JSON(http.StatusUnauthorized, schemas.TokenExpiredOrInvalidMsg)
 	default:
 		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
 	}
 }
+
+// @Summary Logout user
+// @Description Revokes the access token of the request and the refresh token from the cookie. Clears the token cookies.
+// @Security Bearer <jwt_access_token>
+// @Tags Auth
+// @Produce json
+// @Success 204 "Tokens revoked"
+// @Failure 401 {object} schemas.TokenExpiredOrInvalid "Token expired or invalid"
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError "Internal server error"
+// @Router /logout [post]
+func (h *AuthServiceHandler) Logout(c echo.Context) error {
+	logoutRequest := &auth.LogoutRequest{
+		AccessToken: c.Get("accessToken").(string),
+	}
+	if refreshToken, err := c.Cookie("refresh_token"); err == nil {
+		logoutRequest.RefreshToken = refreshToken.Value
+	}
+	err := h.authService.Logout(logoutRequest)
+	switch {
+	case err == nil:
+		clearTokenCookies(c)
+		return c.NoContent(http.StatusNoContent)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidToken),
+		errors.Is(errs.FromGRPC(err), errs.ErrTokenExpired):
+		return c.JSON(http.StatusUnauthorized, schemas.TokenExpiredOrInvalidMsg)
+	default:
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Logout user on all devices
+// @Description Revokes every access and refresh token of the user, including the ones of the request. Clears the token cookies.
+// @Security Bearer <jwt_access_token>
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} schemas.LogoutAllResponse
+// @Failure 401 {object} schemas.TokenExpiredOrInvalid "Token expired or invalid"
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError "Internal server error"
+// @Router /logout-all [post]
+func (h *AuthServiceHandler) LogoutAll(c echo.Context) error {
+	response, err := h.authService.LogoutAll(&auth.LogoutAllRequest{
+		AccessToken: c.Get("accessToken").(string),
+	})
+	switch {
+	case err == nil:
+		clearTokenCookies(c)
+		return c.JSON(http.StatusOK, schemas.LogoutAllResponse{RevokedTokens: response.GetRevokedTokens()})
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidToken),
+		errors.Is(errs.FromGRPC(err), errs.ErrTokenExpired):
+		return c.JSON(http.StatusUnauthorized, schemas.TokenExpiredOrInvalidMsg)
+	default:
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// clearTokenCookies tells the browser to drop the cookies set by Login and Refresh
+func clearTokenCookies(c echo.Context) {
+	for _, name := range []string{"access_token", "refresh_token"} {
+		c.SetCookie(&http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			HttpOnly: true,
+			MaxAge:   -1,
+		})
+	}
+}
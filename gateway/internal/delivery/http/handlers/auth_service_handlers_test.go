@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	errs "github.com/jaam8/web_calculator/common-lib/errors"
+	auth "github.com/jaam8/web_calculator/common-lib/gen/auth_service"
+	"github.com/jaam8/web_calculator/gateway/internal/delivery/grpc"
+	"github.com/jaam8/web_calculator/gateway/internal/delivery/http/schemas"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeAuthAdapter answers every call with err, the way the auth service client returns it
+type fakeAuthAdapter struct {
+	err error
+}
+
+func (a *fakeAuthAdapter) Login(*auth.LoginRequest) (*auth.LoginResponse, error) {
+	return nil, a.err
+}
+
+func (a *fakeAuthAdapter) Register(*auth.RegisterRequest) (*auth.RegisterResponse, error) {
+	return nil, a.err
+}
+
+func (a *fakeAuthAdapter) Refresh(*auth.RefreshRequest) (*auth.RefreshResponse, error) {
+	return nil, a.err
+}
+
+func (a *fakeAuthAdapter) Logout(*auth.LogoutRequest) error {
+	return a.err
+}
+
+func (a *fakeAuthAdapter) LogoutAll(*auth.LogoutAllRequest) (*auth.LogoutAllResponse, error) {
+	return nil, a.err
+}
+
+func TestAuthServiceHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        func(h *AuthServiceHandler) echo.HandlerFunc
+		err            error
+		expectedStatus int
+		expectedBody   any
+	}{
+		{
+			name:           "login wrong password",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Login },
+			err:            errs.ErrWrongPassword,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   schemas.WrongCredentialsMsg,
+		},
+		{
+			name:           "login user not found",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Login },
+			err:            errs.ErrUserNotFound,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   schemas.WrongCredentialsMsg,
+		},
+		{
+			name:           "login empty login",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Login },
+			err:            errs.ErrEmptyLogin,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   schemas.EmptyLoginMsg,
+		},
+		{
+			name:           "login empty password",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Login },
+			err:            errs.ErrEmptyPassword,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   schemas.EmptyPasswordMsg,
+		},
+		{
+			name:           "login unavailable",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Login },
+			err:            status.Error(codes.Unavailable, "connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   schemas.InternalServerErrorMsg,
+		},
+		{
+			name:           "register taken login",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Register },
+			err:            errs.ErrUserAlreadyExists,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   schemas.WrongCredentialsMsg,
+		},
+		{
+			name:           "register empty password",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Register },
+			err:            errs.ErrEmptyPassword,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   schemas.EmptyPasswordMsg,
+		},
+		{
+			name:           "refresh expired token",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Refresh },
+			err:            errs.ErrTokenExpired,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   schemas.TokenExpiredMsg,
+		},
+		{
+			name:           "refresh invalid token",
+			handler:        func(h *AuthServiceHandler) echo.HandlerFunc { return h.Refresh },
+			err:            errs.ErrInvalidToken,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   schemas.TokenExpiredOrInvalidMsg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// only the message of the sentinel reaches the gateway over gRPC
+			err, ok := status.FromError(tt.err)
+			if !ok {
+				err = status.New(codes.Unknown, tt.err.Error())
+			}
+			authService := grpc.NewAuthService(&fakeAuthAdapter{err: err.Err()}, 0, 0)
+			h := NewAuthServiceHandler(authService, 0, 0)
+
+			request := httptest.NewRequest(http.MethodPost, "/",
+				strings.NewReader(`{"login":"user","password":"password"}`))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.AddCookie(&http.Cookie{Name: "refresh_token", Value: "token"})
+			recorder := httptest.NewRecorder()
+
+			assert.NoError(t, tt.handler(h)(echo.New().NewContext(request, recorder)))
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			expectedBody, _ := json.Marshal(tt.expectedBody)
+			assert.JSONEq(t, string(expectedBody), recorder.Body.String())
+		})
+	}
+}
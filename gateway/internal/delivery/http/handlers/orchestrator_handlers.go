@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	errs "github.com/jaam8/web_calculator/common-lib/errors"
 	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
@@ -11,7 +14,13 @@ import (
 	"github.com/labstack/echo/v4"
 	_ "github.com/swaggo/echo-swagger"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type OrchestratorHandler struct {
@@ -25,32 +34,252 @@ func NewOrchestratorHandler(orchestratorService *grpc.OrchestratorService) *Orch
 }
 
 // @Summary Calculate mathematical expression
-// @Description Evaluates a mathematical expression and returns the result
+// @Description Evaluates a mathematical expression and returns the result.
+// @Description With "decimal" precision operands and the result are exact decimals, e.g. 0.1+0.2 = 0.3
+// @Description Tasks of expressions with a higher priority are computed first, users with equal priority take turns
+// @Description An expression not calculated within timeout_ms (or the server default) gets the "timed out" status
+// @Description Results of identical expressions and subexpressions are taken from the cache unless no_cache is set
 // @Security Bearer <jwt_access_token>
 // @Tags Orchestrator
 // @Accept json
 // @Produce json
 // @Param expression body schemas.CalculateRequest true "Expression to calculate"
 // @Success 201 {object} schemas.CalculateResponse
+// @Failure 400 {object} schemas.UnknownPrecision
+// @Failure 400 {object} schemas.InvalidPriority
+// @Failure 400 {object} schemas.InvalidTimeout
 // @Failure 422 {object} schemas.CannotParseExpression
+// @Failure 429 {object} schemas.TooManyRequests
+// @Failure 429 {object} schemas.QuotaExceeded
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
 // @Failure 500 {object} schemas.InternalServerError
+// @Failure 503 {object} schemas.QueueFull
 // @Router /calculate [post]
 func (h *OrchestratorHandler) Calculate(c echo.Context) error {
 	var request schemas.CalculateRequest
 	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusUnprocessableEntity, schemas.CannotParseExpressionMsg)
 	}
+	precision, ok := precisions[request.Precision]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, schemas.UnknownPrecisionMsg)
+	}
 	calculateRequest := &orchestrator.CalculateRequest{
 		UserId:     c.Get("userID").(string),
 		Expression: request.Expression,
+		Precision:  precision,
+		Priority:   request.Priority,
+		Timeout:    timeout(request.TimeoutMs),
+		NoCache:    request.NoCache,
 	}
 	response, err := h.orchestratorService.Calculate(calculateRequest)
 
 	switch {
 	case err == nil:
 		return c.JSON(http.StatusCreated, response)
-	case errors.As(err, &errs.ErrInvalidExpression):
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidExpression):
+		return c.JSON(http.StatusUnprocessableEntity, cannotParseExpression(err))
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidPriority):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidPriorityMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidTimeout):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidTimeoutMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrQueueFull):
+		return c.JSON(http.StatusServiceUnavailable, schemas.QueueFullMsg)
+	default:
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// precisions maps the precision of a calculate request to the orchestrator one, empty means float
+var precisions = map[string]orchestrator.Precision{
+	"":        orchestrator.Precision_PRECISION_FLOAT,
+	"float":   orchestrator.Precision_PRECISION_FLOAT,
+	"decimal": orchestrator.Precision_PRECISION_DECIMAL,
+}
+
+// cannotParseExpression adds the position of the syntax error from the gRPC status details, if any
+func cannotParseExpression(err error) schemas.CannotParseExpression {
+	response := schemas.CannotParseExpressionMsg
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return response
+	}
+	for _, detail := range grpcErr.GRPCStatus().Details() {
+		if syntaxErr, ok := detail.(*orchestrator.SyntaxError); ok {
+			response.Message = syntaxErr.GetMessage()
+			response.Column = int(syntaxErr.GetColumn())
+		}
+	}
+	return response
+}
+
+// timeout converts timeout_ms of a calculate request, 0 means the server default
+func timeout(ms int64) *durationpb.Duration {
+	if ms == 0 {
+		return nil
+	}
+	return durationpb.New(time.Duration(ms) * time.Millisecond)
+}
+
+// @Summary Explain how an expression would be calculated
+// @Description Shows the RPN and the tasks the expression would be split into, when each of them can start,
+// @Description the critical path and the time estimated from the operation times and the registered agents.
+// @Description Nothing is saved or computed
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Accept json
+// @Produce json
+// @Param expression body schemas.ExplainRequest true "Expression to explain"
+// @Success 200 {object} schemas.ExplainResponse
+// @Failure 400 {object} schemas.UnknownPrecision
+// @Failure 422 {object} schemas.CannotParseExpression
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /calculate/explain [post]
+func (h *OrchestratorHandler) Explain(c echo.Context) error {
+	var request schemas.ExplainRequest
+	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusUnprocessableEntity, schemas.CannotParseExpressionMsg)
+	}
+	precision, ok := precisions[request.Precision]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, schemas.UnknownPrecisionMsg)
+	}
+	response, err := h.orchestratorService.Explain(&orchestrator.ExplainRequest{
+		UserId:     c.Get("userID").(string),
+		Expression: request.Expression,
+		Precision:  precision,
+	})
+
+	switch {
+	case err == nil:
+		return c.JSON(http.StatusOK, explainSchema(response))
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidExpression):
+		return c.JSON(http.StatusUnprocessableEntity, cannotParseExpression(err))
+	default:
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// explainSchema converts the plan of the orchestrator to the response one
+func explainSchema(plan *orchestrator.ExplainResponse) schemas.ExplainResponse {
+	response := schemas.ExplainResponse{
+		Rpn:                plan.GetRpn(),
+		Tasks:              make([]schemas.ExplainTask, 0, len(plan.GetTasks())),
+		TasksSaved:         plan.GetTasksSaved(),
+		Agents:             plan.GetAgents(),
+		Slots:              plan.GetSlots(),
+		CriticalPath:       plan.GetCriticalPath(),
+		CriticalPathTimeMs: plan.GetCriticalPathTime().AsDuration().Milliseconds(),
+	}
+	if response.CriticalPath == nil {
+		response.CriticalPath = []int32{}
+	}
+	if plan.GetEstimatedTime() != nil {
+		estimated := plan.GetEstimatedTime().AsDuration().Milliseconds()
+		response.EstimatedTimeMs = &estimated
+	}
+	for _, task := range plan.GetTasks() {
+		operands := make([]schemas.ExplainOperand, len(task.GetOperands()))
+		for i, operand := range task.GetOperands() {
+			switch value := operand.GetOperand().(type) {
+			case *orchestrator.ExplainOperand_TaskId:
+				operands[i].TaskId = &value.TaskId
+			case *orchestrator.ExplainOperand_Constant:
+				operands[i].Constant = &value.Constant
+			}
+		}
+		response.Tasks = append(response.Tasks, schemas.ExplainTask{
+			Id:              task.GetId(),
+			Operation:       task.GetOperation(),
+			Operands:        operands,
+			OperationTimeMs: task.GetOperationTime().AsDuration().Milliseconds(),
+			EarliestStartMs: task.GetEarliestStart().AsDuration().Milliseconds(),
+		})
+	}
+	return response
+}
+
+// @Summary Calculate a batch of expressions
+// @Description Accepts up to 1000 expressions at once, precision, priority and timeout_ms apply to each of them.
+// @Description Expressions that cannot be parsed are reported in their items and do not reject the batch,
+// @Description the progress of the accepted ones is available by batch_id. Every expression counts against the daily quota
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Accept json
+// @Produce json
+// @Param batch body schemas.CalculateBatchRequest true "Expressions to calculate"
+// @Success 201 {object} schemas.CalculateBatchResponse
+// @Failure 400 {object} schemas.UnknownPrecision
+// @Failure 400 {object} schemas.InvalidPriority
+// @Failure 400 {object} schemas.InvalidTimeout
+// @Failure 400 {object} schemas.InvalidBatch
+// @Failure 422 {object} schemas.CalculateBatchResponse
+// @Failure 429 {object} schemas.TooManyRequests
+// @Failure 429 {object} schemas.QuotaExceeded
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Failure 503 {object} schemas.QueueFull
+// @Router /calculate/batch [post]
+func (h *OrchestratorHandler) CalculateBatch(c echo.Context) error {
+	var request schemas.CalculateBatchRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, schemas.InvalidBatchMsg)
+	}
+	precision, ok := precisions[request.Precision]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, schemas.UnknownPrecisionMsg)
+	}
+	batchRequest := &orchestrator.CalculateBatchRequest{
+		UserId:      c.Get("userID").(string),
+		Expressions: request.Expressions,
+		Precision:   precision,
+		Priority:    request.Priority,
+		Timeout:     timeout(request.TimeoutMs),
+		NoCache:     request.NoCache,
+	}
+	response, err := h.orchestratorService.CalculateBatch(batchRequest)
+
+	switch {
+	case err == nil:
+		batch := schemas.CalculateBatchResponse{
+			BatchId: response.GetBatchId(),
+			Items:   make([]schemas.BatchItem, len(response.GetItems())),
+		}
+		for i, item := range response.GetItems() {
+			batch.Items[i].Id = item.GetId()
+			switch {
+			case item.GetError() != nil && item.GetId() != "":
+				// the expression is saved, but failed to start
+				batch.Items[i].Error = schemas.InternalServerErrorMsg.Error
+			case item.GetError() != nil:
+				batch.Items[i].Error = schemas.CannotParseExpressionMsg.Error
+				batch.Items[i].Message = item.GetError().GetMessage()
+				batch.Items[i].Column = int(item.GetError().GetColumn())
+			}
+		}
+		// nothing is created when none of the expressions can be parsed
+		if batch.BatchId == "" {
+			return c.JSON(http.StatusUnprocessableEntity, batch)
+		}
+		return c.JSON(http.StatusCreated, batch)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidBatch):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidBatchMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidPriority):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidPriorityMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidTimeout):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidTimeoutMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrQueueFull):
+		return c.JSON(http.StatusServiceUnavailable, schemas.QueueFullMsg)
 	default:
 		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
 			c.Request().Context(),
@@ -60,38 +289,210 @@ func (h *OrchestratorHandler) Calculate(c echo.Context) error {
 	}
 }
 
+// @Summary Get batch progress
+// @Description Counts expressions of a batch by status
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Produce json
+// @Param id path string true "Batch ID"
+// @Success 200 {object} schemas.BatchProgressResponse
+// @Failure 404 {object} schemas.CannotParseId
+// @Failure 404 {object} schemas.BatchNotFound
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /calculate/batch/{id} [get]
+func (h *OrchestratorHandler) BatchProgress(c echo.Context) error {
+	batchId := c.Param("id")
+	if _, err := uuid.Parse(batchId); err != nil {
+		return c.JSON(http.StatusNotFound, schemas.CannotParseIdMsg)
+	}
+
+	req := &orchestrator.BatchProgressRequest{
+		UserId:  c.Get("userID").(string),
+		BatchId: batchId,
+	}
+	progress, err := h.orchestratorService.BatchProgress(req)
+	switch {
+	case err == nil:
+		response := schemas.BatchProgressResponse{
+			BatchId:  progress.GetBatchId(),
+			Total:    int(progress.GetTotal()),
+			Pending:  int(progress.GetPending()),
+			Done:     int(progress.GetDone()),
+			Failed:   int(progress.GetFailed()),
+			Statuses: make(map[string]int, len(progress.GetStatuses())),
+		}
+		for name, count := range progress.GetStatuses() {
+			response.Statuses[name] = int(count)
+		}
+		return c.JSON(http.StatusOK, response)
+	case errors.Is(errs.FromGRPC(err), errs.ErrBatchNotFound):
+		return c.JSON(http.StatusNotFound, schemas.BatchNotFoundMsg)
+	default:
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Get result cache statistics
+// @Description Reports how many tasks the result cache of the orchestrator saved since it started
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Produce json
+// @Success 200 {object} schemas.CacheStatsResponse
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /cache/stats [get]
+func (h *OrchestratorHandler) CacheStats(c echo.Context) error {
+	stats, err := h.orchestratorService.CacheStats()
+	if err != nil {
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+	return c.JSON(http.StatusOK, schemas.CacheStatsResponse{
+		Enabled:      stats.GetEnabled(),
+		Hits:         stats.GetHits(),
+		Misses:       stats.GetMisses(),
+		HitRate:      stats.GetHitRate(),
+		Deduplicated: stats.GetDeduplicated(),
+		Expressions:  stats.GetExpressions(),
+	})
+}
+
 // @Summary Get all expressions
-// @Description Returns a list of all calculated expressions
+// @Description Returns a page of expressions, newest first by default. Pass next_cursor of the response
+// @Description as cursor to get the next page, the filters and the order must stay the same
 // @Security Bearer <jwt_access_token>
 // @Tags Orchestrator
 // @Produce json
+// @Param limit query int false "Page size from 1 to 100" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param status query []string false "Only expressions with one of the statuses" collectionFormat(multi)
+// @Param from query string false "Only expressions created at or after, RFC 3339" format(date-time)
+// @Param to query string false "Only expressions created before, RFC 3339" format(date-time)
+// @Param order query string false "Sort order by creation time" Enums(newest, oldest) default(newest)
 // @Success 200 {object} schemas.ExpressionsResponse
+// @Failure 400 {object} schemas.InvalidDate
+// @Failure 400 {object} schemas.UnknownOrder
+// @Failure 400 {object} schemas.InvalidCursor
+// @Failure 400 {object} schemas.InvalidPageSize
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
 // @Failure 500 {object} schemas.InternalServerError
 // @Router /expressions [get]
 func (h *OrchestratorHandler) Expressions(c echo.Context) error {
 	req := &orchestrator.ExpressionsRequest{
 		UserId: c.Get("userID").(string),
+		Cursor: c.QueryParam("cursor"),
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		size, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, schemas.InvalidPageSizeMsg)
+		}
+		req.Limit = int32(size)
+	}
+	// statuses may be repeated or comma separated
+	for _, param := range c.QueryParams()["status"] {
+		for _, status := range strings.Split(param, ",") {
+			if status != "" {
+				req.Statuses = append(req.Statuses, status)
+			}
+		}
+	}
+	for param, bound := range map[string]**timestamppb.Timestamp{"from": &req.CreatedFrom, "to": &req.CreatedTo} {
+		value := c.QueryParam(param)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, schemas.InvalidDateMsg)
+		}
+		*bound = timestamppb.New(date)
 	}
+	order, ok := sortOrders[c.QueryParam("order")]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, schemas.UnknownOrderMsg)
+	}
+	req.Order = order
 
 	expressions, err := h.orchestratorService.Expressions(req)
-	if err != nil {
+	switch {
+	case err == nil:
+		response := schemas.ExpressionsResponse{
+			Expressions: make([]schemas.Expression, len(expressions.GetExpressions())),
+			NextCursor:  expressions.GetNextCursor(),
+		}
+		for i, expression := range expressions.GetExpressions() {
+			response.Expressions[i] = expressionSchema(expression)
+		}
+		return c.JSON(http.StatusOK, response)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidPageSize):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidPageSizeMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidCursor):
+		return c.JSON(http.StatusBadRequest, schemas.InvalidCursorMsg)
+	default:
 		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
 			c.Request().Context(),
 			"error in orchestrator service",
 			zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
 	}
-	return c.JSON(http.StatusOK, expressions)
+}
+
+// sortOrders maps the order query param to the orchestrator one, empty means newest first
+var sortOrders = map[string]orchestrator.SortOrder{
+	"":       orchestrator.SortOrder_SORT_ORDER_NEWEST_FIRST,
+	"newest": orchestrator.SortOrder_SORT_ORDER_NEWEST_FIRST,
+	"oldest": orchestrator.SortOrder_SORT_ORDER_OLDEST_FIRST,
+}
+
+// expressionSchema converts an expression of the orchestrator to the response one
+func expressionSchema(expression *orchestrator.Expression) schemas.Expression {
+	response := schemas.Expression{
+		Id:            expression.GetId(),
+		Expression:    expression.GetExpression(),
+		Normalized:    expression.GetNormalized(),
+		Status:        expression.GetStatus(),
+		Result:        expression.Result,
+		DecimalResult: expression.DecimalResult,
+		Error:         expression.Error,
+		TasksSaved:    expression.GetTasksSaved(),
+	}
+	if expression.GetCreatedAt() != nil {
+		createdAt := expression.GetCreatedAt().AsTime()
+		response.CreatedAt = &createdAt
+	}
+	if expression.GetStartedAt() != nil {
+		startedAt := expression.GetStartedAt().AsTime()
+		response.StartedAt = &startedAt
+	}
+	if expression.GetFinishedAt() != nil {
+		finishedAt := expression.GetFinishedAt().AsTime()
+		response.FinishedAt = &finishedAt
+	}
+	return response
 }
 
 // @Summary Get expression by ID
-// @Description Returns a specific expression by its ID
+// @Description Returns a specific expression by its ID. If the computation failed, the status holds the reason, e.g. "division by zero"
 // @Security Bearer <jwt_access_token>
 // @Tags Orchestrator
 // @Produce json
 // @Param id path int true "Expression ID"
 // @Success 200 {object} schemas.ExpressionByIdResponse
 // @Failure 404 {object} schemas.ExpressionNotFound
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
 // @Failure 500 {object} schemas.InternalServerError
 // @Router /expressions/{id} [get]
 func (h *OrchestratorHandler) ExpressionByID(c echo.Context) error {
@@ -110,10 +511,317 @@ func (h *OrchestratorHandler) ExpressionByID(c echo.Context) error {
 	expression, err := h.orchestratorService.ExpressionByID(req)
 	switch {
 	case err == nil:
-		return c.JSON(http.StatusOK, expression)
-	case errors.As(err, &errs.ErrExpressionNotFound):
+		return c.JSON(http.StatusOK, schemas.ExpressionByIdResponse{
+			Expression: expressionSchema(expression.GetExpression()),
+		})
+	case errors.Is(errs.FromGRPC(err), errs.ErrExpressionNotFound):
+		return c.JSON(http.StatusNotFound, schemas.ExpressionNotFoundMsg)
+	default:
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Get tasks of an expression
+// @Description Returns the tasks the expression was split into with their operands, results, errors,
+// @Description the agents that computed them and when they were queued, handed out and finished
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Produce json
+// @Param id path string true "Expression ID"
+// @Success 200 {object} schemas.ExpressionTasksResponse
+// @Failure 404 {object} schemas.ExpressionNotFound
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /expressions/{id}/tasks [get]
+func (h *OrchestratorHandler) ExpressionTasks(c echo.Context) error {
+	exprId := c.Param("id")
+	if _, err := uuid.Parse(exprId); err != nil {
+		return c.JSON(http.StatusNotFound, schemas.CannotParseIdMsg)
+	}
+
+	response, err := h.orchestratorService.ExpressionTasks(&orchestrator.ExpressionTasksRequest{
+		UserId: c.Get("userID").(string),
+		Id:     exprId,
+	})
+	switch {
+	case err == nil:
+		tasks := make([]schemas.TaskTrace, 0, len(response.GetTasks()))
+		for _, task := range response.GetTasks() {
+			tasks = append(tasks, taskTraceSchema(task))
+		}
+		return c.JSON(http.StatusOK, schemas.ExpressionTasksResponse{Tasks: tasks})
+	case errors.Is(errs.FromGRPC(err), errs.ErrExpressionNotFound):
 		return c.JSON(http.StatusNotFound, schemas.ExpressionNotFoundMsg)
 	default:
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// taskTraceSchema converts a task of the orchestrator to the response one
+func taskTraceSchema(task *orchestrator.TaskTrace) schemas.TaskTrace {
+	response := schemas.TaskTrace{
+		Id:            task.GetId(),
+		Operation:     task.GetOperation(),
+		Args:          task.GetArgs(),
+		DecimalArgs:   task.GetDecimalArgs(),
+		Status:        task.GetStatus(),
+		Result:        task.Result,
+		DecimalResult: task.DecimalResult,
+		Error:         task.Error,
+		AgentId:       task.GetAgentId(),
+		Attempts:      task.GetAttempts(),
+	}
+	if task.GetQueuedAt() != nil {
+		queuedAt := task.GetQueuedAt().AsTime()
+		response.QueuedAt = &queuedAt
+	}
+	if task.GetStartedAt() != nil {
+		startedAt := task.GetStartedAt().AsTime()
+		response.StartedAt = &startedAt
+	}
+	if task.GetFinishedAt() != nil {
+		finishedAt := task.GetFinishedAt().AsTime()
+		response.FinishedAt = &finishedAt
+	}
+	return response
+}
+
+// @Summary Cancel expression
+// @Description Stops calculating a pending expression: its queued tasks are dropped,
+// @Description agents abandon the computing ones and the expression gets the "cancelled" status
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Param id path string true "Expression ID"
+// @Success 204
+// @Failure 404 {object} schemas.ExpressionNotFound
+// @Failure 409 {object} schemas.ExpressionFinished
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /expressions/{id} [delete]
+func (h *OrchestratorHandler) CancelExpression(c echo.Context) error {
+	exprId := c.Param("id")
+	if _, err := uuid.Parse(exprId); err != nil {
+		return c.JSON(http.StatusNotFound, schemas.CannotParseIdMsg)
+	}
+
+	req := &orchestrator.CancelExpressionRequest{
+		UserId: c.Get("userID").(string),
+		Id:     exprId,
+	}
+	err := h.orchestratorService.CancelExpression(req)
+	switch {
+	case err == nil:
+		return c.NoContent(http.StatusNoContent)
+	case errors.Is(errs.FromGRPC(err), errs.ErrExpressionNotFound):
+		return c.JSON(http.StatusNotFound, schemas.ExpressionNotFoundMsg)
+	case errors.Is(errs.FromGRPC(err), errs.ErrExpressionFinished):
+		return c.JSON(http.StatusConflict, schemas.ExpressionFinishedMsg)
+	default:
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Set variable
+// @Description Creates or updates a named variable that can be used in expressions, e.g. "rate * hours"
+// @Security Bearer <jwt_access_token>
+// @Tags Variables
+// @Accept json
+// @Produce json
+// @Param name path string true "Variable name"
+// @Param variable body schemas.SetVariableRequest true "Variable value"
+// @Success 200 {object} schemas.SetVariableResponse
+// @Failure 422 {object} schemas.InvalidVariableName
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /variables/{name} [put]
+func (h *OrchestratorHandler) SetVariable(c echo.Context) error {
+	var request schemas.SetVariableRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusUnprocessableEntity, schemas.CannotParseRequestMsg)
+	}
+	req := &orchestrator.SetVariableRequest{
+		UserId: c.Get("userID").(string),
+		Name:   c.Param("name"),
+		Value:  request.Value,
+	}
+	response, err := h.orchestratorService.SetVariable(req)
+	switch {
+	case err == nil:
+		return c.JSON(http.StatusOK, response)
+	case errors.Is(errs.FromGRPC(err), errs.ErrInvalidVariableName):
+		return c.JSON(http.StatusUnprocessableEntity, schemas.InvalidVariableNameMsg)
+	default:
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Get all variables
+// @Description Returns a list of the user's variables sorted by name
+// @Security Bearer <jwt_access_token>
+// @Tags Variables
+// @Produce json
+// @Success 200 {object} schemas.VariablesResponse
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /variables [get]
+func (h *OrchestratorHandler) Variables(c echo.Context) error {
+	req := &orchestrator.VariablesRequest{
+		UserId: c.Get("userID").(string),
+	}
+
+	variables, err := h.orchestratorService.Variables(req)
+	if err != nil {
+		logger.GetOrCreateLoggerFromCtx(c.Request().Context()).Error(
+			c.Request().Context(),
+			"error in orchestrator service",
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+	return c.JSON(http.StatusOK, variables)
+}
+
+// @Summary Get variable by name
+// @Description Returns a specific variable by its name
+// @Security Bearer <jwt_access_token>
+// @Tags Variables
+// @Produce json
+// @Param name path string true "Variable name"
+// @Success 200 {object} schemas.VariableByNameResponse
+// @Failure 404 {object} schemas.VariableNotFound
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /variables/{name} [get]
+func (h *OrchestratorHandler) VariableByName(c echo.Context) error {
+	req := &orchestrator.VariableByNameRequest{
+		UserId: c.Get("userID").(string),
+		Name:   c.Param("name"),
+	}
+	variable, err := h.orchestratorService.VariableByName(req)
+	switch {
+	case err == nil:
+		return c.JSON(http.StatusOK, variable)
+	case errors.Is(errs.FromGRPC(err), errs.ErrVariableNotFound):
+		return c.JSON(http.StatusNotFound, schemas.VariableNotFoundMsg)
+	default:
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Delete variable
+// @Description Deletes a variable by its name
+// @Security Bearer <jwt_access_token>
+// @Tags Variables
+// @Param name path string true "Variable name"
+// @Success 204
+// @Failure 404 {object} schemas.VariableNotFound
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /variables/{name} [delete]
+func (h *OrchestratorHandler) DeleteVariable(c echo.Context) error {
+	req := &orchestrator.DeleteVariableRequest{
+		UserId: c.Get("userID").(string),
+		Name:   c.Param("name"),
+	}
+	err := h.orchestratorService.DeleteVariable(req)
+	switch {
+	case err == nil:
+		return c.NoContent(http.StatusNoContent)
+	case errors.Is(errs.FromGRPC(err), errs.ErrVariableNotFound):
+		return c.JSON(http.StatusNotFound, schemas.VariableNotFoundMsg)
+	default:
+		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+	}
+}
+
+// @Summary Watch expression status
+// @Description Streams status transitions of the expression (pending, in progress, done or the error)
+// @Description as Server-Sent Events until it is done or failed. Each event is "status" with the JSON in data
+// @Security Bearer <jwt_access_token>
+// @Tags Orchestrator
+// @Produce text/event-stream
+// @Param id path string true "Expression ID"
+// @Success 200 {object} schemas.ExpressionEvent
+// @Failure 404 {object} schemas.ExpressionNotFound
+// @Failure 429 {object} schemas.TooManyRequests
+// @Header 429 {integer} Retry-After "Seconds until the limit is reset"
+// @Failure 500 {object} schemas.InternalServerError
+// @Router /expressions/{id}/events [get]
+func (h *OrchestratorHandler) WatchExpression(c echo.Context) error {
+	exprId := c.Param("id")
+	if _, err := uuid.Parse(exprId); err != nil {
+		return c.JSON(http.StatusNotFound, schemas.CannotParseIdMsg)
+	}
+	req := &orchestrator.WatchExpressionRequest{
+		UserId: c.Get("userID").(string),
+		Id:     exprId,
+	}
+
+	ctx := c.Request().Context()
+	response := c.Response()
+	// headers are written with the first event, so that errors before it are plain JSON responses
+	started := false
+	err := h.orchestratorService.WatchExpression(ctx, req, func(event *orchestrator.ExpressionEvent) error {
+		if !started {
+			response.Header().Set(echo.HeaderContentType, "text/event-stream")
+			response.Header().Set(echo.HeaderCacheControl, "no-cache")
+			response.Header().Set(echo.HeaderConnection, "keep-alive")
+			response.WriteHeader(http.StatusOK)
+			started = true
+		}
+		data, err := json.Marshal(schemas.ExpressionEvent{
+			Id:            event.GetId(),
+			Status:        event.GetStatus(),
+			Result:        event.Result,
+			DecimalResult: event.DecimalResult,
+			TasksDone:     int(event.GetTasksDone()),
+			TasksTotal:    int(event.GetTasksTotal()),
+			ElapsedMs:     event.GetElapsed().AsDuration().Milliseconds(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(response, "event: status\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+		response.Flush()
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		return nil
+	case started:
+		// the client has gone away or the stream broke, the response is already sent
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx,
+				"expression events stream broke",
+				zap.Error(err))
+		}
+		return nil
+	case errors.Is(errs.FromGRPC(err), errs.ErrExpressionNotFound):
+		return c.JSON(http.StatusNotFound, schemas.ExpressionNotFoundMsg)
+	default:
+		logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx,
+			"error in orchestrator service",
+			zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
 	}
 }
@@ -4,13 +4,19 @@ import (
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	errs "github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/gateway/internal/delivery/http/schemas"
+	"github.com/jaam8/web_calculator/gateway/internal/ports"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 	"net/http"
 	"strings"
 	"time"
 )
 
-func AuthMiddleware(jwtSecret string) echo.MiddlewareFunc {
+// AuthMiddleware accepts signed access tokens that the auth service has not revoked yet.
+// Sets userID and accessToken of the request
+func AuthMiddleware(jwtSecret string, tokens ports.TokenAdapter) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			var accessToken string
@@ -43,7 +49,21 @@ func AuthMiddleware(jwtSecret string) echo.MiddlewareFunc {
 				return echo.NewHTTPError(http.StatusUnauthorized, errs.ErrTokenExpired)
 			}
 
+			owner, err := tokens.AccessTokenOwner(accessToken)
+			if err != nil {
+				// unlike the rate limit, a revoked token must not pass while redis is unavailable
+				ctx := c.Request().Context()
+				logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx, "failed to check access token",
+					zap.String("user_id", sub),
+					zap.Error(err))
+				return c.JSON(http.StatusInternalServerError, schemas.InternalServerErrorMsg)
+			}
+			if owner != sub {
+				return echo.NewHTTPError(http.StatusUnauthorized, errs.ErrInvalidToken)
+			}
+
 			c.Set("userID", sub)
+			c.Set("accessToken", accessToken)
 
 			return next(c)
 		}
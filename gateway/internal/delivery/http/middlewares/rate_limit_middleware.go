@@ -0,0 +1,110 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/gateway/internal/delivery/http/schemas"
+	"github.com/jaam8/web_calculator/gateway/internal/ports"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitMiddleware allows a user at most limit requests per minute, 0 disables the limit.
+// Must be used after AuthMiddleware
+func RateLimitMiddleware(limiter ports.RateLimitAdapter, limit int) echo.MiddlewareFunc {
+	return limitMiddleware(limiter, limit, schemas.TooManyRequestsMsg, nil, false,
+		func(userID string, _ time.Time) (string, time.Duration) {
+			return fmt.Sprintf("rate:%s", userID), time.Minute
+		})
+}
+
+// QuotaMiddleware allows a user at most quota calculations per day (UTC), 0 disables the quota.
+// cost returns how many calculations the request starts, nil - one per request. Only calculations
+// the handler accepts are counted: requests rejected by the quota or answered with an error are refunded.
+// Must be used after AuthMiddleware
+func QuotaMiddleware(limiter ports.RateLimitAdapter, quota int, cost func(c echo.Context) int64) echo.MiddlewareFunc {
+	return limitMiddleware(limiter, quota, schemas.QuotaExceededMsg, cost, true, quotaWindow)
+}
+
+// quotaWindow is the window of QuotaMiddleware: the current day (UTC)
+func quotaWindow(userID string, now time.Time) (string, time.Duration) {
+	now = now.UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("quota:%s:%s", userID, now.Format(time.DateOnly)), tomorrow.Sub(now)
+}
+
+// BatchCost is the cost of a batch request for QuotaMiddleware: the number of its expressions.
+// The body is read ahead of the handler and restored for it, a malformed body costs one
+func BatchCost(c echo.Context) int64 {
+	body, err := io.ReadAll(c.Request().Body)
+	c.Request().Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return 1
+	}
+	var batch struct {
+		Expressions []json.RawMessage `json:"expressions"`
+	}
+	if err = json.Unmarshal(body, &batch); err != nil {
+		return 1
+	}
+	return max(int64(len(batch.Expressions)), 1)
+}
+
+// limitMiddleware counts requests of a user in the window returned by window, each request
+// costs cost(c) or one if cost is nil, and rejects them with 429 and Retry-After once there are more than limit.
+// refund - the cost of requests rejected with 429 or answered by the handler with an error is taken back
+func limitMiddleware(limiter ports.RateLimitAdapter, limit int, response any, cost func(c echo.Context) int64,
+	refund bool, window func(userID string, now time.Time) (string, time.Duration)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if limit <= 0 {
+			return next
+		}
+		return func(c echo.Context) error {
+			userID, _ := c.Get("userID").(string)
+			key, ttl := window(userID, time.Now())
+			hits := int64(1)
+			if cost != nil {
+				hits = cost(c)
+			}
+			count, left, err := limiter.Hit(key, ttl, hits)
+			if err != nil {
+				// an unavailable limiter must not take the whole API down
+				ctx := c.Request().Context()
+				logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx, "failed to check rate limit",
+					zap.String("key", key),
+					zap.Error(err))
+				return next(c)
+			}
+			if count > int64(limit) {
+				if refund {
+					refundHits(c, limiter, key, hits)
+				}
+				retryAfter := int(math.Ceil(left.Seconds()))
+				c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				return c.JSON(http.StatusTooManyRequests, response)
+			}
+			err = next(c)
+			if refund && (err != nil || c.Response().Status >= http.StatusBadRequest) {
+				refundHits(c, limiter, key, hits)
+			}
+			return err
+		}
+	}
+}
+
+// refundHits takes back hits of a request that was not accepted
+func refundHits(c echo.Context, limiter ports.RateLimitAdapter, key string, hits int64) {
+	if err := limiter.Refund(key, hits); err != nil {
+		ctx := c.Request().Context()
+		logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx, "failed to refund rate limit",
+			zap.String("key", key),
+			zap.Error(err))
+	}
+}
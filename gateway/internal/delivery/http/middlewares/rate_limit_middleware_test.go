@@ -0,0 +1,135 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeLimiter counts requests in memory, every key is one window
+type fakeLimiter struct {
+	counts map[string]int64
+}
+
+func (l *fakeLimiter) Hit(key string, window time.Duration, cost int64) (int64, time.Duration, error) {
+	l.counts[key] += cost
+	return l.counts[key], window, nil
+}
+
+func (l *fakeLimiter) Refund(key string, cost int64) error {
+	l.counts[key] -= cost
+	return nil
+}
+
+// total returns the number of requests counted for all keys
+func (l *fakeLimiter) total() int64 {
+	var total int64
+	for _, count := range l.counts {
+		total += count
+	}
+	return total
+}
+
+func TestQuotaMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		quota          int
+		used           int64
+		body           string
+		cost           func(c echo.Context) int64
+		handlerStatus  int
+		expectedStatus int
+		expectedUsed   int64
+	}{
+		{
+			name:           "accepted calculation is counted",
+			quota:          2,
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusCreated,
+			expectedUsed:   1,
+		},
+		{
+			name:           "rejected calculation is refunded",
+			quota:          2,
+			handlerStatus:  http.StatusUnprocessableEntity,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedUsed:   0,
+		},
+		{
+			name:           "failed calculation is refunded",
+			quota:          2,
+			handlerStatus:  http.StatusServiceUnavailable,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedUsed:   0,
+		},
+		{
+			name:           "request over the quota is refunded",
+			quota:          2,
+			used:           2,
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedUsed:   2,
+		},
+		{
+			name:           "batch counts every expression",
+			quota:          5,
+			body:           `{"expressions":["1+1","2+2","3+3"]}`,
+			cost:           BatchCost,
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusCreated,
+			expectedUsed:   3,
+		},
+		{
+			name:           "batch over the quota is refunded",
+			quota:          5,
+			used:           3,
+			body:           `{"expressions":["1+1","2+2","3+3"]}`,
+			cost:           BatchCost,
+			handlerStatus:  http.StatusCreated,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedUsed:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := &fakeLimiter{counts: make(map[string]int64)}
+			key, _ := quotaWindow("user", time.Now())
+			limiter.counts[key] = tt.used
+			handler := QuotaMiddleware(limiter, tt.quota, tt.cost)(func(c echo.Context) error {
+				return c.NoContent(tt.handlerStatus)
+			})
+
+			request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("userID", "user")
+
+			assert.NoError(t, handler(c))
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedUsed, limiter.total())
+		})
+	}
+}
+
+func TestRateLimitMiddleware_CountsRejectedRequests(t *testing.T) {
+	limiter := &fakeLimiter{counts: make(map[string]int64)}
+	handler := RateLimitMiddleware(limiter, 1)(func(c echo.Context) error {
+		return c.NoContent(http.StatusUnprocessableEntity)
+	})
+
+	// the rate limit protects the API from any requests, so rejected ones are not refunded
+	for _, expectedStatus := range []int{http.StatusUnprocessableEntity, http.StatusTooManyRequests} {
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, "/", nil), recorder)
+		c.Set("userID", "user")
+
+		assert.NoError(t, handler(c))
+		assert.Equal(t, expectedStatus, recorder.Code)
+	}
+	assert.Equal(t, int64(2), limiter.total())
+}
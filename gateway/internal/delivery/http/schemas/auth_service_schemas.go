@@ -13,3 +13,8 @@ type RegisterRequest struct {
 type RegisterResponse struct {
 	UserId string `json:"user_id" example:"0196cb7d-7d60-78cc-ac28-f9e114de51fc"`
 }
+
+type LogoutAllResponse struct {
+	// RevokedTokens is the number of access and refresh tokens that were still valid
+	RevokedTokens int32 `json:"revoked_tokens" example:"4"`
+}
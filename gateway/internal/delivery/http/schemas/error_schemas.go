@@ -42,8 +42,18 @@ type InternalServerError struct {
 	Error string `json:"error" example:"internal server error"`
 }
 
+type TooManyRequests struct {
+	Error string `json:"error" example:"too many requests, try again later"`
+}
+
+type QuotaExceeded struct {
+	Error string `json:"error" example:"daily calculation quota exceeded"`
+}
+
 var (
 	InternalServerErrorMsg = InternalServerError{Error: "internal server error"}
+	TooManyRequestsMsg     = TooManyRequests{Error: "too many requests, try again later"}
+	QuotaExceededMsg       = QuotaExceeded{Error: "daily calculation quota exceeded"}
 )
 
 // endregion general
@@ -54,18 +64,85 @@ type ExpressionNotFound struct {
 	Error string `json:"error" example:"expression not found"`
 }
 
+type ExpressionFinished struct {
+	Error string `json:"error" example:"expression is already finished"`
+}
+
 type CannotParseId struct {
 	Error string `json:"error" example:"cannot parse id"`
 }
 
 type CannotParseExpression struct {
-	Error string `json:"error" example:"cannot parse expression"`
+	Error   string `json:"error" example:"cannot parse expression"`
+	Message string `json:"message,omitempty" example:"unexpected '(', expected operator"`
+	Column  int    `json:"column,omitempty" example:"2"`
+}
+
+type UnknownPrecision struct {
+	Error string `json:"error" example:"unknown precision, expected float or decimal"`
+}
+
+type InvalidPriority struct {
+	Error string `json:"error" example:"invalid priority, expected 0 to 9"`
+}
+
+type InvalidTimeout struct {
+	Error string `json:"error" example:"invalid timeout, expected a positive number of milliseconds"`
+}
+
+type InvalidBatch struct {
+	Error string `json:"error" example:"invalid batch, expected 1 to 1000 expressions"`
+}
+
+type BatchNotFound struct {
+	Error string `json:"error" example:"batch not found"`
+}
+
+type InvalidPageSize struct {
+	Error string `json:"error" example:"invalid limit, expected 1 to 100"`
+}
+
+type InvalidCursor struct {
+	Error string `json:"error" example:"invalid cursor"`
+}
+
+type InvalidDate struct {
+	Error string `json:"error" example:"invalid date, expected RFC 3339"`
+}
+
+type UnknownOrder struct {
+	Error string `json:"error" example:"unknown order, expected newest or oldest"`
+}
+
+type QueueFull struct {
+	Error string `json:"error" example:"calculation queue is full, try again later"`
+}
+
+type VariableNotFound struct {
+	Error string `json:"error" example:"variable not found"`
+}
+
+type InvalidVariableName struct {
+	Error string `json:"error" example:"invalid variable name"`
 }
 
 var (
 	ExpressionNotFoundMsg    = ExpressionNotFound{Error: "expression not found"}
+	ExpressionFinishedMsg    = ExpressionFinished{Error: "expression is already finished"}
 	CannotParseIdMsg         = CannotParseId{Error: "cannot parse id"}
 	CannotParseExpressionMsg = CannotParseExpression{Error: "cannot parse expression"}
+	UnknownPrecisionMsg      = UnknownPrecision{Error: "unknown precision, expected float or decimal"}
+	InvalidPriorityMsg       = InvalidPriority{Error: "invalid priority, expected 0 to 9"}
+	InvalidTimeoutMsg        = InvalidTimeout{Error: "invalid timeout, expected a positive number of milliseconds"}
+	InvalidBatchMsg          = InvalidBatch{Error: "invalid batch, expected 1 to 1000 expressions"}
+	BatchNotFoundMsg         = BatchNotFound{Error: "batch not found"}
+	InvalidPageSizeMsg       = InvalidPageSize{Error: "invalid limit, expected 1 to 100"}
+	InvalidCursorMsg         = InvalidCursor{Error: "invalid cursor"}
+	InvalidDateMsg           = InvalidDate{Error: "invalid date, expected RFC 3339"}
+	UnknownOrderMsg          = UnknownOrder{Error: "unknown order, expected newest or oldest"}
+	QueueFullMsg             = QueueFull{Error: "calculation queue is full, try again later"}
+	VariableNotFoundMsg      = VariableNotFound{Error: "variable not found"}
+	InvalidVariableNameMsg   = InvalidVariableName{Error: "invalid variable name"}
 )
 
 // endregion orchestrator
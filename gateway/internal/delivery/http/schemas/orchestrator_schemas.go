@@ -1,21 +1,148 @@
 package schemas
 
+import "time"
+
 type CalculateRequest struct {
 	Expression string `json:"expression" example:"40+2"`
+	// Precision "decimal" computes the expression exactly with decimal arithmetic
+	Precision string `json:"precision,omitempty" example:"float" enums:"float,decimal"`
+	// Priority from 0 to 9, tasks of expressions with a higher priority are computed first
+	Priority int32 `json:"priority,omitempty" example:"0" minimum:"0" maximum:"9"`
+	// TimeoutMs after which a not yet calculated expression gets the "timed out" status, 0 means the server default
+	TimeoutMs int64 `json:"timeout_ms,omitempty" example:"60000" minimum:"1"`
+	// NoCache computes every subexpression again instead of taking cached results
+	NoCache bool `json:"no_cache,omitempty" example:"false"`
 }
 
 type CalculateResponse struct {
 	Id int `json:"id" example:"1"`
 }
 
+type ExplainRequest struct {
+	Expression string `json:"expression" example:"2*3+sqrt(x)"`
+	// Precision "decimal" expressions are simplified less than "float" ones
+	Precision string `json:"precision,omitempty" example:"float" enums:"float,decimal"`
+}
+
+// ExplainOperand is either a constant or the id of the task computing the operand
+type ExplainOperand struct {
+	TaskId   *int32  `json:"task_id,omitempty" example:"2"`
+	Constant *string `json:"constant,omitempty" example:"4"`
+}
+
+type ExplainTask struct {
+	// Id is the position of the operation in rpn
+	Id              int32            `json:"id" example:"5"`
+	Operation       string           `json:"operation" example:"+"`
+	Operands        []ExplainOperand `json:"operands"`
+	OperationTimeMs int64            `json:"operation_time_ms" example:"100"`
+	// EarliestStartMs is when the task can start if every ready task gets an agent at once
+	EarliestStartMs int64 `json:"earliest_start_ms" example:"500"`
+}
+
+type ExplainResponse struct {
+	// Rpn is what the expression is planned as, after simplification if it is enabled
+	Rpn []string `json:"rpn" example:"2,3,*,4,sqrt:1,+"`
+	// Tasks are in the order of rpn, the last one computes the result
+	Tasks      []ExplainTask `json:"tasks"`
+	TasksSaved int32         `json:"tasks_saved" example:"0"`
+	// Agents registered and Slots, the number of tasks they compute at once
+	Agents int32 `json:"agents" example:"2"`
+	Slots  int32 `json:"slots" example:"2"`
+	// EstimatedTimeMs to compute the tasks with all slots, empty when no agent is registered.
+	// Cached results and tasks of other expressions are not taken into account
+	EstimatedTimeMs *int64 `json:"estimated_time_ms,omitempty" example:"600"`
+	// CriticalPath is the longest chain of dependent tasks, no number of agents computes the expression faster
+	CriticalPath       []int32 `json:"critical_path" example:"4,5"`
+	CriticalPathTimeMs int64   `json:"critical_path_time_ms" example:"600"`
+}
+
+type CalculateBatchRequest struct {
+	Expressions []string `json:"expressions" example:"40+2,6*(7"`
+	// Precision, Priority, TimeoutMs and NoCache apply to every expression of the batch
+	Precision string `json:"precision,omitempty" example:"float" enums:"float,decimal"`
+	Priority  int32  `json:"priority,omitempty" example:"0" minimum:"0" maximum:"9"`
+	TimeoutMs int64  `json:"timeout_ms,omitempty" example:"60000" minimum:"1"`
+	NoCache   bool   `json:"no_cache,omitempty" example:"false"`
+}
+
+// BatchItem is either the id of an accepted expression or why it cannot be parsed.
+// An accepted expression that failed to start has both the id and the error
+type BatchItem struct {
+	Id      string `json:"id,omitempty" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Error   string `json:"error,omitempty" example:"cannot parse expression"`
+	Message string `json:"message,omitempty" example:"expected ')'"`
+	Column  int    `json:"column,omitempty" example:"5"`
+}
+
+type CalculateBatchResponse struct {
+	// BatchId is empty when no expression of the batch was accepted
+	BatchId string `json:"batch_id,omitempty" example:"5b1e4a3c-7f6d-4b8e-9a2c-1d3f5e7a9b0c"`
+	// Items are in the order of the request expressions
+	Items []BatchItem `json:"items"`
+}
+
+type BatchProgressResponse struct {
+	BatchId string `json:"batch_id" example:"5b1e4a3c-7f6d-4b8e-9a2c-1d3f5e7a9b0c"`
+	Total   int    `json:"total" example:"10"`
+	Pending int    `json:"pending" example:"4"`
+	Done    int    `json:"done" example:"5"`
+	// Failed counts expressions that failed, were cancelled or timed out
+	Failed   int            `json:"failed" example:"1"`
+	Statuses map[string]int `json:"statuses"`
+}
+
+type CacheStatsResponse struct {
+	// Enabled is false when the orchestrator runs without a result cache
+	Enabled bool `json:"enabled" example:"true"`
+	// Hits counts tasks whose results were taken from the cache
+	Hits int64 `json:"hits" example:"30"`
+	// Misses counts tasks computed by agents, expressions calculated with no_cache are not counted
+	Misses  int64   `json:"misses" example:"10"`
+	HitRate float64 `json:"hit_rate" example:"0.75"`
+	// Deduplicated counts tasks of identical subexpressions of one expression that were computed once
+	Deduplicated int64 `json:"deduplicated" example:"2"`
+	// Expressions counts expressions whose whole result was taken from the cache
+	Expressions int64 `json:"expressions" example:"5"`
+}
+
 type Expression struct {
-	Id     int      `json:"id" example:"1"`
-	Status string   `json:"status" example:"done"`
-	Result *float64 `json:"result,omitempty" example:"42.0"`
+	Id string `json:"id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	// Expression is the text as it was submitted
+	Expression string `json:"expression" example:"x*(2+ 5)"`
+	// Normalized is the canonical form with variable values substituted, this is what was calculated
+	Normalized string   `json:"normalized" example:"6 * (2 + 5)"`
+	Status     string   `json:"status" example:"done" enums:"pending,done,cancelled,timed out,invalid expression,division by zero"`
+	Result     *float64 `json:"result,omitempty" example:"42.0"`
+	// DecimalResult is the exact result of an expression calculated with "decimal" precision
+	DecimalResult *string `json:"decimal_result,omitempty" example:"42.0"`
+	// Error details why the expression failed, e.g. the operation that divided by zero
+	Error *string `json:"error,omitempty" example:"division by zero in 7 / 0"`
+	// TasksSaved counts tasks that were not sent to agents because the expression was simplified
+	TasksSaved int32      `json:"tasks_saved" example:"2"`
+	CreatedAt  *time.Time `json:"created_at,omitempty" example:"2025-05-20T12:00:00Z"`
+	// StartedAt is when an agent took the first task, empty while the tasks are queued
+	StartedAt *time.Time `json:"started_at,omitempty" example:"2025-05-20T12:00:01Z"`
+	// FinishedAt is empty while the expression is pending
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2025-05-20T12:00:03Z"`
+}
+
+// ExpressionEvent is sent in the data of the "status" Server-Sent Event
+type ExpressionEvent struct {
+	Id            string   `json:"id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Status        string   `json:"status" example:"in progress" enums:"pending,in progress,done,cancelled,timed out,invalid expression,division by zero"`
+	Result        *float64 `json:"result,omitempty" example:"42.0"`
+	DecimalResult *string  `json:"decimal_result,omitempty" example:"42.0"`
+	TasksDone     int      `json:"tasks_done" example:"1"`
+	TasksTotal    int      `json:"tasks_total" example:"3"`
+	// ElapsedMs from the creation of a timed out expression to its timeout
+	ElapsedMs int64 `json:"elapsed_ms,omitempty" example:"60000"`
 }
 
 type ExpressionsResponse struct {
 	Expressions []Expression `json:"expressions"`
+	// NextCursor is the cursor query param of the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty" example:"MTc0NzczMjQwMDAwMDAwMC4zZmE4NWY2NA"`
 }
 
 type ExpressionByIdRequest struct {
@@ -23,5 +150,51 @@ type ExpressionByIdRequest struct {
 }
 
 type ExpressionByIdResponse struct {
-	Expression
+	Expression Expression `json:"expression"`
+}
+
+type TaskTrace struct {
+	Id        int64     `json:"id" example:"1"`
+	Operation string    `json:"operation" example:"+"`
+	Args      []float64 `json:"args" example:"0.1,0.2"`
+	// DecimalArgs are the exact operands of an expression calculated with "decimal" precision
+	DecimalArgs   []string `json:"decimal_args,omitempty" example:"0.1,0.2"`
+	Status        string   `json:"status" example:"done" enums:"pending,done,failed"`
+	Result        *float64 `json:"result,omitempty" example:"0.3"`
+	DecimalResult *string  `json:"decimal_result,omitempty" example:"0.3"`
+	// Error is why the agent failed to compute the task
+	Error *string `json:"error,omitempty" example:"division by zero"`
+	// AgentId is the agent the task was handed out to last, empty for tasks taken without the work stream
+	AgentId string `json:"agent_id,omitempty" example:"agent-1"`
+	// Attempts counts how many times the task was handed out, more than one if a lease expired
+	Attempts   int32      `json:"attempts" example:"1"`
+	QueuedAt   *time.Time `json:"queued_at,omitempty" example:"2025-05-20T12:00:00Z"`
+	StartedAt  *time.Time `json:"started_at,omitempty" example:"2025-05-20T12:00:01Z"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" example:"2025-05-20T12:00:02Z"`
+}
+
+type ExpressionTasksResponse struct {
+	// Tasks are in the order they were created
+	Tasks []TaskTrace `json:"tasks"`
+}
+
+type SetVariableRequest struct {
+	Value float64 `json:"value" example:"0.2"`
+}
+
+type Variable struct {
+	Name  string  `json:"name" example:"rate"`
+	Value float64 `json:"value" example:"0.2"`
+}
+
+type SetVariableResponse struct {
+	Variable Variable `json:"variable"`
+}
+
+type VariablesResponse struct {
+	Variables []Variable `json:"variables"`
+}
+
+type VariableByNameResponse struct {
+	Variable Variable `json:"variable"`
 }
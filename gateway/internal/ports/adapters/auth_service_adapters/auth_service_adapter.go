@@ -61,3 +61,32 @@ func (a AuthServiceAdapter) Refresh(request *auth.RefreshRequest) (*auth.Refresh
 	}
 	return response, nil
 }
+
+func (a AuthServiceAdapter) Logout(request *auth.LogoutRequest) error {
+	conn, err := a.grpcPool.GetConn()
+	if err != nil {
+		return fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer a.grpcPool.Restore(conn) //nolint
+	client := auth.NewAuthServiceClient(conn)
+	if _, grpcErr := client.Logout(context.Background(), request); grpcErr != nil {
+		return fmt.Errorf("error in Logout grpc: %w", grpcErr)
+	}
+	return nil
+}
+
+func (a AuthServiceAdapter) LogoutAll(request *auth.LogoutAllRequest) (*auth.LogoutAllResponse, error) {
+	conn, err := a.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer a.grpcPool.Restore(conn) //nolint
+	client := auth.NewAuthServiceClient(conn)
+	response, grpcErr := client.LogoutAll(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in LogoutAll grpc: %w", grpcErr)
+	}
+	return response, nil
+}
@@ -2,9 +2,12 @@ package orchestrator_adapters
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
 	"github.com/jaam8/web_calculator/common-lib/grpc/pool"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
 )
 
 type OrchestratorAdapter struct {
@@ -32,6 +35,70 @@ func (o OrchestratorAdapter) Calculate(request *orchestrator.CalculateRequest) (
 	return response, nil
 }
 
+func (o OrchestratorAdapter) CalculateBatch(
+	request *orchestrator.CalculateBatchRequest,
+) (*orchestrator.CalculateBatchResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.CalculateBatch(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in CalculateBatch grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) BatchProgress(
+	request *orchestrator.BatchProgressRequest,
+) (*orchestrator.BatchProgressResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.BatchProgress(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in BatchProgress grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) Explain(request *orchestrator.ExplainRequest) (*orchestrator.ExplainResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.Explain(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in Explain grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) CacheStats() (*orchestrator.CacheStatsResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.CacheStats(context.Background(), &emptypb.Empty{})
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in CacheStats grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
 func (o OrchestratorAdapter) Expressions(request *orchestrator.ExpressionsRequest) (*orchestrator.ExpressionsResponse, error) {
 	conn, err := o.grpcPool.GetConn()
 	if err != nil {
@@ -61,3 +128,121 @@ func (o OrchestratorAdapter) ExpressionByID(request *orchestrator.ExpressionById
 	}
 	return response, nil
 }
+
+func (o OrchestratorAdapter) ExpressionTasks(
+	request *orchestrator.ExpressionTasksRequest,
+) (*orchestrator.ExpressionTasksResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.ExpressionTasks(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in ExpressionTasks grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) WatchExpression(ctx context.Context, request *orchestrator.WatchExpressionRequest,
+	onEvent func(event *orchestrator.ExpressionEvent) error,
+) error {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	stream, grpcErr := client.WatchExpression(ctx, request)
+	if grpcErr != nil {
+		return fmt.Errorf("error in WatchExpression grpc: %w", grpcErr)
+	}
+	for {
+		event, grpcErr := stream.Recv()
+		if errors.Is(grpcErr, io.EOF) {
+			return nil
+		}
+		if grpcErr != nil {
+			return fmt.Errorf("error in WatchExpression grpc: %w", grpcErr)
+		}
+		if err = onEvent(event); err != nil {
+			return err
+		}
+	}
+}
+
+func (o OrchestratorAdapter) CancelExpression(request *orchestrator.CancelExpressionRequest) error {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	if _, grpcErr := client.CancelExpression(context.Background(), request); grpcErr != nil {
+		return fmt.Errorf("error in CancelExpression grpc: %w", grpcErr)
+	}
+	return nil
+}
+
+func (o OrchestratorAdapter) SetVariable(request *orchestrator.SetVariableRequest) (*orchestrator.SetVariableResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.SetVariable(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in SetVariable grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) Variables(request *orchestrator.VariablesRequest) (*orchestrator.VariablesResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.Variables(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in Variables grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) VariableByName(request *orchestrator.VariableByNameRequest) (*orchestrator.VariableByNameResponse, error) {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	response, grpcErr := client.VariableByName(context.Background(), request)
+	if grpcErr != nil {
+		return nil, fmt.Errorf("error in VariableByName grpc: %w", grpcErr)
+	}
+	return response, nil
+}
+
+func (o OrchestratorAdapter) DeleteVariable(request *orchestrator.DeleteVariableRequest) error {
+	conn, err := o.grpcPool.GetConn()
+	if err != nil {
+		return fmt.Errorf("couldn't get conn from pool: %w", err)
+	}
+	defer conn.Close()             //nolint
+	defer o.grpcPool.Restore(conn) //nolint
+	client := orchestrator.NewOrchestratorServiceClient(conn)
+	if _, grpcErr := client.DeleteVariable(context.Background(), request); grpcErr != nil {
+		return fmt.Errorf("error in DeleteVariable grpc: %w", grpcErr)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rate_limit_adapters
+
+import (
+	"fmt"
+	"github.com/go-redis/redis/v7"
+	"time"
+)
+
+// hitScript increments the counter of the window by the cost and starts the window on the first hit
+var hitScript = redis.NewScript(`
+local count = redis.call("INCRBY", KEYS[1], ARGV[2])
+if count == tonumber(ARGV[2]) then
+    redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// refundScript decrements the counter of the window, a reset window is not started again
+var refundScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+    return redis.call("DECRBY", KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+type RateLimitAdapter struct {
+	client *redis.Client
+}
+
+func NewRateLimitAdapter(client *redis.Client) *RateLimitAdapter {
+	return &RateLimitAdapter{
+		client: client,
+	}
+}
+
+func (a RateLimitAdapter) Hit(key string, window time.Duration, cost int64) (int64, time.Duration, error) {
+	result, err := hitScript.Run(a.client, []string{key}, window.Milliseconds(), cost).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count request: %w", err)
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, 0, fmt.Errorf("unexpected result of rate limit script: %v", result)
+	}
+	count, _ := values[0].(int64)
+	ttl, _ := values[1].(int64)
+	if ttl < 0 {
+		ttl = window.Milliseconds()
+	}
+	return count, time.Duration(ttl) * time.Millisecond, nil
+}
+
+func (a RateLimitAdapter) Refund(key string, cost int64) error {
+	if err := refundScript.Run(a.client, []string{key}, cost).Err(); err != nil {
+		return fmt.Errorf("failed to refund request: %w", err)
+	}
+	return nil
+}
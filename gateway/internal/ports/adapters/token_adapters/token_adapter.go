@@ -0,0 +1,29 @@
+package token_adapters
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v7"
+)
+
+// TokenAdapter reads the tokens the auth service keeps in its redis database
+type TokenAdapter struct {
+	client *redis.Client
+}
+
+func NewTokenAdapter(client *redis.Client) *TokenAdapter {
+	return &TokenAdapter{
+		client: client,
+	}
+}
+
+func (a TokenAdapter) AccessTokenOwner(token string) (string, error) {
+	userID, err := a.client.Get(fmt.Sprintf("access:%s", token)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return userID, nil
+}
@@ -1,18 +1,49 @@
 package ports
 
 import (
+	"context"
 	"github.com/jaam8/web_calculator/common-lib/gen/auth_service"
 	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
+	"time"
 )
 
 type OrchestratorAdapter interface {
 	Calculate(request *orchestrator.CalculateRequest) (*orchestrator.CalculateResponse, error)
 	Expressions(request *orchestrator.ExpressionsRequest) (*orchestrator.ExpressionsResponse, error)
 	ExpressionByID(request *orchestrator.ExpressionByIdRequest) (*orchestrator.ExpressionByIdResponse, error)
+	ExpressionTasks(request *orchestrator.ExpressionTasksRequest) (*orchestrator.ExpressionTasksResponse, error)
+	// WatchExpression calls onEvent for every event of the stream until it ends or ctx is done
+	WatchExpression(ctx context.Context, request *orchestrator.WatchExpressionRequest,
+		onEvent func(event *orchestrator.ExpressionEvent) error) error
+	CancelExpression(request *orchestrator.CancelExpressionRequest) error
+	CalculateBatch(request *orchestrator.CalculateBatchRequest) (*orchestrator.CalculateBatchResponse, error)
+	BatchProgress(request *orchestrator.BatchProgressRequest) (*orchestrator.BatchProgressResponse, error)
+	CacheStats() (*orchestrator.CacheStatsResponse, error)
+	Explain(request *orchestrator.ExplainRequest) (*orchestrator.ExplainResponse, error)
+	SetVariable(request *orchestrator.SetVariableRequest) (*orchestrator.SetVariableResponse, error)
+	Variables(request *orchestrator.VariablesRequest) (*orchestrator.VariablesResponse, error)
+	VariableByName(request *orchestrator.VariableByNameRequest) (*orchestrator.VariableByNameResponse, error)
+	DeleteVariable(request *orchestrator.DeleteVariableRequest) error
 }
 
 type AuthServiceAdapter interface {
 	Login(request *auth_service.LoginRequest) (*auth_service.LoginResponse, error)
 	Register(request *auth_service.RegisterRequest) (*auth_service.RegisterResponse, error)
 	Refresh(request *auth_service.RefreshRequest) (*auth_service.RefreshResponse, error)
+	Logout(request *auth_service.LogoutRequest) error
+	LogoutAll(request *auth_service.LogoutAllRequest) (*auth_service.LogoutAllResponse, error)
+}
+
+type TokenAdapter interface {
+	// AccessTokenOwner returns the user the access token was issued to,
+	// an empty string if the token is expired or revoked
+	AccessTokenOwner(token string) (string, error)
+}
+
+type RateLimitAdapter interface {
+	// Hit counts cost requests in the window of key and returns the number of requests
+	// in the window together with the time left until it is reset
+	Hit(key string, window time.Duration, cost int64) (int64, time.Duration, error)
+	// Refund takes back cost requests counted by Hit, if the window of key is not reset yet
+	Refund(key string, cost int64) error
 }
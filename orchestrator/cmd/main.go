@@ -4,13 +4,18 @@ import (
 	"context"
 	"github.com/jaam8/web_calculator/common-lib/logger"
 	"github.com/jaam8/web_calculator/common-lib/postgres"
+	"github.com/jaam8/web_calculator/common-lib/redis"
 	"github.com/jaam8/web_calculator/orchestrator/internal/config"
+	"github.com/jaam8/web_calculator/orchestrator/internal/ports"
+	"github.com/jaam8/web_calculator/orchestrator/internal/ports/adapters/cache"
 	"github.com/jaam8/web_calculator/orchestrator/internal/ports/adapters/storage"
 	"github.com/jaam8/web_calculator/orchestrator/internal/server"
+	"github.com/jaam8/web_calculator/orchestrator/internal/service/helper"
 	"github.com/jaam8/web_calculator/orchestrator/internal/service/utils"
 	"log"
 	"os"
 	"os/signal"
+	"time"
 )
 
 func main() {
@@ -29,12 +34,36 @@ func main() {
 	postgresCfg := cfg.Postgres
 
 	durations := map[string]int{
-		"+": orchestratorCfg.TimeAddition,
-		"-": orchestratorCfg.TimeSubtraction,
-		"*": orchestratorCfg.TimeMultiplications,
-		"/": orchestratorCfg.TimeDivisions,
+		"+":  orchestratorCfg.TimeAddition,
+		"-":  orchestratorCfg.TimeSubtraction,
+		"*":  orchestratorCfg.TimeMultiplications,
+		"/":  orchestratorCfg.TimeDivisions,
+		"^":  orchestratorCfg.TimeExponentiations,
+		"%":  orchestratorCfg.TimeModulo,
+		"//": orchestratorCfg.TimeIntDivisions,
 	}
-	expressionManager := utils.NewExpressionManager(durations)
+	for _, function := range helper.Functions() {
+		durations[function] = orchestratorCfg.TimeFunctions
+	}
+	expressionManager := utils.NewExpressionManager(
+		durations,
+		time.Duration(orchestratorCfg.LeaseTimeout)*time.Millisecond,
+		utils.QueueLimits{
+			MaxTasks:     orchestratorCfg.MaxQueuedTasks,
+			MaxUserTasks: orchestratorCfg.MaxQueuedTasksPerUser,
+		},
+	)
+	go expressionManager.WatchLeases(ctx,
+		time.Duration(orchestratorCfg.LeaseCheckInterval)*time.Millisecond,
+	)
+
+	agentRegistry := utils.NewAgentRegistry(
+		time.Duration(orchestratorCfg.HeartbeatInterval)*time.Millisecond,
+		time.Duration(orchestratorCfg.AgentTTL)*time.Millisecond,
+	)
+	go agentRegistry.WatchAgents(ctx,
+		time.Duration(orchestratorCfg.HeartbeatInterval)*time.Millisecond,
+	)
 
 	PostgresClient, err := postgres.New(ctx, postgresCfg)
 	defer PostgresClient.Close()
@@ -49,7 +78,29 @@ func main() {
 
 	postgresAdapter := storage.NewPostgresAdapter(PostgresClient)
 
-	Server := server.NewOrchestratorService(postgresAdapter, expressionManager)
+	var cacheAdapter ports.CacheAdapter
+	cacheTTL := time.Duration(orchestratorCfg.CacheTTL) * time.Millisecond
+	switch orchestratorCfg.CacheBackend {
+	case "memory":
+		cacheAdapter = cache.NewMemoryCacheAdapter(orchestratorCfg.CacheSize, cacheTTL)
+	case "redis":
+		redisClient, err := redis.NewRedisClient(ctx, cfg.Redis, orchestratorCfg.CacheRedisDB)
+		if err != nil {
+			log.Fatalf("failed to connect to redis for result cache: %v", err)
+		}
+		defer redisClient.Close() //nolint
+		cacheAdapter = cache.NewRedisCacheAdapter(redisClient, cacheTTL)
+	case "none":
+	default:
+		log.Fatalf("unknown cache backend: %s", orchestratorCfg.CacheBackend)
+	}
+
+	Server := server.NewOrchestratorService(postgresAdapter, expressionManager, agentRegistry,
+		time.Duration(orchestratorCfg.ExpressionTimeout)*time.Millisecond, cacheAdapter, orchestratorCfg.Simplify)
+	if err = Server.Recover(ctx); err != nil {
+		log.Fatalf("failed to recover pending expressions: %v", err)
+	}
+
 	grpcServer, err := server.CreateGRPC(Server)
 	if err != nil {
 		log.Fatalf("failed to create gRPC server: %v", err)
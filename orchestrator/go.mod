@@ -5,6 +5,7 @@ go 1.24.2
 replace github.com/jaam8/web_calculator/common-lib => ../common-lib
 
 require (
+	github.com/go-redis/redis/v7 v7.4.1
 	github.com/google/uuid v1.6.0
 	github.com/ilyakaznacheev/cleanenv v1.5.0
 	github.com/jaam8/web_calculator/common-lib v0.0.0-20250507172040-6912db979615
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/jaam8/web_calculator/common-lib/postgres"
+	"github.com/jaam8/web_calculator/common-lib/redis"
 	"time"
 )
 
@@ -15,11 +16,40 @@ type OrchestratorConfig struct {
 	TimeSubtraction     int `env:"TIME_SUBTRACTION_MS"`
 	TimeMultiplications int `env:"TIME_MULTIPLICATIONS_MS"`
 	TimeDivisions       int `env:"TIME_DIVISIONS_MS"`
+	TimeExponentiations int `env:"TIME_EXPONENTIATIONS_MS"`
+	TimeModulo          int `env:"TIME_MODULO_MS"`
+	TimeIntDivisions    int `env:"TIME_INTEGER_DIVISIONS_MS"`
+	TimeFunctions       int `env:"TIME_FUNCTIONS_MS"`
+
+	LeaseTimeout       int `yaml:"lease_timeout" env:"LEASE_TIMEOUT_MS" env-default:"5000"`
+	LeaseCheckInterval int `yaml:"lease_check_interval" env:"LEASE_CHECK_INTERVAL_MS" env-default:"500"`
+
+	// новые выражения отклоняются, пока в очереди столько задач, 0 - без ограничения
+	MaxQueuedTasks        int `yaml:"max_queued_tasks" env:"MAX_QUEUED_TASKS" env-default:"10000"`
+	MaxQueuedTasksPerUser int `yaml:"max_queued_tasks_per_user" env:"MAX_QUEUED_TASKS_PER_USER" env-default:"1000"`
+
+	// срок вычисления выражения, если он не указан в запросе, 0 - без ограничения
+	ExpressionTimeout int `yaml:"expression_timeout" env:"EXPRESSION_TIMEOUT_MS" env-default:"0"`
+
+	// сворачивать операторы над константами и нейтральные операции до отправки задач агентам
+	Simplify bool `yaml:"simplify" env:"SIMPLIFY" env-default:"true"`
+
+	HeartbeatInterval int `yaml:"heartbeat_interval" env:"HEARTBEAT_INTERVAL_MS" env-default:"2000"`
+	AgentTTL          int `yaml:"agent_ttl" env:"AGENT_TTL_MS" env-default:"6000"`
+
+	// кэш результатов подвыражений: memory - в памяти оркестратора, redis - общий в Redis, none - без кэша
+	CacheBackend string `yaml:"cache_backend" env:"CACHE_BACKEND" env-default:"memory"`
+	// сколько результатов хранит кэш в памяти
+	CacheSize int `yaml:"cache_size" env:"CACHE_SIZE" env-default:"10000"`
+	// сколько хранится результат, 0 - бессрочно
+	CacheTTL     int `yaml:"cache_ttl" env:"CACHE_TTL_MS" env-default:"3600000"`
+	CacheRedisDB int `yaml:"cache_redis_db" env:"CACHE_REDIS_DB" env-default:"2"`
 }
 
 type Config struct {
 	Orchestrator  OrchestratorConfig `yaml:"orchestrator" env-prefix:"ORCHESTRATOR_"`
 	Postgres      postgres.Config    `yaml:"postgres" env-prefix:"POSTGRES_"`
+	Redis         redis.Config       `yaml:"redis" env-prefix:"REDIS_"`
 	LogLevel      string             `yaml:"log_level" env:"LOG_LEVEL" env-default:"info"`
 	MigrationPath string             `yaml:"migration_path" env:"MIGRATION_PATH" env-default:"file:///db/migrations"`
 }
@@ -48,6 +78,14 @@ func (c OrchestratorConfig) GetOperationsTime(oper string) time.Duration {
 		return time.Duration(c.TimeMultiplications) * time.Millisecond
 	case "/":
 		return time.Duration(c.TimeDivisions) * time.Millisecond
+	case "^":
+		return time.Duration(c.TimeExponentiations) * time.Millisecond
+	case "%":
+		return time.Duration(c.TimeModulo) * time.Millisecond
+	case "//":
+		return time.Duration(c.TimeIntDivisions) * time.Millisecond
+	case "sqrt", "abs", "sin", "cos", "log", "min", "max":
+		return time.Duration(c.TimeFunctions) * time.Millisecond
 	default:
 		return 0
 	}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Agent агент вычислений, зарегистрированный в оркестраторе
+type Agent struct {
+	ID       string
+	Capacity int
+	// Operations операции и функции, которые умеет считать агент
+	Operations []string
+	// Busy задачи, отправленные агенту и ещё не посчитанные
+	Busy         int
+	RegisteredAt time.Time
+	LastSeen     time.Time
+}
@@ -0,0 +1,26 @@
+package models
+
+// CachedResult результат подвыражения в кэше. Decimal заполнен у результатов,
+// посчитанных в режиме PrecisionDecimal, Value - у остальных
+type CachedResult struct {
+	Value   float64
+	Decimal string
+}
+
+// CacheStats сколько задач взято из кэша (Hits) и сколько посчитали агенты (Misses) у выражений,
+// вычисляемых с кэшем. Deduplicated - задачи одинаковых подвыражений выражения, посчитанные один раз,
+// Expressions - выражения, результат которых целиком взят из кэша
+type CacheStats struct {
+	Hits         int64
+	Misses       int64
+	Deduplicated int64
+	Expressions  int64
+}
+
+// HitRate доля задач, взятых из кэша, 0 - кэш ещё не использовался
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Статусы выражения, которое ещё вычисляется
+const (
+	StatusPending    = "pending"
+	StatusInProgress = "in progress"
+)
+
+// StatusDone статус успешно вычисленного выражения
+const StatusDone = "done"
+
+// ExpressionEvent состояние вычисления выражения для подписчиков WatchExpression
+type ExpressionEvent struct {
+	ExpressionID uuid.UUID `json:"id"`
+	Status       string    `json:"status"`
+	TasksDone    int       `json:"tasks_done"`
+	TasksTotal   int       `json:"tasks_total"`
+	// Elapsed время с создания выражения до timed out, у остальных событий 0
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+}
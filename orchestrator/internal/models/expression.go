@@ -1,10 +1,84 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Режимы точности вычисления выражения
+const (
+	PrecisionFloat   = "float"
+	PrecisionDecimal = "decimal"
+)
+
+// Приоритет выражения: задачи выражений с большим приоритетом выдаются агентам раньше
+const (
+	MinPriority = 0
+	MaxPriority = 9
+)
+
+// MaxBatchSize сколько выражений можно отправить одним CalculateBatch
+const MaxBatchSize = 1000
+
+// Размер страницы списка выражений
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
 
 type Expression struct {
 	UserId       uuid.UUID `db:"user_id"`
 	ExpressionID uuid.UUID `json:"id" db:"id"`
-	Status       string    `json:"status" db:"status"`
-	Result       *float64  `json:"result,omitempty" db:"result"`
+	// Source текст выражения, как его прислал пользователь
+	Source string `json:"expression" db:"expression"`
+	// Normalized выражение в каноническом виде с подставленными значениями переменных - то, что вычислялось
+	Normalized string   `json:"normalized" db:"normalized"`
+	Status     string   `json:"status" db:"status"`
+	Result     *float64 `json:"result,omitempty" db:"result"`
+	// Error подробности ошибки выражения с конечным статусом, отличным от done, например операция,
+	// на которой произошло деление на ноль
+	Error *string  `json:"error,omitempty" db:"error"`
+	RPN   []string `json:"-" db:"rpn"`
+	// TasksSaved сколько задач не пришлось отправлять агентам благодаря упрощению выражения
+	TasksSaved int    `json:"tasks_saved" db:"tasks_saved"`
+	Precision  string `json:"-" db:"precision_mode"`
+	Priority   int    `json:"-" db:"priority"`
+	// NoCache подвыражения вычисляются заново, а не берутся из кэша. Сохраняется, чтобы
+	// восстановленное после перезапуска выражение тоже досчитывалось без кэша
+	NoCache bool `json:"-" db:"no_cache"`
+	// BatchID пакет CalculateBatch, в котором пришло выражение, nil - выражение пришло одно
+	BatchID *uuid.UUID `json:"-" db:"batch_id"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	// StartedAt момент, когда агент взял первую задачу выражения, nil - задачи ещё ждут в очереди
+	StartedAt *time.Time `json:"started_at,omitempty" db:"started_at"`
+	// FinishedAt момент, когда выражение получило конечный статус, nil - ещё вычисляется
+	FinishedAt *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+	// Deadline момент, после которого невычисленное выражение получает статус timed out, nil - без срока
+	Deadline *time.Time `json:"-" db:"deadline"`
+	// Timeout срок вычисления, из которого получен Deadline
+	Timeout time.Duration `json:"-" db:"timeout_ms"`
+	// DecimalResult точный результат выражения, вычисленного в режиме PrecisionDecimal
+	DecimalResult *string `json:"decimal_result,omitempty"`
+}
+
+// ExpressionsQuery выборка выражений пользователя: не больше Limit выражений после курсора After
+// в порядке времени создания
+type ExpressionsQuery struct {
+	Limit int
+	// After последнее выражение предыдущей страницы, nil - первая страница
+	After *ExpressionsCursor
+	// Statuses нужные статусы, пусто - любые
+	Statuses []string
+	// CreatedFrom и CreatedTo полуинтервал [CreatedFrom, CreatedTo) времени создания, nil - без границы
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// OldestFirst сортирует от старых выражений к новым, по умолчанию - от новых к старым
+	OldestFirst bool
+}
+
+// ExpressionsCursor позиция выражения в выборке, выражения с одним временем создания упорядочены по ID
+type ExpressionsCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
 }
@@ -6,4 +6,8 @@ type Result struct {
 	ExpressionID uuid.UUID
 	TaskID       int     `json:"id"`
 	Result       float64 `json:"result"`
+	// Decimal точный результат задачи в режиме PrecisionDecimal
+	Decimal string `json:"decimal,omitempty"`
+	// Err ошибка вычисления задачи на стороне агента
+	Err error `json:"-"`
 }
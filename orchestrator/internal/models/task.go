@@ -8,8 +8,25 @@ import (
 type Task struct {
 	ExpressionID  uuid.UUID
 	TaskID        int           `json:"id"`
-	Arg1          float64       `json:"arg1"`
-	Arg2          float64       `json:"arg2"`
+	NodeID        int           `json:"-" db:"node_id"`
+	Args          []float64     `json:"args"`
 	Operation     string        `json:"operation"`
 	OperationTime time.Duration `json:"operation_time"`
+	Status        string        `json:"-" db:"status"`
+	Result        *float64      `json:"-" db:"result"`
+	// DecimalArgs точные значения Args в режиме PrecisionDecimal
+	DecimalArgs   []string `json:"decimal_args,omitempty"`
+	DecimalResult *string  `json:"-"`
+
+	// AgentID агент, которому задача выдана последней, nil - задачу взяли через GetTask
+	AgentID *string `json:"-" db:"agent_id"`
+	// Attempts сколько раз задача выдавалась агентам, больше одного - аренда истекала
+	Attempts int `json:"-" db:"attempts"`
+	// Error ошибка, с которой агент не смог вычислить задачу
+	Error *string `json:"-" db:"error"`
+	// QueuedAt, StartedAt и FinishedAt - когда задача создана, последний раз выдана агенту
+	// и когда пришёл её результат
+	QueuedAt   *time.Time `json:"-" db:"queued_at"`
+	StartedAt  *time.Time `json:"-" db:"started_at"`
+	FinishedAt *time.Time `json:"-" db:"finished_at"`
 }
@@ -0,0 +1,9 @@
+package models
+
+import "github.com/google/uuid"
+
+type Variable struct {
+	UserId uuid.UUID `db:"user_id"`
+	Name   string    `json:"name" db:"name"`
+	Value  float64   `json:"value" db:"value"`
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"container/list"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"sync"
+	"time"
+)
+
+// MemoryCacheAdapter кэш результатов в памяти оркестратора: хранит не больше size
+// результатов, при переполнении вытесняется давно не запрошенный
+type MemoryCacheAdapter struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	result  models.CachedResult
+	expires time.Time
+}
+
+// NewMemoryCacheAdapter создаёт кэш на size результатов, ttl - сколько хранится результат, 0 - бессрочно
+func NewMemoryCacheAdapter(size int, ttl time.Duration) *MemoryCacheAdapter {
+	return &MemoryCacheAdapter{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+		now:     time.Now,
+	}
+}
+
+func (a *MemoryCacheAdapter) GetResults(keys []string) ([]*models.CachedResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	results := make([]*models.CachedResult, len(keys))
+	now := a.now()
+	for i, key := range keys {
+		element, ok := a.entries[key]
+		if !ok {
+			continue
+		}
+		entry := element.Value.(*memoryEntry)
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			a.order.Remove(element)
+			delete(a.entries, key)
+			continue
+		}
+		a.order.MoveToFront(element)
+		result := entry.result
+		results[i] = &result
+	}
+	return results, nil
+}
+
+func (a *MemoryCacheAdapter) SaveResult(key string, result models.CachedResult) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var expires time.Time
+	if a.ttl > 0 {
+		expires = a.now().Add(a.ttl)
+	}
+	if element, ok := a.entries[key]; ok {
+		element.Value = &memoryEntry{key: key, result: result, expires: expires}
+		a.order.MoveToFront(element)
+		return nil
+	}
+	a.entries[key] = a.order.PushFront(&memoryEntry{key: key, result: result, expires: expires})
+	for a.order.Len() > a.size {
+		oldest := a.order.Back()
+		a.order.Remove(oldest)
+		delete(a.entries, oldest.Value.(*memoryEntry).key)
+	}
+	return nil
+}
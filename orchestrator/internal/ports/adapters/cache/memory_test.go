@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheAdapter_Evict(t *testing.T) {
+	cache := NewMemoryCacheAdapter(2, 0)
+	require.NoError(t, cache.SaveResult("a", models.CachedResult{Value: 1}))
+	require.NoError(t, cache.SaveResult("b", models.CachedResult{Value: 2}))
+
+	// запрос делает a недавним, поэтому при переполнении вытесняется b
+	results, err := cache.GetResults([]string{"a"})
+	require.NoError(t, err)
+	require.Equal(t, 1.0, results[0].Value)
+	require.NoError(t, cache.SaveResult("c", models.CachedResult{Decimal: "3"}))
+
+	results, err = cache.GetResults([]string{"a", "b", "c"})
+	require.NoError(t, err)
+	require.Equal(t, &models.CachedResult{Value: 1}, results[0])
+	require.Nil(t, results[1])
+	require.Equal(t, &models.CachedResult{Decimal: "3"}, results[2])
+}
+
+func TestMemoryCacheAdapter_TTL(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryCacheAdapter(10, time.Minute)
+	cache.now = func() time.Time { return now }
+	require.NoError(t, cache.SaveResult("a", models.CachedResult{Value: 1}))
+
+	now = now.Add(time.Minute - time.Second)
+	results, err := cache.GetResults([]string{"a"})
+	require.NoError(t, err)
+	require.NotNil(t, results[0])
+
+	now = now.Add(2 * time.Second)
+	results, err = cache.GetResults([]string{"a"})
+	require.NoError(t, err)
+	require.Nil(t, results[0])
+	require.Zero(t, cache.order.Len())
+}
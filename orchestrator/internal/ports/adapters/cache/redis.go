@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/go-redis/redis/v7"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RedisCacheAdapter кэш результатов в Redis, общий для всех экземпляров оркестратора
+type RedisCacheAdapter struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisCacheAdapter создаёт кэш в Redis, ttl - сколько хранится результат, 0 - бессрочно
+func NewRedisCacheAdapter(client *redis.Client, ttl time.Duration) *RedisCacheAdapter {
+	return &RedisCacheAdapter{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// resultKey ключ результата в Redis. Ключ подвыражения может быть длинным, поэтому хранится его хеш
+func resultKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return "result:" + hex.EncodeToString(hash[:])
+}
+
+func (a *RedisCacheAdapter) GetResults(keys []string) ([]*models.CachedResult, error) {
+	results := make([]*models.CachedResult, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = resultKey(key)
+	}
+	values, err := a.client.MGet(redisKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached results: %w", err)
+	}
+	for i, value := range values {
+		encoded, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if result, ok := decodeResult(encoded); ok {
+			results[i] = &result
+		}
+	}
+	return results, nil
+}
+
+func (a *RedisCacheAdapter) SaveResult(key string, result models.CachedResult) error {
+	err := a.client.Set(resultKey(key), encodeResult(result), a.ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to save cached result: %w", err)
+	}
+	return nil
+}
+
+// encodeResult записывает результат строкой: d:<точный результат> или f:<результат>,
+// NaN и бесконечности записываются как NaN, +Inf и -Inf
+func encodeResult(result models.CachedResult) string {
+	if result.Decimal != "" {
+		return "d:" + result.Decimal
+	}
+	return "f:" + strconv.FormatFloat(result.Value, 'g', -1, 64)
+}
+
+func decodeResult(encoded string) (models.CachedResult, bool) {
+	kind, value, _ := strings.Cut(encoded, ":")
+	switch kind {
+	case "d":
+		return models.CachedResult{Decimal: value}, value != ""
+	case "f":
+		number, err := strconv.ParseFloat(value, 64)
+		return models.CachedResult{Value: number}, err == nil
+	default:
+		return models.CachedResult{}, false
+	}
+}
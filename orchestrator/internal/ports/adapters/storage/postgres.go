@@ -9,6 +9,7 @@ import (
 	"github.com/jaam8/web_calculator/orchestrator/internal/models"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 )
 
 type PostgresAdapter struct {
@@ -21,26 +22,124 @@ func NewPostgresAdapter(pool *pgxpool.Pool) *PostgresAdapter {
 	}
 }
 
-func (a *PostgresAdapter) SaveExpression(expression models.Expression) (uuid.UUID, error) {
-	query := `INSERT INTO expressions.expressions (user_id, status, result) 
-			  VALUES ($1, $2, $3)
+const insertExpressionQuery = `INSERT INTO expressions.expressions
+			  (user_id, status, result, rpn, precision_mode, priority, deadline, timeout_ms, batch_id, expression, normalized,
+			   tasks_saved, no_cache) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 			  RETURNING id`
-	var id uuid.UUID
-	err := a.pool.QueryRow(context.Background(), query,
+
+// insertExpressionArgs аргументы insertExpressionQuery
+func insertExpressionArgs(expression models.Expression) []any {
+	precision := expression.Precision
+	if precision == "" {
+		precision = models.PrecisionFloat
+	}
+	var timeout *int64
+	if expression.Deadline != nil {
+		ms := expression.Timeout.Milliseconds()
+		timeout = &ms
+	}
+	return []any{
 		expression.UserId,
 		expression.Status,
-		expression.Result).Scan(&id)
+		expression.Result,
+		expression.RPN,
+		precision,
+		expression.Priority,
+		expression.Deadline,
+		timeout,
+		expression.BatchID,
+		expression.Source,
+		expression.Normalized,
+		expression.TasksSaved,
+		expression.NoCache,
+	}
+}
+
+// selectExpressionColumns колонки выражения, которые читает scanExpression
+const selectExpressionColumns = `id, status, result, result::text, precision_mode, expression, normalized, error,
+			  tasks_saved, created_at, started_at, finished_at`
+
+// scanExpression читает выражение из строки с колонками selectExpressionColumns
+func scanExpression(row pgx.Row, expr *models.Expression) error {
+	var exact *string
+	err := row.Scan(&expr.ExpressionID, &expr.Status, &expr.Result, &exact, &expr.Precision,
+		&expr.Source, &expr.Normalized, &expr.Error, &expr.TasksSaved, &expr.CreatedAt, &expr.StartedAt, &expr.FinishedAt)
+	if err != nil {
+		return err
+	}
+	if expr.Precision == models.PrecisionDecimal {
+		expr.DecimalResult = exact
+	}
+	return nil
+}
+
+func (a *PostgresAdapter) SaveExpression(expression models.Expression) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := a.pool.QueryRow(context.Background(), insertExpressionQuery, insertExpressionArgs(expression)...).Scan(&id)
 	if err != nil {
 		return uuid.UUID{}, fmt.Errorf("failed to save expression: %w", err)
 	}
 	return id, nil
 }
 
+// SaveExpressions сохраняет выражения одним пакетом запросов: сохраняются или все выражения, или ни одного.
+// ID возвращаются в порядке выражений
+func (a *PostgresAdapter) SaveExpressions(expressions []models.Expression) ([]uuid.UUID, error) {
+	batch := &pgx.Batch{}
+	for _, expression := range expressions {
+		batch.Queue(insertExpressionQuery, insertExpressionArgs(expression)...)
+	}
+	results := a.pool.SendBatch(context.Background(), batch)
+	defer results.Close() //nolint
+
+	ids := make([]uuid.UUID, len(expressions))
+	for i := range expressions {
+		if err := results.QueryRow().Scan(&ids[i]); err != nil {
+			return nil, fmt.Errorf("failed to save expressions: %w", err)
+		}
+	}
+	if err := results.Close(); err != nil {
+		return nil, fmt.Errorf("failed to save expressions: %w", err)
+	}
+	return ids, nil
+}
+
+// GetBatchProgress считает выражения пакета по статусам
+func (a *PostgresAdapter) GetBatchProgress(userId, batchId uuid.UUID) (map[string]int, error) {
+	query := `SELECT status, count(*) FROM expressions.expressions
+			  WHERE user_id = $1 AND batch_id = $2
+			  GROUP BY status`
+	rows, err := a.pool.Query(context.Background(), query, userId, batchId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch progress: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err = rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan batch progress: %w", err)
+		}
+		statuses[status] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get batch progress: %w", err)
+	}
+
+	if len(statuses) == 0 {
+		return nil, errs.ErrBatchNotFound
+	}
+	return statuses, nil
+}
+
 func (a *PostgresAdapter) GetExpressionById(userId, id uuid.UUID) (*models.Expression, error) {
-	query := `SELECT status, result FROM expressions.expressions
+	query := `SELECT ` + selectExpressionColumns + ` FROM expressions.expressions
 			  WHERE user_id = $1 AND id = $2`
-	expr := models.Expression{UserId: userId, ExpressionID: id}
-	err := a.pool.QueryRow(context.Background(), query, userId, id).Scan(&expr.Status, &expr.Result)
+	expr := models.Expression{UserId: userId}
+	err := scanExpression(a.pool.QueryRow(context.Background(), query, userId, id), &expr)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, errs.ErrExpressionNotFound
@@ -50,25 +149,54 @@ func (a *PostgresAdapter) GetExpressionById(userId, id uuid.UUID) (*models.Expre
 	return &expr, nil
 }
 
-func (a *PostgresAdapter) GetExpressions(userId uuid.UUID) ([]*models.Expression, error) {
-	query := `SELECT id, status, result FROM expressions.expressions
+// GetExpressions возвращает страницу выражений пользователя по фильтрам params
+func (a *PostgresAdapter) GetExpressions(userId uuid.UUID, params models.ExpressionsQuery) ([]*models.Expression, error) {
+	query := `SELECT ` + selectExpressionColumns + ` FROM expressions.expressions
 			  WHERE user_id = $1`
+	args := []any{userId}
+	if len(params.Statuses) > 0 {
+		args = append(args, params.Statuses)
+		query += fmt.Sprintf(` AND status = ANY($%d)`, len(args))
+	}
+	if params.CreatedFrom != nil {
+		args = append(args, *params.CreatedFrom)
+		query += fmt.Sprintf(` AND created_at >= $%d`, len(args))
+	}
+	if params.CreatedTo != nil {
+		args = append(args, *params.CreatedTo)
+		query += fmt.Sprintf(` AND created_at < $%d`, len(args))
+	}
+	order, after := "DESC", "<"
+	if params.OldestFirst {
+		order, after = "ASC", ">"
+	}
+	if params.After != nil {
+		args = append(args, params.After.CreatedAt, params.After.ID)
+		query += fmt.Sprintf(` AND (created_at, id) %s ($%d, $%d)`, after, len(args)-1, len(args))
+	}
+	query += fmt.Sprintf(` ORDER BY created_at %s, id %s`, order, order)
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
 	var expressions []*models.Expression
-	rows, err := a.pool.Query(context.Background(), query, userId)
+	rows, err := a.pool.Query(context.Background(), query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get expressions: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		expr := new(models.Expression)
-		err = rows.Scan(&expr.ExpressionID, &expr.Status, &expr.Result)
-		if err != nil {
+		expr := &models.Expression{UserId: userId}
+		if err = scanExpression(rows, expr); err != nil {
 			return nil, fmt.Errorf("failed to scan expression: %w", err)
 		}
-		expr.UserId = userId
 		expressions = append(expressions, expr)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get expressions: %w", err)
+	}
 
 	if len(expressions) == 0 {
 		return nil, errs.ErrExpressionNotFound
@@ -82,7 +210,8 @@ func (a *PostgresAdapter) UpdateExpression(userId, id uuid.UUID, status *string,
 	args := make([]any, 0, 4)
 	if status != nil {
 		args = append(args, *status)
-		query += fmt.Sprintf(` status = $%d`, len(args))
+		// статус всегда меняется на конечный
+		query += fmt.Sprintf(` status = $%d, finished_at = now()`, len(args))
 	}
 	if result != nil {
 		if len(args) > 0 {
@@ -92,7 +221,8 @@ func (a *PostgresAdapter) UpdateExpression(userId, id uuid.UUID, status *string,
 		query += fmt.Sprintf(` result = $%d`, len(args))
 	}
 	args = append(args, userId, id)
-	query += fmt.Sprintf(` WHERE user_id = $%d AND id = $%d`, len(args)-1, len(args))
+	// статус завершённого выражения, например отменённого, больше не меняется
+	query += fmt.Sprintf(` WHERE user_id = $%d AND id = $%d AND status = 'pending'`, len(args)-1, len(args))
 	_, err := a.pool.Exec(context.Background(), query, args...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -102,3 +232,236 @@ func (a *PostgresAdapter) UpdateExpression(userId, id uuid.UUID, status *string,
 	}
 	return nil
 }
+
+// FailExpression сохраняет статус ошибки вычисления и её подробности details
+func (a *PostgresAdapter) FailExpression(userId, id uuid.UUID, status, details string) error {
+	query := `UPDATE expressions.expressions SET status = $1, error = $2, finished_at = now()
+			  WHERE user_id = $3 AND id = $4 AND status = 'pending'`
+	_, err := a.pool.Exec(context.Background(), query, status, details, userId, id)
+	if err != nil {
+		return fmt.Errorf("failed to fail expression: %w", err)
+	}
+	return nil
+}
+
+// StartExpression запоминает момент, когда агент взял первую задачу выражения
+func (a *PostgresAdapter) StartExpression(id uuid.UUID) error {
+	query := `UPDATE expressions.expressions SET started_at = now()
+			  WHERE id = $1 AND started_at IS NULL`
+	_, err := a.pool.Exec(context.Background(), query, id)
+	if err != nil {
+		return fmt.Errorf("failed to start expression: %w", err)
+	}
+	return nil
+}
+
+// StopExpression переводит ещё вычисляемое выражение в конечный статус, например cancelled или timed out,
+// details - подробности, пустые не сохраняются.
+// Возвращает errors.ErrExpressionFinished, если выражение уже завершилось
+func (a *PostgresAdapter) StopExpression(userId, id uuid.UUID, status, details string) error {
+	query := `UPDATE expressions.expressions SET status = $1, error = nullif($2, ''), finished_at = now()
+			  WHERE user_id = $3 AND id = $4 AND status = 'pending'`
+	tag, err := a.pool.Exec(context.Background(), query, status, details, userId, id)
+	if err != nil {
+		return fmt.Errorf("failed to stop expression: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+	if _, err = a.GetExpressionById(userId, id); err != nil {
+		return err
+	}
+	return errs.ErrExpressionFinished
+}
+
+// UpdateExpressionDecimal сохраняет статус и точный результат выражения в режиме PrecisionDecimal
+func (a *PostgresAdapter) UpdateExpressionDecimal(userId, id uuid.UUID, status string, result string) error {
+	query := `UPDATE expressions.expressions SET status = $1, result = $2, finished_at = now()
+			  WHERE user_id = $3 AND id = $4 AND status = 'pending'`
+	_, err := a.pool.Exec(context.Background(), query, status, result, userId, id)
+	if err != nil {
+		return fmt.Errorf("failed to update expression: %w", err)
+	}
+	return nil
+}
+
+func (a *PostgresAdapter) GetPendingExpressions() ([]*models.Expression, error) {
+	query := `SELECT id, user_id, status, rpn, precision_mode, priority, deadline, timeout_ms, started_at, no_cache
+			  FROM expressions.expressions
+			  WHERE status = 'pending'`
+	var expressions []*models.Expression
+	rows, err := a.pool.Query(context.Background(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending expressions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		expr := new(models.Expression)
+		var timeout *int64
+		err = rows.Scan(&expr.ExpressionID, &expr.UserId, &expr.Status, &expr.RPN, &expr.Precision, &expr.Priority,
+			&expr.Deadline, &timeout, &expr.StartedAt, &expr.NoCache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expression: %w", err)
+		}
+		if timeout != nil {
+			expr.Timeout = time.Duration(*timeout) * time.Millisecond
+		}
+		expressions = append(expressions, expr)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get pending expressions: %w", err)
+	}
+
+	return expressions, nil
+}
+
+func (a *PostgresAdapter) SaveTask(task models.Task) error {
+	query := `INSERT INTO expressions.tasks (expression_id, task_id, node_id, args, operation, status)
+			  VALUES ($1, $2, $3, $4, $5, 'pending')`
+	var args any = task.Args
+	if task.DecimalArgs != nil {
+		args = task.DecimalArgs
+	}
+	_, err := a.pool.Exec(context.Background(), query,
+		task.ExpressionID,
+		task.TaskID,
+		task.NodeID,
+		args,
+		task.Operation)
+	if err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+	return nil
+}
+
+// StartTask отмечает, что задачу выдали агенту agentID, пустой agentID - задачу взяли через GetTask
+func (a *PostgresAdapter) StartTask(expressionID uuid.UUID, taskID int, agentID string) error {
+	query := `UPDATE expressions.tasks SET agent_id = nullif($3, ''), started_at = now(), attempts = attempts + 1
+			  WHERE expression_id = $1 AND task_id = $2`
+	tag, err := a.pool.Exec(context.Background(), query, expressionID, taskID, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to start task: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrTaskNotFound
+	}
+	return nil
+}
+
+// SaveTaskResult сохраняет результат задачи или, если result.Err задан, ошибку агента
+func (a *PostgresAdapter) SaveTaskResult(result models.Result) error {
+	query := `UPDATE expressions.tasks SET status = $1, result = $2, error = $3, finished_at = now()
+			  WHERE expression_id = $4 AND task_id = $5`
+	status := models.StatusDone
+	var value any = result.Result
+	if result.Decimal != "" {
+		value = result.Decimal
+	}
+	var details *string
+	if result.Err != nil {
+		status, value = "failed", nil
+		text := result.Err.Error()
+		details = &text
+	}
+	tag, err := a.pool.Exec(context.Background(), query, status, value, details, result.ExpressionID, result.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to save task result: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrTaskNotFound
+	}
+	return nil
+}
+
+func (a *PostgresAdapter) GetTasks(expressionID uuid.UUID) ([]*models.Task, error) {
+	query := `SELECT task_id, node_id, args, args::text[], operation, status, result, result::text,
+			  agent_id, attempts, error, queued_at, started_at, finished_at FROM expressions.tasks
+			  WHERE expression_id = $1
+			  ORDER BY task_id`
+	var tasks []*models.Task
+	rows, err := a.pool.Query(context.Background(), query, expressionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task := &models.Task{ExpressionID: expressionID}
+		err = rows.Scan(&task.TaskID, &task.NodeID, &task.Args, &task.DecimalArgs,
+			&task.Operation, &task.Status, &task.Result, &task.DecimalResult,
+			&task.AgentID, &task.Attempts, &task.Error, &task.QueuedAt, &task.StartedAt, &task.FinishedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+func (a *PostgresAdapter) SaveVariable(variable models.Variable) error {
+	query := `INSERT INTO expressions.variables (user_id, name, value)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (user_id, name) DO UPDATE SET value = EXCLUDED.value`
+	_, err := a.pool.Exec(context.Background(), query, variable.UserId, variable.Name, variable.Value)
+	if err != nil {
+		return fmt.Errorf("failed to save variable: %w", err)
+	}
+	return nil
+}
+
+func (a *PostgresAdapter) GetVariables(userId uuid.UUID) ([]*models.Variable, error) {
+	query := `SELECT name, value FROM expressions.variables
+			  WHERE user_id = $1
+			  ORDER BY name`
+	var variables []*models.Variable
+	rows, err := a.pool.Query(context.Background(), query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variables: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		variable := &models.Variable{UserId: userId}
+		if err = rows.Scan(&variable.Name, &variable.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan variable: %w", err)
+		}
+		variables = append(variables, variable)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+func (a *PostgresAdapter) GetVariable(userId uuid.UUID, name string) (*models.Variable, error) {
+	query := `SELECT value FROM expressions.variables
+			  WHERE user_id = $1 AND name = $2`
+	variable := models.Variable{UserId: userId, Name: name}
+	err := a.pool.QueryRow(context.Background(), query, userId, name).Scan(&variable.Value)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.ErrVariableNotFound
+		}
+		return nil, fmt.Errorf("failed to get variable: %w", err)
+	}
+	return &variable, nil
+}
+
+func (a *PostgresAdapter) DeleteVariable(userId uuid.UUID, name string) error {
+	query := `DELETE FROM expressions.variables
+			  WHERE user_id = $1 AND name = $2`
+	tag, err := a.pool.Exec(context.Background(), query, userId, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete variable: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errs.ErrVariableNotFound
+	}
+	return nil
+}
@@ -7,7 +7,29 @@ import (
 
 type StorageAdapter interface {
 	SaveExpression(expression models.Expression) (uuid.UUID, error)
+	SaveExpressions(expressions []models.Expression) ([]uuid.UUID, error)
+	GetBatchProgress(userId uuid.UUID, batchId uuid.UUID) (map[string]int, error)
 	GetExpressionById(userId uuid.UUID, id uuid.UUID) (*models.Expression, error)
-	GetExpressions(userId uuid.UUID) ([]*models.Expression, error)
+	GetExpressions(userId uuid.UUID, params models.ExpressionsQuery) ([]*models.Expression, error)
 	UpdateExpression(userId uuid.UUID, id uuid.UUID, status *string, result *float64) error
+	UpdateExpressionDecimal(userId uuid.UUID, id uuid.UUID, status string, result string) error
+	FailExpression(userId uuid.UUID, id uuid.UUID, status, details string) error
+	StartExpression(id uuid.UUID) error
+	StopExpression(userId uuid.UUID, id uuid.UUID, status, details string) error
+	GetPendingExpressions() ([]*models.Expression, error)
+	SaveTask(task models.Task) error
+	StartTask(expressionID uuid.UUID, taskID int, agentID string) error
+	SaveTaskResult(result models.Result) error
+	GetTasks(expressionID uuid.UUID) ([]*models.Task, error)
+	SaveVariable(variable models.Variable) error
+	GetVariables(userId uuid.UUID) ([]*models.Variable, error)
+	GetVariable(userId uuid.UUID, name string) (*models.Variable, error)
+	DeleteVariable(userId uuid.UUID, name string) error
+}
+
+// CacheAdapter кэш результатов подвыражений по их каноническому ключу
+type CacheAdapter interface {
+	// GetResults возвращает результаты в порядке keys, nil - результата нет в кэше
+	GetResults(keys []string) ([]*models.CachedResult, error)
+	SaveResult(key string, result models.CachedResult) error
 }
@@ -12,18 +12,26 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"net"
+	"time"
 )
 
 func CreateGRPC(grpcSrv *service.OrchestratorService) (*grpc.Server, error) {
-	server := grpc.NewServer(grpc.UnaryInterceptor(interceptors.AddLogMiddleware))
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptors.AddLogMiddleware),
+		grpc.StreamInterceptor(interceptors.AddStreamLogMiddleware),
+	)
 	orchestrator.RegisterOrchestratorServiceServer(server, grpcSrv)
 	return server, nil
 }
 
 func NewOrchestratorService(
 	storage ports.StorageAdapter,
-	expressionManager types.ExpressionManager) *service.OrchestratorService {
-	return service.NewOrchestratorService(storage, expressionManager)
+	expressionManager types.ExpressionManager,
+	agents types.AgentRegistry,
+	defaultTimeout time.Duration,
+	cache ports.CacheAdapter,
+	simplify bool) *service.OrchestratorService {
+	return service.NewOrchestratorService(storage, expressionManager, agents, defaultTimeout, cache, simplify)
 }
 
 func RunGRPC(ctx context.Context, server *grpc.Server, port int) {
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	errs "github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
+	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *OrchestratorService) RegisterAgent(
+	ctx context.Context, request *orchestrator.RegisterAgentRequest,
+) (*orchestrator.RegisterAgentResponse, error) {
+	// задачи достаются только агентам, умеющим их операции, агент без операций не получит ничего
+	if request.Id == "" || request.Capacity <= 0 || len(request.Operations) == 0 {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"invalid agent registration",
+			zap.String("agentID", request.Id),
+			zap.Int32("capacity", request.Capacity),
+		)
+		return nil, errs.ErrInvalidAgent
+	}
+
+	s.agents.Register(models.Agent{
+		ID:         request.Id,
+		Capacity:   int(request.Capacity),
+		Operations: request.Operations,
+	})
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("registered agent %s", request.Id),
+		zap.String("agentID", request.Id),
+		zap.Int32("capacity", request.Capacity),
+		zap.Strings("operations", request.Operations),
+	)
+	return &orchestrator.RegisterAgentResponse{
+		HeartbeatInterval: durationpb.New(s.agents.HeartbeatInterval()),
+	}, nil
+}
+
+func (s *OrchestratorService) Heartbeat(
+	ctx context.Context, request *orchestrator.HeartbeatRequest,
+) (*emptypb.Empty, error) {
+	if err := s.agents.Heartbeat(request.Id); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"heartbeat from unknown agent",
+			zap.String("agentID", request.Id),
+		)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *OrchestratorService) ListAgents(
+	_ context.Context, _ *emptypb.Empty,
+) (*orchestrator.ListAgentsResponse, error) {
+	agents := s.agents.Agents()
+	response := &orchestrator.ListAgentsResponse{
+		Agents: make([]*orchestrator.Agent, 0, len(agents)),
+	}
+	for _, agent := range agents {
+		response.Agents = append(response.Agents, &orchestrator.Agent{
+			Id:           agent.ID,
+			Capacity:     int32(agent.Capacity),
+			Operations:   agent.Operations,
+			Busy:         int32(agent.Busy),
+			RegisteredAt: timestamppb.New(agent.RegisteredAt),
+			LastSeen:     timestamppb.New(agent.LastSeen),
+		})
+	}
+	return response, nil
+}
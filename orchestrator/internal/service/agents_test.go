@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
+	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"testing"
+	"time"
+)
+
+func TestRegisterAgent(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *orchestrator.RegisterAgentRequest
+		setupMocks  func(agents *MockAgentRegistry)
+		expectedErr error
+	}{
+		{
+			name:    "success",
+			request: &orchestrator.RegisterAgentRequest{Id: "agent-1", Capacity: 2, Operations: []string{"+", "-"}},
+			setupMocks: func(agents *MockAgentRegistry) {
+				agents.On("Register", models.Agent{ID: "agent-1", Capacity: 2, Operations: []string{"+", "-"}}).Return()
+				agents.On("HeartbeatInterval").Return(2 * time.Second)
+			},
+		},
+		{
+			name:        "empty id",
+			request:     &orchestrator.RegisterAgentRequest{Capacity: 2, Operations: []string{"+"}},
+			setupMocks:  func(_ *MockAgentRegistry) {},
+			expectedErr: errors.ErrInvalidAgent,
+		},
+		{
+			name:        "no operations",
+			request:     &orchestrator.RegisterAgentRequest{Id: "agent-1", Capacity: 2},
+			setupMocks:  func(_ *MockAgentRegistry) {},
+			expectedErr: errors.ErrInvalidAgent,
+		},
+		{
+			name:        "no capacity",
+			request:     &orchestrator.RegisterAgentRequest{Id: "agent-1", Operations: []string{"+"}},
+			setupMocks:  func(_ *MockAgentRegistry) {},
+			expectedErr: errors.ErrInvalidAgent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agents := new(MockAgentRegistry)
+			tt.setupMocks(agents)
+			ctx, _ := logger.New(context.Background())
+
+			service := NewOrchestratorService(new(MockStorageAdapter), new(MockExpressionManager), agents, 0, nil, false)
+			resp, err := service.RegisterAgent(ctx, tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2*time.Second, resp.HeartbeatInterval.AsDuration())
+			}
+			agents.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	agents := new(MockAgentRegistry)
+	agents.On("Heartbeat", "agent-1").Return(nil)
+	agents.On("Heartbeat", "agent-2").Return(errors.ErrAgentNotFound)
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(new(MockStorageAdapter), new(MockExpressionManager), agents, 0, nil, false)
+
+	_, err := service.Heartbeat(ctx, &orchestrator.HeartbeatRequest{Id: "agent-1"})
+	assert.NoError(t, err)
+	_, err = service.Heartbeat(ctx, &orchestrator.HeartbeatRequest{Id: "agent-2"})
+	assert.ErrorIs(t, err, errors.ErrAgentNotFound)
+	agents.AssertExpectations(t)
+}
+
+func TestListAgents(t *testing.T) {
+	seen := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	agents := new(MockAgentRegistry)
+	agents.On("Agents").Return([]models.Agent{
+		{ID: "agent-1", Capacity: 2, Operations: []string{"+"}, Busy: 1, RegisteredAt: seen, LastSeen: seen},
+	})
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(new(MockStorageAdapter), new(MockExpressionManager), agents, 0, nil, false)
+
+	resp, err := service.ListAgents(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Agents, 1)
+	assert.Equal(t, "agent-1", resp.Agents[0].Id)
+	assert.Equal(t, int32(2), resp.Agents[0].Capacity)
+	assert.Equal(t, int32(1), resp.Agents[0].Busy)
+	assert.Equal(t, seen, resp.Agents[0].LastSeen.AsTime())
+	agents.AssertExpectations(t)
+}
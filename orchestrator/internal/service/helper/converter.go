@@ -1,66 +1,78 @@
 package helper
 
 import (
-	"github.com/jaam8/web_calculator/common-lib/errors"
-	"unicode"
+	"strings"
 )
 
 var precedence = map[string]int{
 	"+": 1, "-": 1,
-	"*": 2, "/": 2,
+	"*": 2, "/": 2, "%": 2, "//": 2,
+	"^": 3,
 }
 
-// ToRPN преобразует выражение в обратную польскую нотацию
-func ToRPN(expression string) ([]string, error) {
-	var stack []string
-	var output []string
-	var num string
-	if err := ValidateExpression(expression); err != nil {
-		return nil, errors.ErrInvalidExpression
+// rightAssociative операторы, которые группируются справа налево: 2^3^2 = 2^(3^2)
+var rightAssociative = map[string]bool{
+	"^": true,
+}
+
+// ToRPN преобразует выражение в обратную польскую нотацию, variables - переменные пользователя
+func ToRPN(expression string, variables map[string]float64) ([]string, error) {
+	expr, err := ParseWithVariables(expression, variables)
+	if err != nil {
+		return nil, err
 	}
-	for _, s := range expression {
-		value := string(s)
-		if unicode.IsDigit(s) || (value == "." && num != "") {
-			num += value
-		} else {
-			if num != "" {
-				output = append(output, num)
-				num = ""
-			}
-			if _, ok := precedence[value]; ok {
-				for len(stack) > 0 && precedence[stack[len(stack)-1]] >= precedence[value] {
-					output = append(output, stack[len(stack)-1])
-					stack = stack[:len(stack)-1]
-				}
-				stack = append(stack, value)
-			} else if value == "(" {
-				stack = append(stack, value)
-			} else if value == ")" {
-				for len(stack) > 0 && stack[len(stack)-1] != "(" {
-					output = append(output, stack[len(stack)-1])
-					stack = stack[:len(stack)-1]
-				}
-				if len(stack) == 0 {
-					return nil, errors.ErrInvalidExpression
-				}
-				stack = stack[:len(stack)-1]
-			} else if !unicode.IsSpace(s) {
-				return nil, errors.ErrInvalidExpression
-			}
-		}
+	if err = validate(expr); err != nil {
+		return nil, err
 	}
+	return appendRPN(nil, expr), nil
+}
 
-	if num != "" {
-		output = append(output, num)
+// appendRPN дописывает вершину дерева в ОПН. Унарный минус над константой сворачивается
+// в отрицательное число, над подвыражением - превращается в умножение на -1
+func appendRPN(output []string, expr Expr) []string {
+	switch e := expr.(type) {
+	case *Number:
+		return append(output, e.Literal)
+	case *Unary:
+		if literal, ok := constantLiteral(e); ok {
+			return append(output, literal)
+		}
+		if e.Operator == "+" {
+			return appendRPN(output, e.Operand)
+		}
+		output = append(output, "-1")
+		output = appendRPN(output, e.Operand)
+		return append(output, "*")
+	case *Binary:
+		output = appendRPN(output, e.Left)
+		output = appendRPN(output, e.Right)
+		return append(output, e.Operator)
+	case *Call:
+		for _, arg := range e.Args {
+			output = appendRPN(output, arg)
+		}
+		return append(output, callToken(e.Name, len(e.Args)))
+	default:
+		return output
 	}
+}
 
-	for len(stack) > 0 {
-		if stack[len(stack)-1] == "(" {
-			return nil, errors.ErrInvalidExpression
+// constantLiteral возвращает текст константы со знаком. Знак дописывается к литералу, а не
+// вычисляется через float64, иначе в десятичном режиме потерялись бы цифры длинных чисел
+func constantLiteral(expr Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *Number:
+		return e.Literal, true
+	case *Unary:
+		literal, ok := constantLiteral(e.Operand)
+		if !ok || e.Operator == "+" {
+			return literal, ok
 		}
-		output = append(output, stack[len(stack)-1])
-		stack = stack[:len(stack)-1]
+		if negative, found := strings.CutPrefix(literal, "-"); found {
+			return negative, true
+		}
+		return "-" + literal, true
+	default:
+		return "", false
 	}
-
-	return output, nil
 }
@@ -74,15 +74,123 @@ func TestToRPN(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name:    "too many operators in one operation",
+			name:    "unary minus after operator",
 			expr:    "2+-2",
+			want:    []string{"2", "-2", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "unary minus at start",
+			expr:    "-5+3",
+			want:    []string{"-5", "3", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "unary minus in brackets",
+			expr:    "2*(-1)",
+			want:    []string{"2", "-1", "*"},
+			wantErr: nil,
+		},
+		{
+			name:    "unary minus before brackets",
+			expr:    "-(1+2)",
+			want:    []string{"-1", "1", "2", "+", "*"},
+			wantErr: nil,
+		},
+		{
+			name:    "unary minus keeps digits of long literal",
+			expr:    "-1.000000000000000000001+-(-0.1)",
+			want:    []string{"-1.000000000000000000001", "0.1", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "unary plus",
+			expr:    "+2*+3",
+			want:    []string{"2", "3", "*"},
+			wantErr: nil,
+		},
+		{
+			name:    "scientific notation",
+			expr:    "1e-3+2.5E2",
+			want:    []string{"1e-3", "2.5E2", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "exponentiation is right associative",
+			expr:    "2^3^2",
+			want:    []string{"2", "3", "2", "^", "^"},
+			wantErr: nil,
+		},
+		{
+			name:    "exponentiation binds tighter than unary minus",
+			expr:    "-2^2",
+			want:    []string{"-1", "2", "2", "^", "*"},
+			wantErr: nil,
+		},
+		{
+			name:    "signed exponent",
+			expr:    "2^-1*3",
+			want:    []string{"2", "-1", "^", "3", "*"},
+			wantErr: nil,
+		},
+		{
+			name:    "modulo and integer division",
+			expr:    "7//2+7%2*3",
+			want:    []string{"7", "2", "//", "7", "2", "%", "3", "*", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "integer division by zero",
+			expr:    "7//0",
+			want:    nil,
+			wantErr: errors.ErrDivideByZero,
+		},
+		{
+			name:    "functions",
+			expr:    "max(1, 2*3, -sqrt(4)) + log(8, 2)",
+			want:    []string{"1", "2", "3", "*", "-1", "4", "sqrt:1", "*", "max:3", "8", "2", "log:2", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "division by zero inside function",
+			expr:    "abs(1/0)",
+			want:    nil,
+			wantErr: errors.ErrDivideByZero,
+		},
+		{
+			name:    "variables",
+			expr:    "x*rate + max(x, 1)",
+			want:    []string{"2.5", "-0.5", "*", "2.5", "1", "max:2", "+"},
+			wantErr: nil,
+		},
+		{
+			name:    "undefined variable",
+			expr:    "x + y",
+			want:    nil,
+			wantErr: errors.ErrInvalidExpression,
+		},
+		{
+			name:    "implicit multiplication",
+			expr:    "2(3)",
+			want:    nil,
+			wantErr: errors.ErrInvalidExpression,
+		},
+		{
+			name:    "too many operators in one operation",
+			expr:    "2*/2",
 			want:    nil,
 			wantErr: errors.ErrInvalidExpression,
 		},
+		{
+			name:    "division by zero constant",
+			expr:    "1/-0",
+			want:    nil,
+			wantErr: errors.ErrDivideByZero,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ToRPN(tt.expr)
+			got, err := ToRPN(tt.expr, map[string]float64{"x": 2.5, "rate": -0.5})
 			require.Equal(t, tt.want, got)
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
@@ -0,0 +1,219 @@
+package helper
+
+import (
+	stderrors "errors"
+	"fmt"
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Node вершина графа вычислений: число, операция над двумя вершинами или вызов функции
+type Node struct {
+	ID        int
+	Operation string
+	Value     float64
+	// Decimal точное значение вершины для вычислений в десятичной арифметике
+	Decimal string
+	Done    bool
+	Args    []*Node
+	Parent  *Node
+	// Key каноническая запись поддерева вершины в ОПН, у одинаковых подвыражений она совпадает.
+	// Операнды сложения и умножения упорядочены, поэтому 1+2 и 2+1 тоже совпадают
+	Key string
+}
+
+// Ready сообщает, что операнды вершины уже вычислены и её можно отправлять на вычисление
+func (n *Node) Ready() bool {
+	if n.Done || len(n.Args) == 0 {
+		return false
+	}
+	for _, arg := range n.Args {
+		if !arg.Done {
+			return false
+		}
+	}
+	return true
+}
+
+// ArgValues возвращает значения операндов вершины
+func (n *Node) ArgValues() []float64 {
+	values := make([]float64, len(n.Args))
+	for i, arg := range n.Args {
+		values[i] = arg.Value
+	}
+	return values
+}
+
+// ArgDecimals возвращает точные значения операндов вершины
+func (n *Node) ArgDecimals() []string {
+	values := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		values[i] = arg.Decimal
+	}
+	return values
+}
+
+// String записывает операцию вершины над значениями операндов: 10 / 0, sqrt(-1).
+// Вершина-число записывается своим значением
+func (n *Node) String() string {
+	if len(n.Args) == 0 {
+		return n.value()
+	}
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		args[i] = arg.value()
+	}
+	if _, ok := precedence[n.Operation]; ok && len(args) == 2 {
+		return args[0] + " " + n.Operation + " " + args[1]
+	}
+	return n.Operation + "(" + strings.Join(args, ", ") + ")"
+}
+
+// value точное значение вершины, если оно есть, иначе её приближение
+func (n *Node) value() string {
+	if n.Decimal != "" {
+		return n.Decimal
+	}
+	return strconv.FormatFloat(n.Value, 'g', -1, 64)
+}
+
+// Resolve сохраняет результат вычисления вершины
+func (n *Node) Resolve(value float64) {
+	n.Value = value
+	n.Done = true
+}
+
+// ResolveDecimal сохраняет точный результат вычисления вершины, Value получает его приближение
+// (±Inf, если число не помещается в float64)
+func (n *Node) ResolveDecimal(value string) error {
+	approx, err := strconv.ParseFloat(value, 64)
+	if err != nil && !stderrors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("malformed decimal %q: %w", value, err)
+	}
+	n.Decimal = value
+	n.Resolve(approx)
+	return nil
+}
+
+// DAG граф зависимостей задач выражения
+type DAG struct {
+	Root  *Node
+	Nodes []*Node
+	// Decimal задачи графа вычисляются в десятичной арифметике над Node.Decimal
+	Decimal bool
+	// NoCache результаты подвыражений не берутся из кэша, а считаются заново
+	NoCache bool
+}
+
+// BuildDAG строит граф зависимостей задач из выражения в ОПН,
+// ID вершины совпадает с позицией токена в ОПН
+func BuildDAG(rpn []string) (*DAG, error) {
+	var stack []*Node
+	dag := &DAG{Nodes: make([]*Node, 0, len(rpn))}
+	for i, v := range rpn {
+		node := &Node{ID: i, Key: v}
+		if num, err := strconv.ParseFloat(v, 64); err == nil {
+			node.Resolve(num)
+			node.Decimal = v
+		} else {
+			operation, args, ok := parseCallToken(v)
+			if !ok {
+				operation, args = v, 2
+				if _, ok = precedence[v]; !ok {
+					return nil, errors.ErrInvalidExpression
+				}
+			}
+			if len(stack) < args {
+				return nil, errors.ErrInvalidExpression
+			}
+			node.Operation = operation
+			node.Args = append([]*Node(nil), stack[len(stack)-args:]...)
+			for _, arg := range node.Args {
+				arg.Parent = node
+			}
+			node.Key = subtreeKey(v, node.Args)
+			stack = stack[:len(stack)-args]
+		}
+		stack = append(stack, node)
+		dag.Nodes = append(dag.Nodes, node)
+	}
+
+	if len(stack) != 1 {
+		return nil, errors.ErrInvalidExpression
+	}
+	dag.Root = stack[0]
+	return dag, nil
+}
+
+// commutative операции, результат которых не зависит от порядка операндов
+var commutative = map[string]bool{
+	"+": true,
+	"*": true,
+}
+
+// subtreeKey записывает поддерево операции token над операндами args в ОПН
+func subtreeKey(token string, args []*Node) string {
+	keys := make([]string, len(args), len(args)+1)
+	for i, arg := range args {
+		keys[i] = arg.Key
+	}
+	// ОПН с известным числом операндов однозначна, поэтому разные упорядоченные
+	// поддеревья не дают одинаковых ключей
+	if commutative[token] {
+		slices.Sort(keys)
+	}
+	return strings.Join(append(keys, token), " ")
+}
+
+// ResolveCached сохраняет готовый результат вершины, например из кэша: вершины её поддерева
+// больше не нужно вычислять и они отмечаются посчитанными. value - результат в десятичной
+// арифметике, если граф вычисляется в ней, иначе обычный. Возвращает, сколько задач не придётся считать
+func (d *DAG) ResolveCached(n *Node, value float64, decimal string) (int, error) {
+	if d.Decimal {
+		if err := n.ResolveDecimal(decimal); err != nil {
+			return 0, err
+		}
+	} else {
+		n.Resolve(value)
+	}
+	saved := 1
+	stack := append([]*Node(nil), n.Args...)
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if node.Done {
+			continue
+		}
+		node.Done = true
+		saved++
+		stack = append(stack, node.Args...)
+	}
+	return saved, nil
+}
+
+// Ready возвращает все вершины, которые можно вычислять прямо сейчас
+func (d *DAG) Ready() []*Node {
+	var ready []*Node
+	for _, node := range d.Nodes {
+		if node.Ready() {
+			ready = append(ready, node)
+		}
+	}
+	return ready
+}
+
+// Progress возвращает количество посчитанных задач графа и общее количество задач
+func (d *DAG) Progress() (done, total int) {
+	for _, node := range d.Nodes {
+		if len(node.Args) == 0 {
+			continue
+		}
+		total++
+		if node.Done {
+			done++
+		}
+	}
+	return done, total
+}
@@ -0,0 +1,175 @@
+package helper
+
+import (
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/stretchr/testify/require"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBuildDAG(t *testing.T) {
+	tests := []struct {
+		name      string
+		rpn       []string
+		wantReady []string
+		wantErr   error
+	}{
+		{
+			name:      "single number",
+			rpn:       []string{"42"},
+			wantReady: nil,
+		},
+		{
+			name:      "independent subexpressions",
+			rpn:       []string{"1", "2", "+", "3", "4", "+", "*"},
+			wantReady: []string{"+", "+"},
+		},
+		{
+			name:      "dependent chain",
+			rpn:       []string{"1", "2", "+", "3", "+", "4", "+"},
+			wantReady: []string{"+"},
+		},
+		{
+			name:      "function calls",
+			rpn:       []string{"1", "2", "3", "max:3", "4", "sqrt:1", "+"},
+			wantReady: []string{"max", "sqrt"},
+		},
+		{
+			name:    "wrong function arity",
+			rpn:     []string{"1", "2", "sqrt:2"},
+			wantErr: errors.ErrInvalidExpression,
+		},
+		{
+			name:    "not enough function arguments",
+			rpn:     []string{"1", "max:2"},
+			wantErr: errors.ErrInvalidExpression,
+		},
+		{
+			name:    "not enough operands",
+			rpn:     []string{"1", "+"},
+			wantErr: errors.ErrInvalidExpression,
+		},
+		{
+			name:    "too many operands",
+			rpn:     []string{"1", "2", "3", "+"},
+			wantErr: errors.ErrInvalidExpression,
+		},
+		{
+			name:    "unknown operation",
+			rpn:     []string{"1", "2", "="},
+			wantErr: errors.ErrInvalidExpression,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dag, err := BuildDAG(tt.rpn)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, dag.Nodes, len(tt.rpn))
+
+			var ready []string
+			for _, node := range dag.Ready() {
+				ready = append(ready, node.Operation)
+			}
+			require.Equal(t, tt.wantReady, ready)
+		})
+	}
+}
+
+func TestDAG_Resolve(t *testing.T) {
+	dag, err := BuildDAG([]string{"1", "2", "+", "3", "4", "+", "*"})
+	require.NoError(t, err)
+
+	ready := dag.Ready()
+	require.Len(t, ready, 2)
+	require.False(t, dag.Root.Ready())
+
+	ready[0].Resolve(3)
+	require.False(t, dag.Root.Ready())
+	done, total := dag.Progress()
+	require.Equal(t, []int{1, 3}, []int{done, total})
+
+	ready[1].Resolve(7)
+	require.True(t, dag.Root.Ready())
+	require.Equal(t, []float64{3, 7}, dag.Root.ArgValues())
+
+	dag.Root.Resolve(21)
+	require.Empty(t, dag.Ready())
+}
+
+func TestDAG_ResolveDecimal(t *testing.T) {
+	dag, err := BuildDAG([]string{"0.1", "0.2", "+", "1e-1", "*"})
+	require.NoError(t, err)
+
+	ready := dag.Ready()
+	require.Len(t, ready, 1)
+	require.Equal(t, []string{"0.1", "0.2"}, ready[0].ArgDecimals())
+
+	require.NoError(t, ready[0].ResolveDecimal("0.3"))
+	require.Equal(t, 0.3, ready[0].Value)
+	require.Equal(t, []string{"0.3", "1e-1"}, dag.Root.ArgDecimals())
+
+	require.NoError(t, dag.Root.ResolveDecimal("1"+strings.Repeat("0", 400)))
+	require.True(t, math.IsInf(dag.Root.Value, 1))
+
+	require.Error(t, dag.Root.ResolveDecimal("abc"))
+}
+
+func TestNode_String(t *testing.T) {
+	dag, err := BuildDAG([]string{"10", "3", "3", "-", "/", "-1", "sqrt:1", "max:2"})
+	require.NoError(t, err)
+
+	ready := dag.Ready()
+	require.Len(t, ready, 2)
+	require.Equal(t, "3 - 3", ready[0].String())
+	require.Equal(t, "sqrt(-1)", ready[1].String())
+
+	ready[0].Resolve(0)
+	require.Equal(t, "10 / 0", dag.Nodes[4].String())
+	require.Equal(t, "10", dag.Nodes[0].String())
+}
+
+func TestBuildDAG_Key(t *testing.T) {
+	dag, err := BuildDAG([]string{"1", "2", "+", "3", "*", "3", "2", "1", "+", "*", "-"})
+	require.NoError(t, err)
+
+	// операнды сложения и умножения упорядочены: (1+2)*3 и 3*(2+1) - одно подвыражение
+	require.Equal(t, "1 2 +", dag.Nodes[2].Key)
+	require.Equal(t, dag.Nodes[2].Key, dag.Nodes[8].Key)
+	require.Equal(t, dag.Nodes[4].Key, dag.Nodes[9].Key)
+	require.Equal(t, "1 2 + 3 * 1 2 + 3 * -", dag.Root.Key)
+
+	// у некоммутативных операций порядок операндов важен
+	other, err := BuildDAG([]string{"2", "1", "-"})
+	require.NoError(t, err)
+	minus, err := BuildDAG([]string{"1", "2", "-"})
+	require.NoError(t, err)
+	require.NotEqual(t, minus.Root.Key, other.Root.Key)
+}
+
+func TestDAG_ResolveCached(t *testing.T) {
+	dag, err := BuildDAG([]string{"1", "2", "+", "3", "4", "+", "*", "5", "-"})
+	require.NoError(t, err)
+
+	// из кэша взято (1+2)*(3+4): его задачи считать не нужно, готово только вычитание
+	saved, err := dag.ResolveCached(dag.Nodes[6], 21, "")
+	require.NoError(t, err)
+	require.Equal(t, 3, saved)
+	require.Equal(t, []*Node{dag.Root}, dag.Ready())
+	require.Equal(t, []float64{21, 5}, dag.Root.ArgValues())
+	done, total := dag.Progress()
+	require.Equal(t, []int{3, 4}, []int{done, total})
+
+	decimal, err := BuildDAG([]string{"0.1", "0.2", "+"})
+	require.NoError(t, err)
+	decimal.Decimal = true
+	_, err = decimal.ResolveCached(decimal.Root, 0, "0.3")
+	require.NoError(t, err)
+	require.Equal(t, "0.3", decimal.Root.Decimal)
+	_, err = decimal.ResolveCached(decimal.Root, 0, "abc")
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"strings"
+)
+
+// Normalize возвращает выражение в каноническом виде: значения переменных подставлены,
+// операции разделены пробелами, лишние скобки убраны. Например, " (1+x) *2" при x = 3
+// становится "(1 + 3) * 2"
+func Normalize(expression string, variables map[string]float64) (string, error) {
+	expr, err := ParseWithVariables(expression, variables)
+	if err != nil {
+		return "", err
+	}
+	return Format(expr), nil
+}
+
+// Format записывает синтаксическое дерево выражением, которое разбирается в то же дерево
+func Format(expr Expr) string {
+	var b strings.Builder
+	writeExpr(&b, expr)
+	return b.String()
+}
+
+func writeExpr(b *strings.Builder, expr Expr) {
+	switch e := expr.(type) {
+	case *Number:
+		b.WriteString(e.Literal)
+	case *Unary:
+		b.WriteString(e.Operator)
+		// -2^2 и так разбирается как -(2^2), а -(1 + 2) без скобок поменяет смысл
+		child, ok := e.Operand.(*Binary)
+		writeOperand(b, e.Operand, ok && child.Operator != "^")
+	case *Binary:
+		writeOperand(b, e.Left, needParens(e, e.Left, false))
+		b.WriteString(" " + e.Operator + " ")
+		writeOperand(b, e.Right, needParens(e, e.Right, true))
+	case *Call:
+		b.WriteString(e.Name + "(")
+		for i, arg := range e.Args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeExpr(b, arg)
+		}
+		b.WriteString(")")
+	}
+}
+
+func writeOperand(b *strings.Builder, expr Expr, parens bool) {
+	if !parens {
+		writeExpr(b, expr)
+		return
+	}
+	b.WriteString("(")
+	writeExpr(b, expr)
+	b.WriteString(")")
+}
+
+// isAtom сообщает, что вершину не нужно брать в скобки ни в каком операнде
+func isAtom(expr Expr) bool {
+	switch e := expr.(type) {
+	case *Call:
+		return true
+	case *Number:
+		return !strings.HasPrefix(e.Literal, "-")
+	default:
+		return false
+	}
+}
+
+// needParens сообщает, нужны ли скобки операнду operand бинарной операции parent,
+// right - operand правый
+func needParens(parent *Binary, operand Expr, right bool) bool {
+	if isAtom(operand) {
+		return false
+	}
+	child, ok := operand.(*Binary)
+	if !ok {
+		// унарный минус или отрицательное число в основании степени: (-2)^2
+		return parent.Operator == "^" && !right
+	}
+	switch {
+	case precedence[child.Operator] != precedence[parent.Operator]:
+		return precedence[child.Operator] < precedence[parent.Operator]
+	case rightAssociative[parent.Operator]:
+		return !right
+	default:
+		return right
+	}
+}
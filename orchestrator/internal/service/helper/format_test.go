@@ -0,0 +1,49 @@
+package helper
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		variables map[string]float64
+		want      string
+	}{
+		{name: "spaces", expr: " 1+2 *3 ", want: "1 + 2 * 3"},
+		{name: "redundant parentheses", expr: "((1+2))*(3*4)", want: "(1 + 2) * (3 * 4)"},
+		{name: "left associative", expr: "8-(4-2)-(1+1)", want: "8 - (4 - 2) - (1 + 1)"},
+		{name: "right associative power", expr: "(2^3)^2+2^(3^2)", want: "(2 ^ 3) ^ 2 + 2 ^ 3 ^ 2"},
+		{name: "unary minus", expr: "-(1+2)*-3-(-2)^2+-2^2", want: "-(1 + 2) * -3 - (-2) ^ 2 + -2 ^ 2"},
+		{name: "functions", expr: "max( 1,2 ,(3))+sqrt((4))", want: "max(1, 2, 3) + sqrt(4)"},
+		{
+			name:      "variables substituted",
+			expr:      "rate*base-shift",
+			variables: map[string]float64{"rate": 0.2, "base": 100, "shift": -5},
+			want:      "0.2 * 100 - -5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.expr, tt.variables)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			// канонический вид разбирается в то же выражение
+			want, err := ToRPN(tt.expr, tt.variables)
+			require.NoError(t, err)
+			rpn, err := ToRPN(got, nil)
+			require.NoError(t, err)
+			require.Equal(t, want, rpn)
+		})
+	}
+}
+
+func TestNormalize_SyntaxError(t *testing.T) {
+	_, err := Normalize("1+", nil)
+	_, ok := AsSyntaxError(err)
+	require.True(t, ok)
+}
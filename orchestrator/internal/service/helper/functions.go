@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"fmt"
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// arity допустимое число аргументов функции, Max < 0 - без ограничения
+type arity struct {
+	Min, Max int
+}
+
+var functions = map[string]arity{
+	"sqrt": {1, 1},
+	"abs":  {1, 1},
+	"sin":  {1, 1},
+	"cos":  {1, 1},
+	// log(x) - натуральный логарифм, log(x, base) - по основанию base
+	"log": {1, 2},
+	"min": {1, -1},
+	"max": {1, -1},
+}
+
+// Functions возвращает имена поддерживаемых функций
+func Functions() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkArity проверяет число аргументов функции, возвращает описание ошибки или пустую строку
+func checkArity(name string, args int) string {
+	a := functions[name]
+	switch {
+	case a.Min == a.Max && args != a.Min:
+		return fmt.Sprintf("function '%s' expects %d argument(s), got %d", name, a.Min, args)
+	case args < a.Min:
+		return fmt.Sprintf("function '%s' expects at least %d argument(s), got %d", name, a.Min, args)
+	case a.Max >= 0 && args > a.Max:
+		return fmt.Sprintf("function '%s' expects at most %d argument(s), got %d", name, a.Max, args)
+	}
+	return ""
+}
+
+// callToken записывает вызов функции в ОПН как имя и число аргументов: max:3
+func callToken(name string, args int) string {
+	return name + ":" + strconv.Itoa(args)
+}
+
+// parseCallToken разбирает вызов функции из ОПН
+func parseCallToken(token string) (string, int, bool) {
+	name, count, found := strings.Cut(token, ":")
+	if !found {
+		return "", 0, false
+	}
+	args, err := strconv.Atoi(count)
+	if _, ok := functions[name]; !ok || err != nil || checkArity(name, args) != "" {
+		return "", 0, false
+	}
+	return name, args, true
+}
+
+// ValidateVariableName проверяет, что имя переменной можно использовать в выражениях:
+// это идентификатор, не совпадающий с именем функции
+func ValidateVariableName(name string) error {
+	tokens, err := Tokenize(name)
+	if err != nil || len(tokens) != 2 || tokens[0].Kind != TokenIdent || tokens[0].Column != 1 {
+		return errors.ErrInvalidVariableName
+	}
+	if _, ok := functions[name]; ok {
+		return errors.ErrInvalidVariableName
+	}
+	return nil
+}
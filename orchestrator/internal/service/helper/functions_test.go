@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestValidateVariableName(t *testing.T) {
+	tests := []struct {
+		name     string
+		variable string
+		want     error
+	}{
+		{name: "simple name", variable: "x", want: nil},
+		{name: "with digits and underscore", variable: "tax_rate2", want: nil},
+		{name: "empty", variable: "", want: errors.ErrInvalidVariableName},
+		{name: "starts with digit", variable: "2x", want: errors.ErrInvalidVariableName},
+		{name: "with spaces", variable: " x", want: errors.ErrInvalidVariableName},
+		{name: "expression", variable: "x+y", want: errors.ErrInvalidVariableName},
+		{name: "function name", variable: "sqrt", want: errors.ErrInvalidVariableName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVariableName(tt.variable)
+			if tt.want != nil {
+				require.ErrorIs(t, err, tt.want)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
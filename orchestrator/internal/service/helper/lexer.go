@@ -0,0 +1,118 @@
+package helper
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// TokenKind тип лексемы выражения
+type TokenKind int
+
+const (
+	TokenEOF TokenKind = iota
+	TokenNumber
+	TokenOperator
+	TokenLParen
+	TokenRParen
+	TokenIdent
+	TokenComma
+)
+
+// Token лексема выражения, Column - номер символа начала лексемы (с 1)
+type Token struct {
+	Kind   TokenKind
+	Value  string
+	Column int
+}
+
+func (t Token) String() string {
+	if t.Kind == TokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s'", t.Value)
+}
+
+// Tokenize разбивает выражение на лексемы, последней всегда идёт TokenEOF
+func Tokenize(expression string) ([]Token, error) {
+	runes := []rune(expression)
+	var tokens []Token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			end, err := scanNumber(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, Token{Kind: TokenNumber, Value: string(runes[i:end]), Column: i + 1})
+			i = end
+		case r == '(':
+			tokens = append(tokens, Token{Kind: TokenLParen, Value: "(", Column: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, Token{Kind: TokenRParen, Value: ")", Column: i + 1})
+			i++
+		case r == ',':
+			tokens = append(tokens, Token{Kind: TokenComma, Value: ",", Column: i + 1})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, Token{Kind: TokenIdent, Value: string(runes[i:end]), Column: i + 1})
+			i = end
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			tokens = append(tokens, Token{Kind: TokenOperator, Value: "//", Column: i + 1})
+			i += 2
+		default:
+			if _, ok := precedence[string(r)]; !ok {
+				return nil, &SyntaxError{Column: i + 1, Message: fmt.Sprintf("unexpected character '%c'", r)}
+			}
+			tokens = append(tokens, Token{Kind: TokenOperator, Value: string(r), Column: i + 1})
+			i++
+		}
+	}
+	return append(tokens, Token{Kind: TokenEOF, Column: len(runes) + 1}), nil
+}
+
+// scanNumber читает число вида 12, 1.5, .5, 2., 1e-3 начиная с позиции start
+// и возвращает позицию сразу после него
+func scanNumber(runes []rune, start int) (int, error) {
+	i := start
+	digits := 0
+	for i < len(runes) && unicode.IsDigit(runes[i]) {
+		i++
+		digits++
+	}
+	if i < len(runes) && runes[i] == '.' {
+		i++
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+			digits++
+		}
+	}
+	if digits == 0 {
+		return 0, &SyntaxError{Column: start + 1, Message: "unexpected character '.'"}
+	}
+	if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+		i++
+		if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+			i++
+		}
+		exponent := 0
+		for i < len(runes) && unicode.IsDigit(runes[i]) {
+			i++
+			exponent++
+		}
+		if exponent == 0 {
+			return 0, &SyntaxError{
+				Column:  start + 1,
+				Message: fmt.Sprintf("malformed number '%s'", string(runes[start:i])),
+			}
+		}
+	}
+	return i, nil
+}
@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens, err := Tokenize(" 1.5e-3*(.5 - 2.)")
+	require.NoError(t, err)
+	require.Equal(t, []Token{
+		{Kind: TokenNumber, Value: "1.5e-3", Column: 2},
+		{Kind: TokenOperator, Value: "*", Column: 8},
+		{Kind: TokenLParen, Value: "(", Column: 9},
+		{Kind: TokenNumber, Value: ".5", Column: 10},
+		{Kind: TokenOperator, Value: "-", Column: 13},
+		{Kind: TokenNumber, Value: "2.", Column: 15},
+		{Kind: TokenRParen, Value: ")", Column: 17},
+		{Kind: TokenEOF, Column: 18},
+	}, tokens)
+}
+
+func TestTokenize_Operators(t *testing.T) {
+	tokens, err := Tokenize("7//2%3^2/1")
+	require.NoError(t, err)
+
+	var operators []string
+	for _, token := range tokens {
+		if token.Kind == TokenOperator {
+			operators = append(operators, token.Value)
+		}
+	}
+	require.Equal(t, []string{"//", "%", "^", "/"}, operators)
+}
@@ -0,0 +1,272 @@
+package helper
+
+import (
+	stderrors "errors"
+	"fmt"
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"strconv"
+)
+
+// SyntaxError ошибка разбора выражения с номером символа (с 1), на котором она найдена
+type SyntaxError struct {
+	Column  int
+	Message string
+	// Reason уточняет причину, если она есть среди общих ошибок, например деление на ноль
+	Reason error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s at column %d", errors.ErrInvalidExpression, e.Message, e.Column)
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, errors.ErrInvalidExpression)
+func (e *SyntaxError) Unwrap() []error {
+	if e.Reason != nil {
+		return []error{errors.ErrInvalidExpression, e.Reason}
+	}
+	return []error{errors.ErrInvalidExpression}
+}
+
+// AsSyntaxError достаёт SyntaxError из цепочки ошибок
+func AsSyntaxError(err error) (*SyntaxError, bool) {
+	var syntaxErr *SyntaxError
+	ok := stderrors.As(err, &syntaxErr)
+	return syntaxErr, ok
+}
+
+// Expr вершина синтаксического дерева выражения
+type Expr interface {
+	// Pos номер символа (с 1), с которого начинается вершина
+	Pos() int
+}
+
+// Number числовая константа
+type Number struct {
+	Value   float64
+	Literal string
+	Column  int
+}
+
+// Unary унарный плюс или минус
+type Unary struct {
+	Operator string
+	Operand  Expr
+	Column   int
+}
+
+// Binary бинарная операция, Column - позиция оператора
+type Binary struct {
+	Operator string
+	Left     Expr
+	Right    Expr
+	Column   int
+}
+
+// Call вызов функции
+type Call struct {
+	Name   string
+	Args   []Expr
+	Column int
+}
+
+func (n *Number) Pos() int { return n.Column }
+func (u *Unary) Pos() int  { return u.Column }
+func (b *Binary) Pos() int { return b.Left.Pos() }
+func (c *Call) Pos() int   { return c.Column }
+
+// Parse разбирает выражение методом рекурсивного спуска:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%" | "//") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | variable | call | "(" expr ")"
+//	call    = name "(" expr { "," expr } ")"
+func Parse(expression string) (Expr, error) {
+	return ParseWithVariables(expression, nil)
+}
+
+// ParseWithVariables разбирает выражение, подставляя значения переменных пользователя
+// вместо их имён
+func ParseWithVariables(expression string, variables map[string]float64) (Expr, error) {
+	tokens, err := Tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, variables: variables}
+	if p.peek().Kind == TokenEOF {
+		return nil, &SyntaxError{Column: 1, Message: "empty expression"}
+	}
+
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.Kind != TokenEOF {
+		if tok.Kind == TokenRParen {
+			return nil, &SyntaxError{Column: tok.Column, Message: "unexpected ')' without matching '('"}
+		}
+		return nil, &SyntaxError{Column: tok.Column, Message: fmt.Sprintf("unexpected %s, expected operator", tok)}
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens    []Token
+	pos       int
+	variables map[string]float64
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != TokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseBinary разбирает цепочку операций одного приоритета. Правым операндом
+// правоассоциативной операции становится вся оставшаяся цепочка, разобранная rightOperand
+func (p *parser) parseBinary(level int, operand, rightOperand func() (Expr, error)) (Expr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.Kind != TokenOperator || precedence[tok.Value] != level {
+			return left, nil
+		}
+		p.next()
+		var right Expr
+		if rightAssociative[tok.Value] {
+			right, err = rightOperand()
+		} else {
+			right, err = operand()
+		}
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Operator: tok.Value, Left: left, Right: right, Column: tok.Column}
+	}
+}
+
+func (p *parser) parseExpr() (Expr, error) {
+	return p.parseBinary(precedence["+"], p.parseTerm, p.parseTerm)
+}
+
+func (p *parser) parseTerm() (Expr, error) {
+	return p.parseBinary(precedence["*"], p.parseUnary, p.parseUnary)
+}
+
+// parsePower разбирает возведение в степень, показатель может быть со знаком: 2^-1
+func (p *parser) parsePower() (Expr, error) {
+	return p.parseBinary(precedence["^"], p.parsePrimary, p.parseUnary)
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	if tok.Kind == TokenOperator && (tok.Value == "+" || tok.Value == "-") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Operator: tok.Value, Operand: operand, Column: tok.Column}, nil
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.Kind {
+	case TokenNumber:
+		value, err := strconv.ParseFloat(tok.Value, 64)
+		if err != nil {
+			return nil, &SyntaxError{Column: tok.Column, Message: fmt.Sprintf("number '%s' is out of range", tok.Value)}
+		}
+		return &Number{Value: value, Literal: tok.Value, Column: tok.Column}, nil
+	case TokenLParen:
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		switch next := p.peek(); next.Kind {
+		case TokenRParen:
+		case TokenEOF:
+			return nil, &SyntaxError{Column: tok.Column, Message: "missing ')' for '('"}
+		default:
+			return nil, &SyntaxError{Column: next.Column, Message: fmt.Sprintf("unexpected %s, expected operator or ')'", next)}
+		}
+		p.next()
+		return expr, nil
+	case TokenIdent:
+		if _, ok := functions[tok.Value]; ok {
+			return p.parseCall(tok)
+		}
+		return p.parseVariable(tok)
+	default:
+		return nil, &SyntaxError{Column: tok.Column, Message: fmt.Sprintf("unexpected %s, expected number or '('", tok)}
+	}
+}
+
+func (p *parser) parseVariable(name Token) (Expr, error) {
+	if p.peek().Kind == TokenLParen {
+		return nil, &SyntaxError{Column: name.Column, Message: fmt.Sprintf("unknown function '%s'", name.Value)}
+	}
+	value, ok := p.variables[name.Value]
+	if !ok {
+		return nil, &SyntaxError{Column: name.Column, Message: fmt.Sprintf("undefined variable '%s'", name.Value)}
+	}
+	return &Number{Value: value, Literal: strconv.FormatFloat(value, 'g', -1, 64), Column: name.Column}, nil
+}
+
+func (p *parser) parseCall(name Token) (Expr, error) {
+	if tok := p.peek(); tok.Kind != TokenLParen {
+		return nil, &SyntaxError{Column: tok.Column, Message: fmt.Sprintf("unexpected %s, expected '(' after function name", tok)}
+	}
+	p.next()
+
+	call := &Call{Name: name.Value, Column: name.Column}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+
+		switch tok := p.next(); tok.Kind {
+		case TokenComma:
+			continue
+		case TokenRParen:
+			if msg := checkArity(call.Name, len(call.Args)); msg != "" {
+				return nil, &SyntaxError{Column: name.Column, Message: msg}
+			}
+			return call, nil
+		case TokenEOF:
+			return nil, &SyntaxError{Column: name.Column, Message: fmt.Sprintf("missing ')' for function '%s'", name.Value)}
+		default:
+			return nil, &SyntaxError{Column: tok.Column, Message: fmt.Sprintf("unexpected %s, expected ',' or ')'", tok)}
+		}
+	}
+}
+
+// constant возвращает значение вершины, если это число или число с унарными знаками
+func constant(expr Expr) (float64, bool) {
+	switch e := expr.(type) {
+	case *Number:
+		return e.Value, true
+	case *Unary:
+		value, ok := constant(e.Operand)
+		if e.Operator == "-" {
+			value = -value
+		}
+		return value, ok
+	default:
+		return 0, false
+	}
+}
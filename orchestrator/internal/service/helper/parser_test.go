@@ -0,0 +1,187 @@
+package helper
+
+import (
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	expr, err := Parse("1 - -2*3")
+	require.NoError(t, err)
+	require.Equal(t, &Binary{
+		Operator: "-",
+		Left:     &Number{Value: 1, Literal: "1", Column: 1},
+		Right: &Binary{
+			Operator: "*",
+			Left: &Unary{
+				Operator: "-",
+				Operand:  &Number{Value: 2, Literal: "2", Column: 6},
+				Column:   5,
+			},
+			Right:  &Number{Value: 3, Literal: "3", Column: 8},
+			Column: 7,
+		},
+		Column: 3,
+	}, expr)
+}
+
+func TestParse_Call(t *testing.T) {
+	expr, err := Parse("min(1, x2())")
+	require.Nil(t, expr)
+	syntaxErr, ok := AsSyntaxError(err)
+	require.True(t, ok)
+	require.Equal(t, 8, syntaxErr.Column)
+
+	expr, err = Parse("max(1, 2)")
+	require.NoError(t, err)
+	require.Equal(t, &Call{
+		Name: "max",
+		Args: []Expr{
+			&Number{Value: 1, Literal: "1", Column: 5},
+			&Number{Value: 2, Literal: "2", Column: 8},
+		},
+		Column: 1,
+	}, expr)
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		name        string
+		expr        string
+		wantColumn  int
+		wantMessage string
+	}{
+		{
+			name:        "empty expression",
+			expr:        "  ",
+			wantColumn:  1,
+			wantMessage: "empty expression",
+		},
+		{
+			name:        "unexpected character",
+			expr:        "2+2=4",
+			wantColumn:  4,
+			wantMessage: "unexpected character '='",
+		},
+		{
+			name:        "implicit multiplication",
+			expr:        "2(3)",
+			wantColumn:  2,
+			wantMessage: "unexpected '(', expected operator",
+		},
+		{
+			name:        "operator at end",
+			expr:        "2 + ",
+			wantColumn:  5,
+			wantMessage: "unexpected end of expression, expected number or '('",
+		},
+		{
+			name:        "two operators in a row",
+			expr:        "2*/2",
+			wantColumn:  3,
+			wantMessage: "unexpected '/', expected number or '('",
+		},
+		{
+			name:        "three slashes",
+			expr:        "6///2",
+			wantColumn:  4,
+			wantMessage: "unexpected '/', expected number or '('",
+		},
+		{
+			name:        "missing exponent",
+			expr:        "2^",
+			wantColumn:  3,
+			wantMessage: "unexpected end of expression, expected number or '('",
+		},
+		{
+			name:        "unknown function",
+			expr:        "1+tan(1)",
+			wantColumn:  3,
+			wantMessage: "unknown function 'tan'",
+		},
+		{
+			name:        "undefined variable",
+			expr:        "2 * radius",
+			wantColumn:  5,
+			wantMessage: "undefined variable 'radius'",
+		},
+		{
+			name:        "function without brackets",
+			expr:        "sqrt 4",
+			wantColumn:  6,
+			wantMessage: "unexpected '4', expected '(' after function name",
+		},
+		{
+			name:        "wrong number of arguments",
+			expr:        "2*sqrt(4, 9)",
+			wantColumn:  3,
+			wantMessage: "function 'sqrt' expects 1 argument(s), got 2",
+		},
+		{
+			name:        "too many log arguments",
+			expr:        "log(1, 2, 3)",
+			wantColumn:  1,
+			wantMessage: "function 'log' expects at most 2 argument(s), got 3",
+		},
+		{
+			name:        "empty argument list",
+			expr:        "max()",
+			wantColumn:  5,
+			wantMessage: "unexpected ')', expected number or '('",
+		},
+		{
+			name:        "unclosed function call",
+			expr:        "min(1, 2",
+			wantColumn:  1,
+			wantMessage: "missing ')' for function 'min'",
+		},
+		{
+			name:        "unclosed bracket",
+			expr:        "1+(2*3",
+			wantColumn:  3,
+			wantMessage: "missing ')' for '('",
+		},
+		{
+			name:        "number inside brackets without operator",
+			expr:        "(2 3)",
+			wantColumn:  4,
+			wantMessage: "unexpected '3', expected operator or ')'",
+		},
+		{
+			name:        "extra closing bracket",
+			expr:        "(1+2))",
+			wantColumn:  6,
+			wantMessage: "unexpected ')' without matching '('",
+		},
+		{
+			name:        "excess dot",
+			expr:        "2.0.1+3",
+			wantColumn:  4,
+			wantMessage: "unexpected '.1', expected operator",
+		},
+		{
+			name:        "malformed exponent",
+			expr:        "1+2e",
+			wantColumn:  3,
+			wantMessage: "malformed number '2e'",
+		},
+		{
+			name:        "number out of range",
+			expr:        "1e999",
+			wantColumn:  1,
+			wantMessage: "number '1e999' is out of range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			require.ErrorIs(t, err, errors.ErrInvalidExpression)
+
+			syntaxErr, ok := AsSyntaxError(err)
+			require.True(t, ok)
+			require.Equal(t, tt.wantColumn, syntaxErr.Column)
+			require.Equal(t, tt.wantMessage, syntaxErr.Message)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"time"
+)
+
+// Plan оценка вычисления графа, если агентов хватает на все готовые задачи
+type Plan struct {
+	// Start самое раннее начало задачи каждой вершины, индекс - ID вершины
+	Start []time.Duration
+	// CriticalPath самая долгая цепочка зависимых задач от первой задачи к корню,
+	// CriticalTime - её время: быстрее выражение не посчитать при любом числе агентов
+	CriticalPath []*Node
+	CriticalTime time.Duration
+}
+
+// isTask сообщает, что вершину ещё нужно отправить агенту
+func (n *Node) isTask() bool {
+	return !n.Done && len(n.Args) > 0
+}
+
+// Plan оценивает вычисление графа без ограничения на число агентов,
+// duration - сколько вычисляется задача вершины
+func (d *DAG) Plan(duration func(*Node) time.Duration) Plan {
+	plan := Plan{Start: make([]time.Duration, len(d.Nodes))}
+	finish := make([]time.Duration, len(d.Nodes))
+	// вершины в графе идут в порядке ОПН, поэтому операнды посчитаны раньше своей операции
+	for _, node := range d.Nodes {
+		if !node.isTask() {
+			continue
+		}
+		for _, arg := range node.Args {
+			plan.Start[node.ID] = max(plan.Start[node.ID], finish[arg.ID])
+		}
+		finish[node.ID] = plan.Start[node.ID] + duration(node)
+	}
+
+	for node := d.Root; node != nil && node.isTask(); {
+		plan.CriticalPath = append([]*Node{node}, plan.CriticalPath...)
+		var longest *Node
+		for _, arg := range node.Args {
+			if arg.isTask() && (longest == nil || finish[arg.ID] > finish[longest.ID]) {
+				longest = arg
+			}
+		}
+		node = longest
+	}
+	if d.Root.isTask() {
+		plan.CriticalTime = finish[d.Root.ID]
+	}
+	return plan
+}
+
+// EstimateTime моделирует вычисление графа slots агентами так, как его раздаёт оркестратор:
+// готовые задачи выдаются по порядку, как только освобождается агент. Кэш результатов не учитывается
+func (d *DAG) EstimateTime(duration func(*Node) time.Duration, slots int) time.Duration {
+	type running struct {
+		node *Node
+		end  time.Duration
+	}
+	done := make(map[int]bool)
+	ready := func(node *Node) bool {
+		for _, arg := range node.Args {
+			if arg.isTask() && !done[arg.ID] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var queue []*Node
+	for _, node := range d.Nodes {
+		if node.isTask() && ready(node) {
+			queue = append(queue, node)
+		}
+	}
+	var now time.Duration
+	var computing []running
+	for len(queue) > 0 || len(computing) > 0 {
+		for len(queue) > 0 && len(computing) < max(slots, 1) {
+			computing = append(computing, running{node: queue[0], end: now + duration(queue[0])})
+			queue = queue[1:]
+		}
+		first := 0
+		for i, task := range computing {
+			if task.end < computing[first].end {
+				first = i
+			}
+		}
+		task := computing[first]
+		computing = append(computing[:first], computing[first+1:]...)
+		now = task.end
+		done[task.node.ID] = true
+		if parent := task.node.Parent; parent != nil && parent.isTask() && ready(parent) {
+			queue = append(queue, parent)
+		}
+	}
+	return now
+}
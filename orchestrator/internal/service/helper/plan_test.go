@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// testDurations время операций для оценки графа 1 + 2 * 3 - sqrt(4)
+var testDurations = map[string]time.Duration{
+	"+": 100 * time.Millisecond, "-": 100 * time.Millisecond,
+	"*": 300 * time.Millisecond, "sqrt": 500 * time.Millisecond,
+}
+
+func testDuration(n *Node) time.Duration {
+	return testDurations[n.Operation]
+}
+
+func TestDAG_Plan(t *testing.T) {
+	dag, err := BuildDAG([]string{"1", "2", "3", "*", "+", "4", "sqrt:1", "-"})
+	require.NoError(t, err)
+
+	plan := dag.Plan(testDuration)
+
+	// сложение ждёт умножения, вычитание - сложения и корня
+	require.Equal(t, 300*time.Millisecond, plan.Start[4])
+	require.Equal(t, time.Duration(0), plan.Start[6])
+	require.Equal(t, 500*time.Millisecond, plan.Start[7])
+	var path []string
+	for _, node := range plan.CriticalPath {
+		path = append(path, node.Operation)
+	}
+	require.Equal(t, []string{"sqrt", "-"}, path)
+	require.Equal(t, 600*time.Millisecond, plan.CriticalTime)
+}
+
+func TestDAG_Plan_Constant(t *testing.T) {
+	dag, err := BuildDAG([]string{"42"})
+	require.NoError(t, err)
+
+	plan := dag.Plan(testDuration)
+
+	require.Empty(t, plan.CriticalPath)
+	require.Zero(t, plan.CriticalTime)
+}
+
+func TestDAG_EstimateTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		slots int
+		want  time.Duration
+	}{
+		{name: "one agent computes tasks one by one", slots: 1, want: time.Second},
+		{name: "two agents reach the critical path", slots: 2, want: 600 * time.Millisecond},
+		{name: "more agents do not help", slots: 8, want: 600 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dag, err := BuildDAG([]string{"1", "2", "3", "*", "+", "4", "sqrt:1", "-"})
+			require.NoError(t, err)
+
+			require.Equal(t, tt.want, dag.EstimateTime(testDuration, tt.slots))
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package helper
+
+import (
+	"github.com/jaam8/web_calculator/common-lib/decimal"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// ToSimplifiedRPN преобразует выражение в ОПН, как ToRPN, но сначала упрощает его через Simplify.
+// Возвращает и количество задач, которые не придётся отправлять агентам благодаря упрощению
+func ToSimplifiedRPN(expression string, variables map[string]float64, decimal bool) ([]string, int, error) {
+	expr, err := ParseWithVariables(expression, variables)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = validate(expr); err != nil {
+		return nil, 0, err
+	}
+	rpn := appendRPN(nil, Simplify(expr, decimal))
+	return rpn, countTasks(appendRPN(nil, expr)) - countTasks(rpn), nil
+}
+
+// countTasks возвращает количество операций в ОПН - столько задач получат агенты
+func countTasks(rpn []string) int {
+	tasks := 0
+	for _, token := range rpn {
+		if _, err := strconv.ParseFloat(token, 64); err != nil {
+			tasks++
+		}
+	}
+	return tasks
+}
+
+// Simplify упрощает выражение до построения графа задач: операторы над константами вычисляются
+// сразу (функции дороже и остаются задачами агентов), а x * 1, 1 * x, x - 0, x ^ 1 и -(-x)
+// заменяются на x. Результат всегда совпадает с тем, что посчитали бы агенты, в том числе NaN
+// и ±Inf. Поэтому 0 * x заменяется нулём, только если по границам x (см. bounds) известно, что x
+// конечно, вычисляется без ошибки и не равно нулю - знак нуля в произведении зависит от знака x,
+// а 0 * Inf = NaN. x + 0 упрощается только в десятичной арифметике (-0 + 0 = +0).
+// Операции, на которых агент вернёт ошибку, например деление на ноль, остаются задачами,
+// чтобы выражение завершилось с той же ошибкой.
+// decimal - выражение вычисляется в десятичной арифметике: константы сворачиваются точно,
+// как их посчитал бы агент через пакет decimal, не упрощается x / 1, потому что частное
+// округляется, и 0 * x, потому что агент проверяет, что каждое значение x помещается
+// в decimal.MaxDigits цифр, и x может завершиться ошибкой
+func Simplify(expr Expr, decimal bool) Expr {
+	switch e := expr.(type) {
+	case *Unary:
+		operand := Simplify(e.Operand, decimal)
+		if e.Operator == "+" {
+			return operand
+		}
+		if inner, ok := operand.(*Unary); ok && inner.Operator == "-" {
+			return inner.Operand
+		}
+		return &Unary{Operator: e.Operator, Operand: operand, Column: e.Column}
+	case *Binary:
+		left, right := Simplify(e.Left, decimal), Simplify(e.Right, decimal)
+		fold := fold
+		if decimal {
+			fold = foldDecimal
+		}
+		if folded, ok := fold(e.Operator, left, right, e.Pos()); ok {
+			return folded
+		}
+		if simplified, ok := identity(e.Operator, left, right, decimal); ok {
+			return simplified
+		}
+		return &Binary{Operator: e.Operator, Left: left, Right: right, Column: e.Column}
+	case *Call:
+		args := make([]Expr, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = Simplify(arg, decimal)
+		}
+		return &Call{Name: e.Name, Args: args, Column: e.Column}
+	default:
+		return expr
+	}
+}
+
+// fold вычисляет оператор, если оба его операнда - константы
+func fold(operator string, left, right Expr, column int) (Expr, bool) {
+	leftValue, leftConst := constant(left)
+	rightValue, rightConst := constant(right)
+	if !leftConst || !rightConst {
+		return nil, false
+	}
+	value, ok := evaluate(operator, leftValue, rightValue)
+	if !ok {
+		return nil, false
+	}
+	return &Number{Value: value, Literal: strconv.FormatFloat(value, 'g', -1, 64), Column: column}, true
+}
+
+// foldDecimal вычисляет оператор над константами точно, как DoDecimalTask агента.
+// Результат, который не помещается в float64, остаётся задачей: граф задач не примет такую константу
+func foldDecimal(operator string, left, right Expr, column int) (Expr, bool) {
+	leftLiteral, leftConst := constantLiteral(left)
+	rightLiteral, rightConst := constantLiteral(right)
+	if !leftConst || !rightConst {
+		return nil, false
+	}
+	leftValue, err := decimal.Parse(leftLiteral)
+	if err != nil {
+		return nil, false
+	}
+	rightValue, err := decimal.Parse(rightLiteral)
+	if err != nil {
+		return nil, false
+	}
+	result, ok := evaluateDecimal(operator, leftValue, rightValue)
+	if !ok {
+		return nil, false
+	}
+	literal := result.String()
+	value, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &Number{Value: value, Literal: literal, Column: column}, true
+}
+
+// identity упрощает бинарную операцию с нейтральным операндом. Константный операнд не упрощается:
+// в десятичной арифметике его запись может отличаться от результата агента, например 2.50 и 2.5
+func identity(operator string, left, right Expr, decimal bool) (Expr, bool) {
+	leftValue, leftConst := constant(left)
+	rightValue, rightConst := constant(right)
+	if leftConst == rightConst {
+		return nil, false
+	}
+	if rightConst {
+		switch {
+		case rightValue == 1 && (operator == "*" || operator == "^" || operator == "/" && !decimal):
+			return left, true
+		// x - (-0) = x + 0, а -0 + 0 = +0
+		case rightValue == 0 && (operator == "-" && !math.Signbit(rightValue) || operator == "+" && decimal):
+			return left, true
+		case rightValue == 0 && operator == "*" && !decimal:
+			return zeroProduct(rightValue, left)
+		}
+		return nil, false
+	}
+	switch {
+	case leftValue == 1 && operator == "*":
+		return right, true
+	case leftValue == 0 && operator == "+" && decimal:
+		return right, true
+	case leftValue == 0 && operator == "*" && !decimal:
+		return zeroProduct(leftValue, right)
+	}
+	return nil, false
+}
+
+// zeroProduct заменяет zero * x нулём со знаком, который получил бы агент, если x конечно,
+// вычисляется без ошибки и его знак известен
+func zeroProduct(zero float64, x Expr) (Expr, bool) {
+	lo, hi, ok := bounds(x)
+	if !ok || lo <= 0 && hi >= 0 {
+		return nil, false
+	}
+	value := math.Copysign(0, zero)
+	if hi < 0 {
+		value = -value
+	}
+	return &Number{Value: value, Literal: strconv.FormatFloat(value, 'g', -1, 64), Column: x.Pos()}, true
+}
+
+// bounds возвращает границы, между которыми окажется значение выражения у агентов, если оно
+// гарантированно конечно и вычисляется без ошибки. Округление float64 монотонно, поэтому
+// границы, посчитанные теми же операциями, не сужают настоящее значение. Деление, остаток
+// и степени, а также функции, которые могут завершиться ошибкой, не оцениваются
+func bounds(expr Expr) (float64, float64, bool) {
+	switch e := expr.(type) {
+	case *Number:
+		return e.Value, e.Value, !math.IsInf(e.Value, 0) && !math.IsNaN(e.Value)
+	case *Unary:
+		lo, hi, ok := bounds(e.Operand)
+		if e.Operator == "-" {
+			lo, hi = -hi, -lo
+		}
+		return lo, hi, ok
+	case *Binary:
+		leftLo, leftHi, leftOk := bounds(e.Left)
+		rightLo, rightHi, rightOk := bounds(e.Right)
+		if !leftOk || !rightOk {
+			return 0, 0, false
+		}
+		var lo, hi float64
+		switch e.Operator {
+		case "+":
+			lo, hi = leftLo+rightLo, leftHi+rightHi
+		case "-":
+			lo, hi = leftLo-rightHi, leftHi-rightLo
+		case "*":
+			products := []float64{leftLo * rightLo, leftLo * rightHi, leftHi * rightLo, leftHi * rightHi}
+			lo, hi = slices.Min(products), slices.Max(products)
+		default:
+			return 0, 0, false
+		}
+		return lo, hi, !math.IsInf(lo, 0) && !math.IsInf(hi, 0)
+	case *Call:
+		los, his := make([]float64, len(e.Args)), make([]float64, len(e.Args))
+		for i, arg := range e.Args {
+			lo, hi, ok := bounds(arg)
+			if !ok {
+				return 0, 0, false
+			}
+			los[i], his[i] = lo, hi
+		}
+		switch {
+		case e.Name == "sin" || e.Name == "cos":
+			return -1, 1, true
+		case e.Name == "abs" && los[0] >= 0:
+			return los[0], his[0], true
+		case e.Name == "abs" && his[0] <= 0:
+			return -his[0], -los[0], true
+		case e.Name == "abs":
+			return 0, max(-los[0], his[0]), true
+		case e.Name == "sqrt" && los[0] >= 0:
+			return math.Sqrt(los[0]), math.Sqrt(his[0]), true
+		case e.Name == "min" && len(e.Args) > 0:
+			return slices.Min(los), slices.Min(his), true
+		case e.Name == "max" && len(e.Args) > 0:
+			return slices.Max(los), slices.Max(his), true
+		}
+		return 0, 0, false
+	default:
+		return 0, 0, false
+	}
+}
+
+// evaluateDecimal вычисляет оператор над константами так же, как DoDecimalTask агента. false -
+// агент вернёт ошибку или посчитает операцию в float64, тогда операция остаётся задачей
+func evaluateDecimal(operator string, left, right decimal.Decimal) (decimal.Decimal, bool) {
+	var result decimal.Decimal
+	switch operator {
+	case "+":
+		result = left.Add(right)
+	case "-":
+		result = left.Sub(right)
+	case "*":
+		result = left.Mul(right)
+	case "/", "%", "//":
+		if right.Sign() == 0 {
+			return decimal.Decimal{}, false
+		}
+		switch operator {
+		case "/":
+			result = left.Div(right)
+		case "%":
+			result = left.Mod(right)
+		default:
+			result = left.FloorDiv(right)
+		}
+	case "^":
+		exponent, ok := right.Int()
+		if !ok || exponent > decimal.MaxExponent || exponent < -decimal.MaxExponent ||
+			exponent < 0 && left.Sign() == 0 || !left.PowFits(exponent) {
+			return decimal.Decimal{}, false
+		}
+		result = left.Pow(exponent)
+	default:
+		return decimal.Decimal{}, false
+	}
+	return result, result.Fits()
+}
+
+// evaluate вычисляет оператор над константами так же, как агент. false - агент вернёт ошибку,
+// тогда операция остаётся задачей
+func evaluate(operator string, left, right float64) (float64, bool) {
+	switch operator {
+	case "+":
+		return left + right, true
+	case "-":
+		return left - right, true
+	case "*":
+		return left * right, true
+	case "/":
+		return left / right, right != 0
+	case "^":
+		return math.Pow(left, right), true
+	case "%":
+		return math.Mod(left, right), right != 0
+	case "//":
+		return math.Floor(left / right), right != 0
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestToSimplifiedRPN(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		variables map[string]float64
+		decimal   bool
+		want      []string
+		wantSaved int
+		wantErr   error
+	}{
+		{name: "constants folded", expr: "2+3*4", want: []string{"14"}, wantSaved: 2},
+		{
+			name:      "variables folded",
+			expr:      "x*1+y",
+			variables: map[string]float64{"x": 5, "y": 0.5},
+			want:      []string{"5.5"},
+			wantSaved: 2,
+		},
+		{name: "functions stay tasks", expr: "sqrt(2+2)*1", want: []string{"4", "sqrt:1"}, wantSaved: 2},
+		{name: "identities", expr: "1*abs(1)^1/1-0", want: []string{"1", "abs:1"}, wantSaved: 4},
+		{name: "double negation", expr: "-(-sqrt(4))", want: []string{"4", "sqrt:1"}, wantSaved: 2},
+		{name: "zero times finite subtree", expr: "0*sqrt(4)", want: []string{"0"}, wantSaved: 2},
+		{
+			name:      "zero times bounded subtrees",
+			expr:      "0*(sin(3)+2)*max(1,cos(2))",
+			want:      []string{"0"},
+			wantSaved: 6,
+		},
+		{name: "negative subtree times zero", expr: "(0-sqrt(4))*0", want: []string{"-0"}, wantSaved: 3},
+		{name: "zero times subtree of unknown sign kept", expr: "0*sin(4)", want: []string{"0", "4", "sin:1", "*"}},
+		// 4-5 сворачивается, а sqrt(-1) завершится у агента ошибкой
+		{name: "zero times failing subtree kept", expr: "sqrt(4-5)*0", want: []string{"-1", "sqrt:1", "0", "*"}, wantSaved: 1},
+		{name: "zero times unbounded subtree kept", expr: "0*(1/sin(4))", want: []string{"0", "1", "4", "sin:1", "/", "*"}},
+		{name: "plus zero kept", expr: "sqrt(4)+0", want: []string{"4", "sqrt:1", "0", "+"}},
+		{name: "minus negative zero kept", expr: "sqrt(4)-(-0)", want: []string{"4", "sqrt:1", "-0", "-"}},
+		{name: "infinities folded as IEEE", expr: "10^400-10^400", want: []string{"NaN"}, wantSaved: 3},
+		{
+			name:      "division by zero left to agent",
+			expr:      "1/(2-2)",
+			want:      []string{"1", "0", "/"},
+			wantSaved: 1,
+		},
+		{
+			name:      "decimal folds constants exactly",
+			expr:      "0.1+0.2+sqrt(4)*1+0",
+			decimal:   true,
+			want:      []string{"0.3", "4", "sqrt:1", "+"},
+			wantSaved: 3,
+		},
+		{name: "decimal integer power", expr: "2^-2*3.00", decimal: true, want: []string{"0.75"}, wantSaved: 2},
+		{
+			name:      "decimal long literal",
+			expr:      "-1.000000000000000000001*10",
+			decimal:   true,
+			want:      []string{"-10.00000000000000000001"},
+			wantSaved: 1,
+		},
+		{name: "decimal fractional power kept", expr: "2^0.5", decimal: true, want: []string{"2", "0.5", "^"}},
+		{name: "decimal result beyond float64 kept", expr: "10^400", decimal: true, want: []string{"10", "400", "^"}},
+		{
+			name:      "decimal division by zero left to agent",
+			expr:      "1/(2-2)",
+			decimal:   true,
+			want:      []string{"1", "0", "/"},
+			wantSaved: 1,
+		},
+		{name: "decimal keeps zero times subtree", expr: "0*sqrt(4)", decimal: true, want: []string{"0", "4", "sqrt:1", "*"}},
+		{name: "decimal keeps division by one", expr: "sqrt(4)/1", decimal: true, want: []string{"4", "sqrt:1", "1", "/"}},
+		{name: "division by constant zero", expr: "1/0", wantErr: errors.ErrDivideByZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, saved, err := ToSimplifiedRPN(tt.expr, tt.variables, tt.decimal)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantSaved, saved)
+		})
+	}
+}
@@ -2,66 +2,47 @@ package helper
 
 import (
 	"github.com/jaam8/web_calculator/common-lib/errors"
-	"regexp"
-	"strings"
 )
 
 // ValidateExpression валидирует выражение
-func ValidateExpression(expr string) error {
-	// Удаляем пробелы
-	expr = strings.ReplaceAll(expr, " ", "")
-	if expr == "" {
-		return errors.ErrInvalidExpression
-	}
-
-	// Регулярка для проверки допустимых символов (цифры, операторы, скобки)
-	validExpr := regexp.MustCompile(`^[0-9+\-*/().\s]+$`)
-	if !validExpr.MatchString(expr) {
-		return errors.ErrInvalidExpression
-	}
-
-	// Проверка сбалансированности скобок
-	if !isBracketsBalanced(expr) {
-		return errors.ErrInvalidExpression
-	}
-
-	// Проверка, что не начинается/заканчивается оператором
-	if strings.HasPrefix(expr, "+") || strings.HasPrefix(expr, "-") || strings.HasPrefix(expr, "*") || strings.HasPrefix(expr, "/") ||
-		strings.HasSuffix(expr, "+") || strings.HasSuffix(expr, "-") || strings.HasSuffix(expr, "*") || strings.HasSuffix(expr, "/") {
-		return errors.ErrInvalidExpression
-	}
-
-	// Проверка, что нет двух операторов подряд
-	if match, _ := regexp.MatchString(`[+\-*/]{2,}`, expr); match {
-		return errors.ErrInvalidExpression
-	}
-
-	if match, _ := regexp.MatchString(`\d*\.\d*\.\d*`, expr); match {
-		return errors.ErrInvalidExpression
-	}
-
-	// Ищем выражения вида "/0" или "/0.0"
-	divByZero := regexp.MustCompile(`/\s*0(\.0+)?\s*$`)
-	if divByZero.MatchString(expr) {
-		return errors.ErrDivideByZero
+func ValidateExpression(expression string) error {
+	expr, err := Parse(expression)
+	if err != nil {
+		return err
 	}
+	return validate(expr)
+}
 
-	return nil
+// divisions операции, для которых ноль в правом операнде означает деление на ноль
+var divisions = map[string]bool{
+	"/": true, "%": true, "//": true,
 }
 
-// isBracketsBalanced проверяет баланс круглых скобок
-func isBracketsBalanced(expr string) bool {
-	count := 0
-	for _, ch := range expr {
-		switch ch {
-		case '(':
-			count++
-		case ')':
-			count--
-			if count < 0 {
-				return false
+// validate проверяет разобранное выражение на деление на константный ноль
+func validate(expr Expr) error {
+	switch e := expr.(type) {
+	case *Unary:
+		return validate(e.Operand)
+	case *Call:
+		for _, arg := range e.Args {
+			if err := validate(arg); err != nil {
+				return err
+			}
+		}
+	case *Binary:
+		if err := validate(e.Left); err != nil {
+			return err
+		}
+		if err := validate(e.Right); err != nil {
+			return err
+		}
+		if value, ok := constant(e.Right); ok && divisions[e.Operator] && value == 0 {
+			return &SyntaxError{
+				Column:  e.Right.Pos(),
+				Message: "division by zero",
+				Reason:  errors.ErrDivideByZero,
 			}
 		}
 	}
-	return count == 0
+	return nil
 }
@@ -39,12 +39,12 @@ func TestValidateExpression(t *testing.T) {
 		},
 		{
 			name: "operator at start",
-			expr: "+2+2",
+			expr: "*2+2",
 			want: errors.ErrInvalidExpression,
 		},
 		{
 			name: "two operators in operation",
-			expr: "2++2",
+			expr: "2**2",
 			want: errors.ErrInvalidExpression,
 		},
 		{
@@ -59,9 +59,29 @@ func TestValidateExpression(t *testing.T) {
 		},
 		{
 			name: "operation with wrong unary operator",
-			expr: "2+-2",
+			expr: "2*/2",
 			want: errors.ErrInvalidExpression,
 		},
+		{
+			name: "implicit multiplication",
+			expr: "2(3)",
+			want: errors.ErrInvalidExpression,
+		},
+		{
+			name: "division by zero in the middle",
+			expr: "2/0.0+1",
+			want: errors.ErrDivideByZero,
+		},
+		{
+			name: "valid unary operators",
+			expr: "-5+3*(-2)-+1",
+			want: nil,
+		},
+		{
+			name: "valid scientific notation",
+			expr: "1e-3*2E+2",
+			want: nil,
+		},
 		{
 			name: "valid operation with extra brackets",
 			expr: "((2+3)*4)",
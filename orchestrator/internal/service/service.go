@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
@@ -13,21 +14,48 @@ import (
 	"github.com/jaam8/web_calculator/orchestrator/internal/service/helper"
 	"github.com/jaam8/web_calculator/orchestrator/internal/service/types"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type OrchestratorService struct {
 	orchestrator.OrchestratorServiceServer
 	expressionManager types.ExpressionManager
 	storage           ports.StorageAdapter
+	agents            types.AgentRegistry
+	// defaultTimeout срок вычисления выражения, если он не указан в запросе, 0 - без ограничения
+	defaultTimeout time.Duration
+	// cache кэш результатов подвыражений, nil - выражения вычисляются без кэша
+	cache      ports.CacheAdapter
+	cacheStats cacheStats
+	// simplify выражения упрощаются через helper.Simplify перед построением графа задач
+	simplify bool
 }
 
-func NewOrchestratorService(storage ports.StorageAdapter, expressionManager types.ExpressionManager) *OrchestratorService {
+func NewOrchestratorService(
+	storage ports.StorageAdapter,
+	expressionManager types.ExpressionManager,
+	agents types.AgentRegistry,
+	defaultTimeout time.Duration,
+	cache ports.CacheAdapter,
+	simplify bool,
+) *OrchestratorService {
 	return &OrchestratorService{
 		expressionManager: expressionManager,
 		storage:           storage,
+		agents:            agents,
+		defaultTimeout:    defaultTimeout,
+		cache:             cache,
+		simplify:          simplify,
 	}
 }
 
@@ -44,18 +72,34 @@ func (s *OrchestratorService) Calculate(
 		return nil, fmt.Errorf("failed to parse user id: %w", err)
 	}
 
-	rpn, err := helper.ToRPN(request.Expression)
-	logger.GetLoggerFromCtx(ctx).Debug(ctx,
-		fmt.Sprintf("RPN for expression: %s", request.Expression),
-		zap.Any("rpn", rpn),
-		zap.Error(err))
+	expr, err := s.newExpression(userId, request.Precision, request.Priority, request.Timeout)
 	if err != nil {
 		return nil, err
 	}
-	expr := &models.Expression{
-		UserId: userId,
-		Status: "pending",
-		Result: nil,
+	expr.NoCache = request.NoCache
+	// очередь не должна расти бесконечно: выражение отклоняется сразу,
+	// а не зависает в ожидании места
+	if err = s.expressionManager.CheckQueue(userId, 1); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"calculation queue is full",
+			zap.String("userID", userId.String()),
+		)
+		return nil, err
+	}
+
+	variables, err := s.userVariables(userId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get variables",
+			zap.String("userID", userId.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	dag, err := s.planExpression(ctx, expr, request.Expression, variables)
+	if err != nil {
+		return nil, syntaxErrorStatus(err)
 	}
 
 	expressionId, err := s.storage.SaveExpression(*expr)
@@ -69,24 +113,352 @@ func (s *OrchestratorService) Calculate(
 	}
 
 	expr.ExpressionID = expressionId
+	if err = s.startExpression(ctx, expr, dag); err != nil {
+		return nil, err
+	}
+
+	return &orchestrator.CalculateResponse{Id: expr.ExpressionID.String()}, nil
+}
+
+// CalculateBatch разбирает все выражения пакета и сохраняет разобранные одним запросом.
+// Выражения с ошибкой разбора не отклоняют пакет, их ошибки возвращаются в элементах ответа.
+// Очередь проверяется один раз на весь пакет
+func (s *OrchestratorService) CalculateBatch(
+	ctx context.Context, request *orchestrator.CalculateBatchRequest,
+) (*orchestrator.CalculateBatchResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+	if len(request.Expressions) == 0 || len(request.Expressions) > models.MaxBatchSize {
+		return nil, errs.ErrInvalidBatch
+	}
+
+	template, err := s.newExpression(userId, request.Precision, request.Priority, request.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	template.NoCache = request.NoCache
+
+	variables, err := s.userVariables(userId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get variables",
+			zap.String("userID", userId.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	batchId := uuid.New()
+	items := make([]*orchestrator.BatchItem, len(request.Expressions))
+	// positions номера принятых выражений в запросе
+	var (
+		accepted  []models.Expression
+		dags      []*helper.DAG
+		positions []int
+	)
+	for i, expression := range request.Expressions {
+		expr := *template
+		expr.BatchID = &batchId
+		dag, err := s.planExpression(ctx, &expr, expression, variables)
+		if err != nil {
+			items[i] = &orchestrator.BatchItem{Error: syntaxErrorDetails(err)}
+			continue
+		}
+		accepted = append(accepted, expr)
+		dags = append(dags, dag)
+		positions = append(positions, i)
+	}
+	if len(accepted) == 0 {
+		return &orchestrator.CalculateBatchResponse{Items: items}, nil
+	}
+	// место в очереди нужно под все принятые выражения пакета, а не под одно
+	if err = s.expressionManager.CheckQueue(userId, len(accepted)); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"calculation queue is full",
+			zap.String("userID", userId.String()),
+			zap.Int("expressions", len(accepted)),
+		)
+		return nil, err
+	}
+
+	ids, err := s.storage.SaveExpressions(accepted)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to save expressions",
+			zap.String("userID", userId.String()),
+			zap.Int("expressions", len(accepted)),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to save expressions: %w", err)
+	}
+	// выражения уже сохранены: если одно не запустилось, остальные всё равно запускаются,
+	// а незапущенное завершается с ошибкой, чтобы не остаться pending до перезапуска
+	for j, id := range ids {
+		expr := &accepted[j]
+		expr.ExpressionID = id
+		items[positions[j]] = &orchestrator.BatchItem{Id: id.String()}
+		if err = s.startExpression(ctx, expr, dags[j]); err != nil {
+			s.failExpression(ctx, userId, id, errs.ErrInternalServerError, err.Error())
+			items[positions[j]].Error = &orchestrator.SyntaxError{Message: errs.ErrInternalServerError.Error()}
+		}
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("accepted %d of %d expressions of batch: %s", len(accepted), len(items), batchId),
+		zap.String("userID", userId.String()),
+		zap.String("batchID", batchId.String()),
+	)
+	return &orchestrator.CalculateBatchResponse{BatchId: batchId.String(), Items: items}, nil
+}
+
+// Explain разбирает выражение так же, как Calculate, и оценивает его вычисление:
+// выражение не сохраняется, задачи не создаются
+func (s *OrchestratorService) Explain(
+	ctx context.Context, request *orchestrator.ExplainRequest,
+) (*orchestrator.ExplainResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+
+	variables, err := s.userVariables(userId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get variables",
+			zap.String("userID", userId.String()),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	expr := &models.Expression{Precision: models.PrecisionFloat}
+	if request.Precision == orchestrator.Precision_PRECISION_DECIMAL {
+		expr.Precision = models.PrecisionDecimal
+	}
+	dag, err := s.planExpression(ctx, expr, request.Expression, variables)
+	if err != nil {
+		return nil, syntaxErrorStatus(err)
+	}
+
+	duration := func(node *helper.Node) time.Duration {
+		return s.expressionManager.OperationTime(node.Operation)
+	}
+	plan := dag.Plan(duration)
+	response := &orchestrator.ExplainResponse{
+		Rpn:              expr.RPN,
+		TasksSaved:       int32(expr.TasksSaved),
+		CriticalPathTime: durationpb.New(plan.CriticalTime),
+	}
+	for _, node := range dag.Nodes {
+		if len(node.Args) == 0 {
+			continue
+		}
+		task := &orchestrator.ExplainTask{
+			Id:            int32(node.ID),
+			Operation:     node.Operation,
+			OperationTime: durationpb.New(duration(node)),
+			EarliestStart: durationpb.New(plan.Start[node.ID]),
+		}
+		for _, arg := range node.Args {
+			operand := &orchestrator.ExplainOperand{Operand: &orchestrator.ExplainOperand_TaskId{TaskId: int32(arg.ID)}}
+			if len(arg.Args) == 0 {
+				operand.Operand = &orchestrator.ExplainOperand_Constant{Constant: arg.String()}
+			}
+			task.Operands = append(task.Operands, operand)
+		}
+		response.Tasks = append(response.Tasks, task)
+	}
+	for _, node := range plan.CriticalPath {
+		response.CriticalPath = append(response.CriticalPath, int32(node.ID))
+	}
+
+	for _, agent := range s.agents.Agents() {
+		response.Agents++
+		response.Slots += int32(agent.Capacity)
+	}
+	if response.Slots > 0 {
+		response.EstimatedTime = durationpb.New(dag.EstimateTime(duration, int(response.Slots)))
+	}
+	return response, nil
+}
+
+// BatchProgress считает выражения пакета пользователя по статусам
+func (s *OrchestratorService) BatchProgress(
+	ctx context.Context, request *orchestrator.BatchProgressRequest,
+) (*orchestrator.BatchProgressResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+	batchId, err := uuid.Parse(request.BatchId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse batch id",
+			zap.String("batchID", request.BatchId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse batch id: %w", err)
+	}
+
+	statuses, err := s.storage.GetBatchProgress(userId, batchId)
+	if err != nil {
+		if errors.Is(err, errs.ErrBatchNotFound) {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"no batch found",
+				zap.String("userID", userId.String()),
+				zap.String("batchID", request.BatchId),
+			)
+			return nil, errs.ErrBatchNotFound
+		}
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get batch progress",
+			zap.String("userID", userId.String()),
+			zap.String("batchID", request.BatchId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get batch progress: %w", err)
+	}
+
+	response := &orchestrator.BatchProgressResponse{
+		BatchId:  batchId.String(),
+		Statuses: make(map[string]int32, len(statuses)),
+	}
+	for status, count := range statuses {
+		response.Statuses[status] = int32(count)
+		response.Total += int32(count)
+		switch status {
+		case models.StatusPending:
+			response.Pending += int32(count)
+		case models.StatusDone:
+			response.Done += int32(count)
+		default:
+			response.Failed += int32(count)
+		}
+	}
+	return response, nil
+}
+
+// newExpression проверяет параметры вычисления и создаёт по ним ещё не сохранённое выражение
+func (s *OrchestratorService) newExpression(
+	userId uuid.UUID, precision orchestrator.Precision, priority int32, timeout *durationpb.Duration,
+) (*models.Expression, error) {
+	if priority < models.MinPriority || priority > models.MaxPriority {
+		return nil, errs.ErrInvalidPriority
+	}
+	limit := s.defaultTimeout
+	if timeout != nil {
+		if timeout.CheckValid() != nil || timeout.AsDuration() <= 0 {
+			return nil, errs.ErrInvalidTimeout
+		}
+		limit = timeout.AsDuration()
+	}
+
+	expr := &models.Expression{
+		UserId:    userId,
+		Status:    models.StatusPending,
+		Precision: models.PrecisionFloat,
+		Priority:  int(priority),
+	}
+	if precision == orchestrator.Precision_PRECISION_DECIMAL {
+		expr.Precision = models.PrecisionDecimal
+	}
+	if limit > 0 {
+		deadline := time.Now().Add(limit)
+		expr.Deadline = &deadline
+		expr.Timeout = limit
+	}
+	return expr, nil
+}
+
+// planExpression разбирает текст выражения в ОПН выражения expr и строит по ней граф задач,
+// expr запоминает текст выражения, его канонический вид и сколько задач сэкономило упрощение
+func (s *OrchestratorService) planExpression(
+	ctx context.Context, expr *models.Expression, expression string, variables map[string]float64,
+) (*helper.DAG, error) {
+	var (
+		rpn   []string
+		saved int
+		err   error
+	)
+	if s.simplify {
+		rpn, saved, err = helper.ToSimplifiedRPN(expression, variables, expr.Precision == models.PrecisionDecimal)
+	} else {
+		rpn, err = helper.ToRPN(expression, variables)
+	}
+	logger.GetLoggerFromCtx(ctx).Debug(ctx,
+		fmt.Sprintf("RPN for expression: %s", expression),
+		zap.Any("rpn", rpn),
+		zap.Int("tasksSaved", saved),
+		zap.Error(err))
+	if err != nil {
+		return nil, err
+	}
+	dag, err := helper.BuildDAG(rpn)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := helper.Normalize(expression, variables)
+	if err != nil {
+		return nil, err
+	}
+	expr.Source = expression
+	expr.Normalized = normalized
+	expr.RPN = rpn
+	expr.TasksSaved = saved
+	dag.Decimal = expr.Precision == models.PrecisionDecimal
+	dag.NoCache = expr.NoCache
+	return dag, nil
+}
 
-	err = s.expressionManager.CreateExpression(expr)
+// startExpression отдаёт сохранённое выражение ExpressionManager и запускает его вычисление
+func (s *OrchestratorService) startExpression(ctx context.Context, expr *models.Expression, dag *helper.DAG) error {
+	err := s.expressionManager.CreateExpression(expr)
 	logger.GetLoggerFromCtx(ctx).Debug(ctx,
 		fmt.Sprintf("Created expression with id: %s", expr.ExpressionID),
 		zap.Error(err),
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	taskManager, err := s.expressionManager.GetTaskManager(expr.ExpressionID)
 	if err != nil {
-		return nil, errs.ErrInternalServerError
+		return errs.ErrInternalServerError
 	}
 
-	go s.Process(ctx, taskManager, rpn, userId, expr.ExpressionID)
+	s.run(ctx, taskManager, dag, expr)
+	return nil
+}
 
-	return &orchestrator.CalculateResponse{Id: expr.ExpressionID.String()}, nil
+// run запускает Process выражения вместе с таймером его deadline. Таймер останавливается,
+// когда Process завершится (выражение вычислено, отменено или завершилось с ошибкой),
+// чтобы не держать выражение в памяти до deadline
+func (s *OrchestratorService) run(ctx context.Context, tm types.TaskManager, dag *helper.DAG, expr *models.Expression) {
+	deadline := s.watchDeadline(ctx, expr)
+	userID, expressionID := expr.UserId, expr.ExpressionID
+	go func() {
+		s.Process(ctx, tm, dag, userID, expressionID)
+		if deadline != nil {
+			deadline.Stop()
+		}
+	}()
 }
 
 func (s *OrchestratorService) Expressions(
@@ -102,7 +474,20 @@ func (s *OrchestratorService) Expressions(
 		return nil, fmt.Errorf("failed to parse user id: %w", err)
 	}
 
-	expressions, err := s.storage.GetExpressions(userId)
+	params, err := expressionsQuery(request)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"invalid expressions query",
+			zap.String("userID", userId.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	limit := params.Limit
+	// лишнее выражение показывает, есть ли следующая страница
+	params.Limit++
+
+	expressions, err := s.storage.GetExpressions(userId, params)
 	if err != nil {
 		if errors.Is(err, errs.ErrExpressionNotFound) {
 			logger.GetLoggerFromCtx(ctx).Debug(ctx,
@@ -119,21 +504,104 @@ func (s *OrchestratorService) Expressions(
 		return nil, fmt.Errorf("failed to get expressions: %w", err)
 	}
 
+	var nextCursor string
+	if len(expressions) > limit {
+		expressions = expressions[:limit]
+		nextCursor = encodeCursor(expressions[limit-1])
+	}
+
 	//expressions := s.expressionManager.GetExpressions()
 	exprs := make([]*orchestrator.Expression, len(expressions))
 	for i, e := range expressions {
-		expr := &orchestrator.Expression{
-			Id:     e.ExpressionID.String(),
-			Status: e.Status,
-			Result: e.Result,
-		}
-		exprs[i] = expr
+		exprs[i] = expressionMessage(e)
 	}
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		fmt.Sprintf("got %d expressions", len(expressions)),
 		zap.String("user_id", userId.String()),
 		zap.Int("expressionsCount", len(expressions)))
-	return &orchestrator.ExpressionsResponse{Expressions: exprs}, nil
+	return &orchestrator.ExpressionsResponse{Expressions: exprs, NextCursor: nextCursor}, nil
+}
+
+// expressionsQuery проверяет параметры страницы списка выражений
+func expressionsQuery(request *orchestrator.ExpressionsRequest) (models.ExpressionsQuery, error) {
+	params := models.ExpressionsQuery{
+		Limit:       int(request.Limit),
+		Statuses:    request.Statuses,
+		OldestFirst: request.Order == orchestrator.SortOrder_SORT_ORDER_OLDEST_FIRST,
+	}
+	if params.Limit == 0 {
+		params.Limit = models.DefaultPageSize
+	}
+	if params.Limit < 0 || params.Limit > models.MaxPageSize {
+		return models.ExpressionsQuery{}, errs.ErrInvalidPageSize
+	}
+	if request.Cursor != "" {
+		cursor, err := decodeCursor(request.Cursor)
+		if err != nil {
+			return models.ExpressionsQuery{}, errs.ErrInvalidCursor
+		}
+		params.After = cursor
+	}
+	if request.CreatedFrom != nil {
+		from := request.CreatedFrom.AsTime()
+		params.CreatedFrom = &from
+	}
+	if request.CreatedTo != nil {
+		to := request.CreatedTo.AsTime()
+		params.CreatedTo = &to
+	}
+	return params, nil
+}
+
+// encodeCursor курсор страницы, следующей за выражением expr
+func encodeCursor(expr *models.Expression) string {
+	position := fmt.Sprintf("%d.%s", expr.CreatedAt.UnixMicro(), expr.ExpressionID)
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+// decodeCursor разбирает курсор, полученный из encodeCursor
+func decodeCursor(cursor string) (*models.ExpressionsCursor, error) {
+	position, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	micros, id, ok := strings.Cut(string(position), ".")
+	if !ok {
+		return nil, errs.ErrInvalidCursor
+	}
+	createdAt, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	expressionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ExpressionsCursor{CreatedAt: time.UnixMicro(createdAt).UTC(), ID: expressionID}, nil
+}
+
+// expressionMessage сохранённое выражение для ответа клиенту
+func expressionMessage(expression *models.Expression) *orchestrator.Expression {
+	expr := &orchestrator.Expression{
+		Id:            expression.ExpressionID.String(),
+		Status:        expression.Status,
+		Result:        expression.Result,
+		DecimalResult: expression.DecimalResult,
+		Expression:    expression.Source,
+		Normalized:    expression.Normalized,
+		Error:         expression.Error,
+		TasksSaved:    int32(expression.TasksSaved),
+	}
+	if !expression.CreatedAt.IsZero() {
+		expr.CreatedAt = timestamppb.New(expression.CreatedAt)
+	}
+	if expression.StartedAt != nil {
+		expr.StartedAt = timestamppb.New(*expression.StartedAt)
+	}
+	if expression.FinishedAt != nil {
+		expr.FinishedAt = timestamppb.New(*expression.FinishedAt)
+	}
+	return expr
 }
 
 func (s *OrchestratorService) ExpressionById(
@@ -186,13 +654,7 @@ func (s *OrchestratorService) ExpressionById(
 		)
 		return nil, fmt.Errorf("failed to get expression: %w", err)
 	}
-	expr := &orchestrator.Expression{
-		Id:     expression.ExpressionID.String(),
-		Status: expression.Status,
-	}
-	if expression.Result != nil {
-		expr.Result = expression.Result
-	}
+	expr := expressionMessage(expression)
 
 	logger.GetLoggerFromCtx(ctx).Info(ctx,
 		"got expression by id",
@@ -202,150 +664,752 @@ func (s *OrchestratorService) ExpressionById(
 	return &orchestrator.ExpressionByIdResponse{Expression: expr}, nil
 }
 
-func (s *OrchestratorService) ResultTask(
-	ctx context.Context, request *orchestrator.ResultTaskRequest,
-) (*orchestrator.ResultTaskResponse, error) {
-	expressionId, err := uuid.Parse(request.ExpressionId)
+// ExpressionTasks возвращает задачи выражения пользователя: чем они вычислены, каким агентом и когда
+func (s *OrchestratorService) ExpressionTasks(
+	ctx context.Context, request *orchestrator.ExpressionTasksRequest,
+) (*orchestrator.ExpressionTasksResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+	expressionId, err := uuid.Parse(request.Id)
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			"failed to parse expression id",
-			zap.String("expressionID", request.ExpressionId),
+			zap.String("expressionID", request.Id),
 			zap.Error(err),
 		)
 		return nil, fmt.Errorf("failed to parse expression id: %w", err)
 	}
-	taskManager, err := s.expressionManager.GetTaskManager(expressionId)
+
+	// задачи чужого выражения не отдаются, как и само выражение
+	expression, err := s.storage.GetExpressionById(userId, expressionId)
 	if err != nil {
-		logger.GetLoggerFromCtx(ctx).Debug(ctx,
-			fmt.Sprintf("no task manager found for expression with id: %s", request.ExpressionId),
-			zap.String("expressionID", request.ExpressionId),
+		if errors.Is(err, errs.ErrExpressionNotFound) {
+			return nil, errs.ErrExpressionNotFound
+		}
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get expression",
+			zap.String("userID", userId.String()),
+			zap.String("expressionID", request.Id),
+			zap.Error(err),
 		)
-		return nil, errs.ErrTaskNotFound
+		return nil, fmt.Errorf("failed to get expression: %w", err)
 	}
-
-	exprId, err := uuid.Parse(request.ExpressionId)
+	tasks, err := s.storage.GetTasks(expressionId)
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
-			"failed to parse expression id",
-			zap.String("expressionID", request.ExpressionId),
+			"failed to get tasks",
+			zap.String("expressionID", request.Id),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("failed to parse expression id: %w", err)
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
 
-	result := models.Result{
-		ExpressionID: exprId,
-		TaskID:       int(request.Id),
-		Result:       request.Result,
+	response := &orchestrator.ExpressionTasksResponse{Tasks: make([]*orchestrator.TaskTrace, 0, len(tasks))}
+	for _, task := range tasks {
+		response.Tasks = append(response.Tasks, taskTrace(task, expression.Precision == models.PrecisionDecimal))
 	}
-	taskManager.AddResult(result)
-	logger.GetLoggerFromCtx(ctx).Info(ctx,
-		fmt.Sprintf("got result for task with id: %d", request.Id),
-		zap.String("expressionID", result.ExpressionID.String()),
-		zap.Int("taskID", result.TaskID),
-		zap.Float64("result", result.Result),
-	)
-	return &orchestrator.ResultTaskResponse{Status: "task completed"}, nil
+	return response, nil
+}
+
+// taskTrace сохранённая задача для ответа клиенту, decimal - выражение вычислялось в десятичной арифметике
+func taskTrace(task *models.Task, decimal bool) *orchestrator.TaskTrace {
+	trace := &orchestrator.TaskTrace{
+		Id:        int64(task.TaskID),
+		Operation: task.Operation,
+		Args:      task.Args,
+		Status:    task.Status,
+		Result:    task.Result,
+		Error:     task.Error,
+		Attempts:  int32(task.Attempts),
+	}
+	if decimal {
+		trace.DecimalArgs = task.DecimalArgs
+		trace.DecimalResult = task.DecimalResult
+	}
+	if task.AgentID != nil {
+		trace.AgentId = *task.AgentID
+	}
+	if task.QueuedAt != nil {
+		trace.QueuedAt = timestamppb.New(*task.QueuedAt)
+	}
+	if task.StartedAt != nil {
+		trace.StartedAt = timestamppb.New(*task.StartedAt)
+	}
+	if task.FinishedAt != nil {
+		trace.FinishedAt = timestamppb.New(*task.FinishedAt)
+	}
+	return trace
+}
+
+// WatchExpression отправляет состояние выражения при каждом его изменении, пока выражение
+// не будет вычислено или не завершится ошибкой, последним отправляется итог из хранилища
+func (s *OrchestratorService) WatchExpression(
+	request *orchestrator.WatchExpressionRequest, stream orchestrator.OrchestratorService_WatchExpressionServer,
+) error {
+	ctx := stream.Context()
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to parse user id: %w", err)
+	}
+	expressionId, err := uuid.Parse(request.Id)
+	if err != nil {
+		logger.GetOrCreateLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse expression id",
+			zap.String("expressionID", request.Id),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to parse expression id: %w", err)
+	}
+
+	// выражение проверяется в хранилище, чтобы нельзя было подписаться на чужое
+	expression, err := s.storage.GetExpressionById(userId, expressionId)
+	if err != nil {
+		if errors.Is(err, errs.ErrExpressionNotFound) {
+			return errs.ErrExpressionNotFound
+		}
+		return fmt.Errorf("failed to get expression: %w", err)
+	}
+	if expression.Status != models.StatusPending {
+		return stream.Send(expressionEvent(expression))
+	}
+
+	events, cancel := s.expressionManager.Watch(expressionId)
+	defer cancel()
+	var last *orchestrator.ExpressionEvent
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				expression, err = s.storage.GetExpressionById(userId, expressionId)
+				if err != nil {
+					return fmt.Errorf("failed to get expression: %w", err)
+				}
+				// итог дополняется прогрессом из последнего события, например
+				// сколько задач успели посчитать до timed out
+				final := expressionEvent(expression)
+				if last != nil {
+					final.TasksDone, final.TasksTotal, final.Elapsed = last.TasksDone, last.TasksTotal, last.Elapsed
+				}
+				return stream.Send(final)
+			}
+			last = &orchestrator.ExpressionEvent{
+				Id:         event.ExpressionID.String(),
+				Status:     event.Status,
+				TasksDone:  int32(event.TasksDone),
+				TasksTotal: int32(event.TasksTotal),
+			}
+			if event.Elapsed > 0 {
+				last.Elapsed = durationpb.New(event.Elapsed)
+			}
+			if err = stream.Send(last); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// CancelExpression отменяет ещё вычисляемое выражение пользователя: статус cancelled
+// сохраняется сразу, задачи убираются из очереди, а агенты бросают уже выданные
+func (s *OrchestratorService) CancelExpression(
+	ctx context.Context, request *orchestrator.CancelExpressionRequest,
+) (*emptypb.Empty, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+	expressionId, err := uuid.Parse(request.Id)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse expression id",
+			zap.String("expressionID", request.Id),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse expression id: %w", err)
+	}
+
+	err = s.storage.StopExpression(userId, expressionId, errs.ErrExpressionCancelled.Error(), "")
+	switch {
+	case errors.Is(err, errs.ErrExpressionNotFound), errors.Is(err, errs.ErrExpressionFinished):
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"cannot cancel expression",
+			zap.String("userID", userId.String()),
+			zap.String("expressionID", request.Id),
+			zap.Error(err),
+		)
+		return nil, err
+	case err != nil:
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to cancel expression",
+			zap.String("userID", userId.String()),
+			zap.String("expressionID", request.Id),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to cancel expression: %w", err)
+	}
+
+	// выражение могло завершиться между обновлением хранилища и отменой,
+	// тогда сохранённый статус cancelled уже не перезапишется
+	s.expressionManager.CancelExpression(expressionId)
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("expression with id: %s cancelled", expressionId),
+		zap.String("userID", userId.String()),
+		zap.String("expressionID", expressionId.String()),
+	)
+	return &emptypb.Empty{}, nil
+}
+
+// expressionEvent итоговое событие по сохранённому выражению
+func expressionEvent(expression *models.Expression) *orchestrator.ExpressionEvent {
+	return &orchestrator.ExpressionEvent{
+		Id:            expression.ExpressionID.String(),
+		Status:        expression.Status,
+		Result:        expression.Result,
+		DecimalResult: expression.DecimalResult,
+	}
+}
+
+func (s *OrchestratorService) ResultTask(
+	ctx context.Context, request *orchestrator.ResultTaskRequest,
+) (*orchestrator.ResultTaskResponse, error) {
+	expressionId, err := uuid.Parse(request.ExpressionId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse expression id",
+			zap.String("expressionID", request.ExpressionId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse expression id: %w", err)
+	}
+	// агент бросил задачу отменённого или просроченного выражения. Если выражение всё же
+	// вычисляется, задача вернётся в очередь по истечении аренды
+	if request.ErrorCode == orchestrator.TaskErrorCode_CANCELLED {
+		logger.GetLoggerFromCtx(ctx).Debug(ctx,
+			fmt.Sprintf("agent abandoned task with id: %d", request.Id),
+			zap.String("expressionID", request.ExpressionId),
+			zap.Int64("taskID", request.Id),
+		)
+		return &orchestrator.ResultTaskResponse{Status: "task cancelled"}, nil
+	}
+
+	taskManager, err := s.expressionManager.GetTaskManager(expressionId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Debug(ctx,
+			fmt.Sprintf("no task manager found for expression with id: %s", request.ExpressionId),
+			zap.String("expressionID", request.ExpressionId),
+		)
+		// выражение уже не вычисляется, но аренду задачи нужно снять, иначе она
+		// будет возвращаться в очередь снова и снова
+		s.expressionManager.CompleteTask(expressionId, int(request.Id))
+		return nil, errs.ErrTaskNotFound
+	}
+
+	// задача могла быть выдана повторно после истечения аренды,
+	// засчитываем только первый результат
+	if !s.expressionManager.CompleteTask(expressionId, int(request.Id)) {
+		logger.GetLoggerFromCtx(ctx).Info(ctx,
+			fmt.Sprintf("ignore duplicate result for task with id: %d", request.Id),
+			zap.String("expressionID", request.ExpressionId),
+			zap.Int64("taskID", request.Id),
+		)
+		return &orchestrator.ResultTaskResponse{Status: "task already completed"}, nil
+	}
+
+	result := models.Result{
+		ExpressionID: expressionId,
+		TaskID:       int(request.Id),
+		Result:       request.Result,
+		Decimal:      request.DecimalResult,
+		Err:          taskError(request.ErrorCode),
+	}
+	if result.Err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			fmt.Sprintf("agent failed task with id: %d", request.Id),
+			zap.String("expressionID", request.ExpressionId),
+			zap.Int64("taskID", request.Id),
+			zap.String("errorMessage", request.ErrorMessage),
+			zap.Error(result.Err),
+		)
+	}
+	taskManager.AddResult(result)
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("got result for task with id: %d", request.Id),
+		zap.String("expressionID", result.ExpressionID.String()),
+		zap.Int("taskID", result.TaskID),
+		zap.Float64("result", result.Result),
+	)
+	return &orchestrator.ResultTaskResponse{Status: "task completed"}, nil
 }
 
 func (s *OrchestratorService) GetTask(
 	ctx context.Context, _ *emptypb.Empty,
 ) (*orchestrator.GetTaskResponse, error) {
-	select {
-	case task := <-s.expressionManager.GetTasks():
-		if task.TaskID != 0 {
-			logger.GetLoggerFromCtx(ctx).Debug(ctx,
-				fmt.Sprintf("send task with id: %d", task.TaskID),
-				zap.String("expressionID", task.ExpressionID.String()),
-				zap.Int("taskID", task.TaskID),
-				zap.Float64("arg1", task.Arg1),
-				zap.Float64("arg2", task.Arg2),
-				zap.String("operation", task.Operation),
-				zap.Duration("operationTime", task.OperationTime),
-			)
-			return &orchestrator.GetTaskResponse{
-				Task: &orchestrator.Task{
-					ExpressionId:  task.ExpressionID.String(),
-					Id:            int64(task.TaskID),
-					Arg1:          task.Arg1,
-					Arg2:          task.Arg2,
-					Operation:     task.Operation,
-					OperationTime: durationpb.New(task.OperationTime),
-				},
-			}, nil
-		}
-	default:
+	task, ok := s.expressionManager.LeaseTask()
+	if !ok {
 		logger.GetLoggerFromCtx(ctx).Warn(ctx, "no task found")
 		return nil, errs.ErrTaskNotFound
 	}
-	logger.GetLoggerFromCtx(ctx).Error(ctx,
-		"internal server error",
-		zap.Error(errs.ErrInternalServerError),
-	)
-	return nil, errs.ErrInternalServerError
+	s.markStarted(ctx, task, "")
+	return &orchestrator.GetTaskResponse{Task: sentTask(ctx, task)}, nil
 }
 
-func (s *OrchestratorService) Process(ctx context.Context, tm types.TaskManager, rpn []string, userID, expressionID uuid.UUID) {
-	var stack []float64
-	for _, v := range rpn {
-		if num, err := strconv.ParseFloat(v, 64); err == nil {
-			stack = append(stack, num)
-			continue
+// markStarted сохраняет, когда и какому агенту выдана задача, и момент, когда агент взял
+// первую задачу выражения. Пустой agentID - задачу взяли через GetTask
+func (s *OrchestratorService) markStarted(ctx context.Context, task models.Task, agentID string) {
+	if err := s.storage.StartTask(task.ExpressionID, task.TaskID, agentID); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to save task start",
+			zap.String("expressionID", task.ExpressionID.String()),
+			zap.Int("taskID", task.TaskID),
+			zap.Error(err))
+	}
+	if !s.expressionManager.MarkStarted(task.ExpressionID) {
+		return
+	}
+	if err := s.storage.StartExpression(task.ExpressionID); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to save expression start",
+			zap.String("expressionID", task.ExpressionID.String()),
+			zap.Error(err))
+	}
+}
+
+// Work держит долгоживущий канал агента: задачи отправляются, как только они появляются
+// и у агента есть свободные вычислители, а результаты принимаются в том же потоке.
+// Первым сообщением агент должен прислать WorkerReady с ID из реестра, число свободных
+// вычислителей не превышает его зарегистрированной мощности, а задачи отправляются
+// только с операциями, которые агент указал при регистрации. Задача, выданная агенту,
+// который отключился, вернётся в очередь по истечении аренды
+func (s *OrchestratorService) Work(stream orchestrator.OrchestratorService_WorkServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	ready := request.GetReady()
+	if ready == nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx, "work stream opened without ready message")
+		return errs.ErrInvalidAgent
+	}
+	agent, ok := s.agents.Agent(ready.AgentId)
+	if !ok {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"work stream opened by unknown agent",
+			zap.String("agentID", ready.AgentId),
+		)
+		return errs.ErrAgentNotFound
+	}
+
+	w := &agentWork{agent: agent, stream: stream, wake: make(chan struct{}, 1)}
+	w.ready(ready.Slots)
+	// задачи, оставшиеся без ответа, больше не считаются занятыми вычислителями агента
+	defer func() { s.agents.AddBusy(agent.ID, -int(w.inFlight.Load())) }()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- s.receiveWork(ctx, stream, w)
+		cancel()
+	}()
+	go s.sendCancels(ctx, w)
+
+	for {
+		for w.free.Load() <= 0 {
+			select {
+			case <-w.wake:
+			case err = <-recvErr:
+				return err
+			}
 		}
-		if len(stack) < 2 {
-			status := "invalid expression"
-			err := s.storage.UpdateExpression(userID, expressionID, &status, nil)
+		if _, ok = s.agents.Agent(agent.ID); !ok {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"agent is gone from registry, closing its work stream",
+				zap.String("agentID", agent.ID),
+			)
+			return errs.ErrAgentNotFound
+		}
+		task, ok := s.expressionManager.NextTask(ctx, agent.Operations)
+		if !ok {
+			return <-recvErr
+		}
+		w.free.Add(-1)
+		w.inFlight.Add(1)
+		s.agents.AddBusy(agent.ID, 1)
+		s.markStarted(ctx, task, agent.ID)
+		err = w.send(&orchestrator.WorkResponse{
+			Payload: &orchestrator.WorkResponse_Task{Task: sentTask(ctx, task)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// agentWork состояние потока Work одного агента
+type agentWork struct {
+	agent models.Agent
+	// stream пишут и выдача задач, и рассылка отмен, sendMu не даёт им писать одновременно
+	stream orchestrator.OrchestratorService_WorkServer
+	sendMu sync.Mutex
+	// free свободные вычислители агента, inFlight - отправленные задачи без результата
+	free     atomic.Int64
+	inFlight atomic.Int64
+	// wake будит отправку задач, когда освобождаются вычислители
+	wake chan struct{}
+}
+
+func (w *agentWork) send(response *orchestrator.WorkResponse) error {
+	w.sendMu.Lock()
+	defer w.sendMu.Unlock()
+	return w.stream.Send(response)
+}
+
+// sendCancels сообщает агенту об отменённых выражениях, чтобы он бросил их задачи.
+// Агент, у которого нет задач выражения, просто пропускает сообщение
+func (s *OrchestratorService) sendCancels(ctx context.Context, w *agentWork) {
+	cancels, stop := s.expressionManager.WatchCancels()
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case expressionID := <-cancels:
+			err := w.send(&orchestrator.WorkResponse{
+				Payload: &orchestrator.WorkResponse_CancelExpressionId{CancelExpressionId: expressionID.String()},
+			})
 			if err != nil {
-				logger.GetLoggerFromCtx(ctx).Error(ctx,
-					"failed to update expression",
-					zap.String("userID", userID.String()),
+				logger.GetLoggerFromCtx(ctx).Warn(ctx,
+					"failed to send expression cancel to agent",
+					zap.String("agentID", w.agent.ID),
 					zap.String("expressionID", expressionID.String()),
-					zap.Error(err))
+					zap.Error(err),
+				)
 				return
 			}
-			s.expressionManager.ExpressionError(expressionID)
-			return
 		}
-		arg2 := stack[len(stack)-1]
-		arg1 := stack[len(stack)-2]
-		stack = stack[:len(stack)-2]
+	}
+}
+
+// ready добавляет свободные вычислители, но не больше мощности агента
+func (w *agentWork) ready(slots int32) {
+	available := int64(w.agent.Capacity) - w.free.Load() - w.inFlight.Load()
+	w.release(min(int64(slots), available))
+}
+
+// release добавляет свободные вычислители и будит отправку задач
+func (w *agentWork) release(slots int64) {
+	if slots <= 0 {
+		return
+	}
+	w.free.Add(slots)
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// receiveWork принимает от агента сообщения о свободных вычислителях и результаты задач.
+// Возвращает nil, когда агент закрыл поток
+func (s *OrchestratorService) receiveWork(
+	ctx context.Context, stream orchestrator.OrchestratorService_WorkServer, w *agentWork,
+) error {
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch payload := request.Payload.(type) {
+		case *orchestrator.WorkRequest_Ready:
+			w.ready(payload.Ready.GetSlots())
+		case *orchestrator.WorkRequest_Result:
+			if _, err = s.ResultTask(ctx, payload.Result); err != nil {
+				logger.GetLoggerFromCtx(ctx).Warn(ctx,
+					"failed to accept task result",
+					zap.String("expressionID", payload.Result.GetExpressionId()),
+					zap.Int64("taskID", payload.Result.GetId()),
+					zap.Error(err),
+				)
+			}
+			if w.inFlight.Add(-1) < 0 {
+				w.inFlight.Add(1)
+			} else {
+				s.agents.AddBusy(w.agent.ID, -1)
+			}
+			w.ready(1)
+		}
+	}
+}
 
-		task := tm.CreateTask(arg1, arg2, v, expressionID)
+// sentTask переводит выданную агенту задачу в сообщение gRPC
+func sentTask(ctx context.Context, task models.Task) *orchestrator.Task {
+	logger.GetLoggerFromCtx(ctx).Debug(ctx,
+		fmt.Sprintf("send task with id: %d", task.TaskID),
+		zap.String("expressionID", task.ExpressionID.String()),
+		zap.Int("taskID", task.TaskID),
+		zap.Float64s("args", task.Args),
+		zap.String("operation", task.Operation),
+		zap.Duration("operationTime", task.OperationTime),
+	)
+	result := &orchestrator.Task{
+		ExpressionId:  task.ExpressionID.String(),
+		Id:            int64(task.TaskID),
+		Args:          task.Args,
+		Operation:     task.Operation,
+		OperationTime: durationpb.New(task.OperationTime),
+	}
+	if task.DecimalArgs != nil {
+		result.Precision = orchestrator.Precision_PRECISION_DECIMAL
+		result.DecimalArgs = task.DecimalArgs
+	}
+	return result
+}
+
+// Recover возобновляет вычисление выражений, оставшихся в статусе pending после перезапуска:
+// уже посчитанные задачи берутся из хранилища, остальные отправляются на вычисление заново
+func (s *OrchestratorService) Recover(ctx context.Context) error {
+	expressions, err := s.storage.GetPendingExpressions()
+	if err != nil {
+		return fmt.Errorf("failed to get pending expressions: %w", err)
+	}
+
+	for _, expr := range expressions {
+		dag, err := helper.BuildDAG(expr.RPN)
+		if err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"cannot re-plan pending expression",
+				zap.String("expressionID", expr.ExpressionID.String()),
+				zap.Strings("rpn", expr.RPN),
+				zap.Error(err))
+			s.failExpression(ctx, expr.UserId, expr.ExpressionID, errs.ErrInvalidExpression,
+				fmt.Sprintf("cannot re-plan saved expression: %v", err))
+			continue
+		}
+		dag.Decimal = expr.Precision == models.PrecisionDecimal
+		dag.NoCache = expr.NoCache
+
+		tasks, err := s.storage.GetTasks(expr.ExpressionID)
+		if err != nil {
+			return fmt.Errorf("failed to get tasks of expression %s: %w", expr.ExpressionID, err)
+		}
+		lastTaskID := 0
+		for _, task := range tasks {
+			lastTaskID = max(lastTaskID, task.TaskID)
+			if task.Status != models.StatusDone || task.Result == nil ||
+				task.NodeID < 0 || task.NodeID >= len(dag.Nodes) {
+				continue
+			}
+			if dag.Decimal && task.DecimalResult != nil {
+				if err = dag.Nodes[task.NodeID].ResolveDecimal(*task.DecimalResult); err != nil {
+					return fmt.Errorf("failed to restore task %d of expression %s: %w", task.TaskID, expr.ExpressionID, err)
+				}
+				continue
+			}
+			dag.Nodes[task.NodeID].Resolve(*task.Result)
+		}
+
+		if err = s.expressionManager.RestoreExpression(expr, lastTaskID); err != nil {
+			return fmt.Errorf("failed to restore expression %s: %w", expr.ExpressionID, err)
+		}
+		taskManager, err := s.expressionManager.GetTaskManager(expr.ExpressionID)
+		if err != nil {
+			return fmt.Errorf("failed to restore expression %s: %w", expr.ExpressionID, err)
+		}
+
+		logger.GetLoggerFromCtx(ctx).Info(ctx,
+			fmt.Sprintf("resumed expression with id: %s", expr.ExpressionID),
+			zap.String("expressionID", expr.ExpressionID.String()),
+			zap.Int("storedTasks", len(tasks)))
+		// срок мог истечь, пока оркестратор не работал, тогда выражение завершится сразу
+		s.run(ctx, taskManager, dag, expr)
+	}
+	return nil
+}
+
+// Process отправляет на вычисление сразу все задачи графа, операнды которых уже готовы,
+// и собирает результаты по TaskID, сохраняя промежуточное состояние в хранилище.
+// Подвыражения, результат которых есть в кэше, не вычисляются, а одинаковые подвыражения
+// выражения вычисляются одной задачей
+func (s *OrchestratorService) Process(ctx context.Context, tm types.TaskManager, dag *helper.DAG, userID, expressionID uuid.UUID) {
+	// inFlight вершины, ждущие результата задачи, computing - задачи по ключам подвыражений,
+	// known - подвыражения, которые выражение уже посчитало
+	inFlight := make(map[int][]*helper.Node)
+	computing := make(map[string]int)
+	known := make(map[string]models.CachedResult)
+	scheduled := make(map[int]bool)
+	useCache := s.cache != nil && !dag.NoCache
+
+	dispatch := func(node *helper.Node) {
+		task := tm.CreateTask(node.ArgValues(), node.Operation, expressionID)
+		task.NodeID = node.ID
+		if dag.Decimal {
+			task.DecimalArgs = node.ArgDecimals()
+		}
 		logger.GetLoggerFromCtx(ctx).Debug(ctx,
 			fmt.Sprintf("created task with id: %d", task.TaskID),
 			zap.String("expressionID", task.ExpressionID.String()),
 			zap.Int("taskID", task.TaskID),
-			zap.Float64("arg1", task.Arg1),
-			zap.Float64("arg2", task.Arg2),
+			zap.Float64s("args", task.Args),
 			zap.String("operator", task.Operation))
-		s.expressionManager.AddTask(task)
+		if err := s.storage.SaveTask(task); err != nil {
+			logger.GetLoggerFromCtx(ctx).Error(ctx,
+				"failed to save task",
+				zap.String("expressionID", expressionID.String()),
+				zap.Int("taskID", task.TaskID),
+				zap.Error(err))
+		}
+		inFlight[task.TaskID] = []*helper.Node{node}
+		computing[node.Key] = task.TaskID
+		if useCache {
+			s.cacheStats.misses.Add(1)
+		}
+		// выражение остановлено, пока разбирались результаты: GetResult вернёт причину остановки
+		if !s.expressionManager.AddTask(task) {
+			logger.GetLoggerFromCtx(ctx).Debug(ctx,
+				fmt.Sprintf("skip task with id: %d of stopped expression", task.TaskID),
+				zap.String("expressionID", task.ExpressionID.String()),
+				zap.Int("taskID", task.TaskID))
+		}
+	}
+	// schedule отправляет готовые вершины на вычисление, если выражение ещё не считает
+	// и не посчитало такое же подвыражение
+	schedule := func(nodes []*helper.Node) {
+		for len(nodes) > 0 {
+			node := nodes[0]
+			nodes = nodes[1:]
+			if scheduled[node.ID] {
+				continue
+			}
+			scheduled[node.ID] = true
+			if taskID, ok := computing[node.Key]; ok {
+				inFlight[taskID] = append(inFlight[taskID], node)
+				s.cacheStats.deduplicated.Add(1)
+				continue
+			}
+			if result, ok := known[node.Key]; ok {
+				if _, err := dag.ResolveCached(node, result.Value, result.Decimal); err == nil {
+					s.cacheStats.deduplicated.Add(1)
+					if node.Parent != nil && node.Parent.Ready() {
+						nodes = append(nodes, node.Parent)
+					}
+					continue
+				}
+			}
+			dispatch(node)
+		}
+	}
+
+	if useCache {
+		s.resolveCached(ctx, dag, expressionID)
+	}
+	schedule(dag.Ready())
+	done, total := dag.Progress()
+	s.expressionManager.ExpressionProgress(expressionID, done, total)
+
+	for len(inFlight) > 0 {
 		result := tm.GetResult()
+		if errors.Is(result.Err, errs.ErrExpressionCancelled) || errors.Is(result.Err, errs.ErrExpressionTimedOut) {
+			logger.GetLoggerFromCtx(ctx).Info(ctx,
+				fmt.Sprintf("stop planning %s expression with id: %s", result.Err, expressionID),
+				zap.String("expressionID", expressionID.String()))
+			return
+		}
+		nodes, ok := inFlight[result.TaskID]
+		if !ok {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				fmt.Sprintf("got result for unknown task with id: %d", result.TaskID),
+				zap.String("expressionID", expressionID.String()),
+				zap.Int("taskID", result.TaskID))
+			continue
+		}
+		delete(inFlight, result.TaskID)
+		delete(computing, nodes[0].Key)
+		if result.Err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				fmt.Sprintf("task with id: %d failed", result.TaskID),
+				zap.String("expressionID", expressionID.String()),
+				zap.Int("taskID", result.TaskID),
+				zap.Error(result.Err))
+			if err := s.storage.SaveTaskResult(result); err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(ctx,
+					"failed to save task error",
+					zap.String("expressionID", expressionID.String()),
+					zap.Int("taskID", result.TaskID),
+					zap.Error(err))
+			}
+			s.failExpression(ctx, userID, expressionID, result.Err, fmt.Sprintf("%s in %s", result.Err, nodes[0]))
+			return
+		}
 		logger.GetLoggerFromCtx(ctx).Debug(ctx,
-			fmt.Sprintf("got result for task with id: %d", task.TaskID),
+			fmt.Sprintf("got result for task with id: %d", result.TaskID),
 			zap.String("expressionID", expressionID.String()),
-			zap.Int("taskID", task.TaskID),
+			zap.Int("taskID", result.TaskID),
 			zap.Float64("result", result.Result))
-		stack = append(stack, result.Result)
-	}
-
-	if len(stack) != 1 {
-		status := "invalid expression"
-		err := s.storage.UpdateExpression(userID, expressionID, &status, nil)
-		if err != nil {
+		if err := s.storage.SaveTaskResult(result); err != nil {
 			logger.GetLoggerFromCtx(ctx).Error(ctx,
-				"failed to update expression",
-				zap.String("userID", userID.String()),
+				"failed to save task result",
 				zap.String("expressionID", expressionID.String()),
+				zap.Int("taskID", result.TaskID),
 				zap.Error(err))
-			return
 		}
-		s.expressionManager.ExpressionError(expressionID)
-		return
+
+		var parents []*helper.Node
+		for _, node := range nodes {
+			if _, err := dag.ResolveCached(node, result.Result, result.Decimal); err != nil {
+				logger.GetLoggerFromCtx(ctx).Warn(ctx,
+					fmt.Sprintf("got malformed decimal result for task with id: %d", result.TaskID),
+					zap.String("expressionID", expressionID.String()),
+					zap.Int("taskID", result.TaskID),
+					zap.Error(err))
+				s.failExpression(ctx, userID, expressionID, errs.ErrInvalidExpression,
+					fmt.Sprintf("%s returned %v", node, err))
+				return
+			}
+			if node.Parent != nil && node.Parent.Ready() {
+				parents = append(parents, node.Parent)
+			}
+		}
+		cached := models.CachedResult{Value: result.Result}
+		if dag.Decimal {
+			cached = models.CachedResult{Decimal: result.Decimal}
+		}
+		known[nodes[0].Key] = cached
+		s.saveCached(ctx, dag, nodes[0], cached)
+
+		done, total = dag.Progress()
+		s.expressionManager.ExpressionProgress(expressionID, done, total)
+		schedule(parents)
 	}
 
-	result := stack[0]
-	status := "done"
-	err := s.storage.UpdateExpression(userID, expressionID, &status, &result)
+	result := dag.Root.Value
+	status := models.StatusDone
+	var err error
+	if dag.Decimal {
+		err = s.storage.UpdateExpressionDecimal(userID, expressionID, status, dag.Root.Decimal)
+	} else {
+		err = s.storage.UpdateExpression(userID, expressionID, &status, &result)
+	}
 	if err != nil {
 		logger.GetLoggerFromCtx(ctx).Error(ctx,
 			"failed to update expression",
@@ -362,3 +1426,210 @@ func (s *OrchestratorService) Process(ctx context.Context, tm types.TaskManager,
 		zap.String("status", status),
 	)
 }
+
+// cacheKey ключ результата вершины в кэше: результаты в разных режимах точности различаются
+func cacheKey(dag *helper.DAG, node *helper.Node) string {
+	if dag.Decimal {
+		return models.PrecisionDecimal + ":" + node.Key
+	}
+	return models.PrecisionFloat + ":" + node.Key
+}
+
+// resolveCached берёт из кэша результаты ещё не посчитанных подвыражений графа. Вершины
+// проверяются от корня к листьям, поэтому берётся самое большое посчитанное подвыражение
+func (s *OrchestratorService) resolveCached(ctx context.Context, dag *helper.DAG, expressionID uuid.UUID) {
+	var (
+		nodes []*helper.Node
+		keys  []string
+	)
+	for _, node := range dag.Nodes {
+		if !node.Done {
+			nodes = append(nodes, node)
+			keys = append(keys, cacheKey(dag, node))
+		}
+	}
+	if len(nodes) == 0 {
+		return
+	}
+	results, err := s.cache.GetResults(keys)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to get cached results, computing the whole expression",
+			zap.String("expressionID", expressionID.String()),
+			zap.Error(err))
+		return
+	}
+
+	hits := 0
+	// в ОПН вершина идёт после всего своего поддерева
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if nodes[i].Done || results[i] == nil {
+			continue
+		}
+		saved, err := dag.ResolveCached(nodes[i], results[i].Value, results[i].Decimal)
+		if err != nil {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"skipping malformed cached result",
+				zap.String("expressionID", expressionID.String()),
+				zap.String("key", keys[i]),
+				zap.Error(err))
+			continue
+		}
+		hits += saved
+	}
+	s.cacheStats.hits.Add(int64(hits))
+	if dag.Root.Done {
+		s.cacheStats.expressions.Add(1)
+	}
+	logger.GetLoggerFromCtx(ctx).Debug(ctx,
+		fmt.Sprintf("took %d of %d tasks from cache", hits, len(nodes)),
+		zap.String("expressionID", expressionID.String()))
+}
+
+// saveCached сохраняет результат подвыражения в кэш, ошибка кэша не мешает вычислению
+func (s *OrchestratorService) saveCached(ctx context.Context, dag *helper.DAG, node *helper.Node, result models.CachedResult) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.SaveResult(cacheKey(dag, node), result); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"failed to cache result",
+			zap.String("key", node.Key),
+			zap.Error(err))
+	}
+}
+
+// CacheStats сообщает, сколько задач сэкономил кэш результатов с запуска оркестратора
+func (s *OrchestratorService) CacheStats(
+	_ context.Context, _ *emptypb.Empty,
+) (*orchestrator.CacheStatsResponse, error) {
+	stats := s.cacheStats.snapshot()
+	return &orchestrator.CacheStatsResponse{
+		Enabled:      s.cache != nil,
+		Hits:         stats.Hits,
+		Misses:       stats.Misses,
+		HitRate:      stats.HitRate(),
+		Deduplicated: stats.Deduplicated,
+		Expressions:  stats.Expressions,
+	}, nil
+}
+
+// cacheStats счётчики models.CacheStats, которые обновляют Process всех выражений
+type cacheStats struct {
+	hits         atomic.Int64
+	misses       atomic.Int64
+	deduplicated atomic.Int64
+	expressions  atomic.Int64
+}
+
+func (c *cacheStats) snapshot() models.CacheStats {
+	return models.CacheStats{
+		Hits:         c.hits.Load(),
+		Misses:       c.misses.Load(),
+		Deduplicated: c.deduplicated.Load(),
+		Expressions:  c.expressions.Load(),
+	}
+}
+
+// failExpression помечает выражение как ошибочное, статусом становится текст ошибки,
+// а details сохраняются подробностями ошибки
+func (s *OrchestratorService) failExpression(
+	ctx context.Context, userID, expressionID uuid.UUID, reason error, details string,
+) {
+	status := reason.Error()
+	err := s.storage.FailExpression(userID, expressionID, status, details)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to update expression",
+			zap.String("userID", userID.String()),
+			zap.String("expressionID", expressionID.String()),
+			zap.Error(err))
+		return
+	}
+	s.expressionManager.ExpressionError(expressionID, reason)
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("expression with id: %s failed", expressionID),
+		zap.String("expressionID", expressionID.String()),
+		zap.String("status", status),
+		zap.String("details", details),
+	)
+}
+
+// watchDeadline завершает выражение со статусом timed out, если оно не вычислится до своего deadline.
+// Возвращает таймер deadline, nil - у выражения нет срока
+func (s *OrchestratorService) watchDeadline(ctx context.Context, expr *models.Expression) *time.Timer {
+	if expr.Deadline == nil {
+		return nil
+	}
+	userID, expressionID := expr.UserId, expr.ExpressionID
+	deadline, timeout := *expr.Deadline, expr.Timeout
+	return time.AfterFunc(time.Until(deadline), func() {
+		s.timeoutExpression(ctx, userID, expressionID, timeout+time.Since(deadline))
+	})
+}
+
+// timeoutExpression сохраняет статус timed out, если выражение ещё вычисляется, и освобождает
+// его задачи так же, как при отмене. elapsed - время с создания выражения
+func (s *OrchestratorService) timeoutExpression(ctx context.Context, userID, expressionID uuid.UUID, elapsed time.Duration) {
+	err := s.storage.StopExpression(userID, expressionID, errs.ErrExpressionTimedOut.Error(),
+		fmt.Sprintf("not calculated within %s", elapsed.Round(time.Millisecond)))
+	if errors.Is(err, errs.ErrExpressionFinished) || errors.Is(err, errs.ErrExpressionNotFound) {
+		return
+	}
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to time out expression",
+			zap.String("userID", userID.String()),
+			zap.String("expressionID", expressionID.String()),
+			zap.Error(err))
+		return
+	}
+
+	event, _ := s.expressionManager.TimeoutExpression(expressionID, elapsed)
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("expression with id: %s timed out", expressionID),
+		zap.String("expressionID", expressionID.String()),
+		zap.Duration("elapsed", elapsed),
+		zap.Int("tasksDone", event.TasksDone),
+		zap.Int("tasksTotal", event.TasksTotal),
+	)
+}
+
+// syntaxErrorStatus переводит ошибку разбора выражения в gRPC статус,
+// позиция и описание ошибки передаются в деталях статуса
+func syntaxErrorStatus(err error) error {
+	if _, ok := helper.AsSyntaxError(err); !ok {
+		return err
+	}
+	st, detailsErr := status.New(codes.InvalidArgument, errs.ErrInvalidExpression.Error()).
+		WithDetails(syntaxErrorDetails(err))
+	if detailsErr != nil {
+		return err
+	}
+	return st.Err()
+}
+
+// syntaxErrorDetails описание ошибки разбора выражения, у ошибок без позиции Column равен 0
+func syntaxErrorDetails(err error) *orchestrator.SyntaxError {
+	if syntaxErr, ok := helper.AsSyntaxError(err); ok {
+		return &orchestrator.SyntaxError{
+			Column:  int32(syntaxErr.Column),
+			Message: syntaxErr.Message,
+		}
+	}
+	return &orchestrator.SyntaxError{Message: err.Error()}
+}
+
+// taskError возвращает ошибку вычисления, о которой сообщил агент, или nil
+func taskError(code orchestrator.TaskErrorCode) error {
+	switch code {
+	case orchestrator.TaskErrorCode_DIVISION_BY_ZERO:
+		return errs.ErrDivideByZero
+	case orchestrator.TaskErrorCode_INVALID_OPERATION:
+		return errs.ErrInvalidExpression
+	case orchestrator.TaskErrorCode_OUT_OF_DOMAIN:
+		return errs.ErrOutOfDomain
+	default:
+		return nil
+	}
+}
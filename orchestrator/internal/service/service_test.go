@@ -7,10 +7,22 @@ import (
 	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
 	"github.com/jaam8/web_calculator/common-lib/logger"
 	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/jaam8/web_calculator/orchestrator/internal/service/helper"
 	"github.com/jaam8/web_calculator/orchestrator/internal/service/types"
+	"github.com/jaam8/web_calculator/orchestrator/internal/service/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -20,6 +32,14 @@ type MockExpressionManager struct {
 	mock.Mock
 	tasks chan models.Task
 	mutex sync.Mutex
+	// queueErr возвращается из CheckQueue
+	queueErr error
+	// queued число выражений из последнего вызова CheckQueue
+	queued int
+	// cancels возвращается из WatchCancels
+	cancels chan uuid.UUID
+	// durations возвращается из OperationTime
+	durations map[string]time.Duration
 }
 
 func (m *MockExpressionManager) CreateExpression(expression *models.Expression) error {
@@ -27,6 +47,11 @@ func (m *MockExpressionManager) CreateExpression(expression *models.Expression)
 	return args.Error(0)
 }
 
+func (m *MockExpressionManager) RestoreExpression(expression *models.Expression, lastTaskID int) error {
+	args := m.Called(expression, lastTaskID)
+	return args.Error(0)
+}
+
 func (m *MockExpressionManager) GetTaskManager(exprID uuid.UUID) (types.TaskManager, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -37,8 +62,33 @@ func (m *MockExpressionManager) GetTaskManager(exprID uuid.UUID) (types.TaskMana
 	return args.Get(0).(types.TaskManager), args.Error(1)
 }
 
-func (m *MockExpressionManager) GetTasks() chan models.Task {
-	return m.tasks
+func (m *MockExpressionManager) LeaseTask() (models.Task, bool) {
+	select {
+	case task := <-m.tasks:
+		return task, true
+	default:
+		return models.Task{}, false
+	}
+}
+
+func (m *MockExpressionManager) MarkStarted(exprID uuid.UUID) bool {
+	args := m.Called(exprID)
+	return args.Bool(0)
+}
+
+func (m *MockExpressionManager) NextTask(ctx context.Context, operations []string) (models.Task, bool) {
+	m.Called(operations)
+	select {
+	case task := <-m.tasks:
+		return task, true
+	case <-ctx.Done():
+		return models.Task{}, false
+	}
+}
+
+func (m *MockExpressionManager) CompleteTask(exprID uuid.UUID, taskID int) bool {
+	args := m.Called(exprID, taskID)
+	return args.Bool(0)
 }
 
 func (m *MockExpressionManager) GetExpressions() []*models.Expression {
@@ -51,24 +101,89 @@ func (m *MockExpressionManager) GetExpression(id uuid.UUID) (*models.Expression,
 	return args.Get(0).(*models.Expression), args.Bool(1)
 }
 
-func (m *MockExpressionManager) AddTask(task models.Task) {
+func (m *MockExpressionManager) AddTask(task models.Task) bool {
 	m.tasks <- task
+	return true
+}
+
+func (m *MockExpressionManager) CheckQueue(_ uuid.UUID, expressions int) error {
+	m.queued = expressions
+	return m.queueErr
+}
+
+func (m *MockExpressionManager) OperationTime(operation string) time.Duration {
+	return m.durations[operation]
 }
 
 func (m *MockExpressionManager) ExpressionDone(exprID uuid.UUID, res float64) {
 	m.Called(exprID, res)
 }
 
-func (m *MockExpressionManager) ExpressionError(exprID uuid.UUID) {
-	m.Called(exprID)
+func (m *MockExpressionManager) ExpressionError(exprID uuid.UUID, err error) {
+	m.Called(exprID, err)
+}
+
+func (m *MockExpressionManager) CancelExpression(exprID uuid.UUID) bool {
+	args := m.Called(exprID)
+	return args.Bool(0)
+}
+
+func (m *MockExpressionManager) TimeoutExpression(exprID uuid.UUID, elapsed time.Duration) (models.ExpressionEvent, bool) {
+	args := m.Called(exprID, elapsed)
+	return args.Get(0).(models.ExpressionEvent), args.Bool(1)
+}
+
+func (m *MockExpressionManager) WatchCancels() (<-chan uuid.UUID, func()) {
+	return m.cancels, func() {}
+}
+
+func (m *MockExpressionManager) ExpressionProgress(exprID uuid.UUID, done, total int) {
+	m.Called(exprID, done, total)
+}
+
+func (m *MockExpressionManager) Watch(exprID uuid.UUID) (<-chan models.ExpressionEvent, func()) {
+	args := m.Called(exprID)
+	return args.Get(0).(chan models.ExpressionEvent), func() {}
+}
+
+type MockAgentRegistry struct {
+	mock.Mock
+}
+
+func (m *MockAgentRegistry) HeartbeatInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockAgentRegistry) Register(agent models.Agent) {
+	m.Called(agent)
+}
+
+func (m *MockAgentRegistry) Heartbeat(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAgentRegistry) Agent(id string) (models.Agent, bool) {
+	args := m.Called(id)
+	return args.Get(0).(models.Agent), args.Bool(1)
+}
+
+func (m *MockAgentRegistry) Agents() []models.Agent {
+	args := m.Called()
+	return args.Get(0).([]models.Agent)
+}
+
+func (m *MockAgentRegistry) AddBusy(id string, delta int) {
+	m.Called(id, delta)
 }
 
 type MockTaskManager struct {
 	mock.Mock
 }
 
-func (m *MockTaskManager) CreateTask(arg1, arg2 float64, oper string, exprID uuid.UUID) models.Task {
-	args := m.Called(arg1, arg2, oper, exprID)
+func (m *MockTaskManager) CreateTask(taskArgs []float64, oper string, exprID uuid.UUID) models.Task {
+	args := m.Called(taskArgs, oper, exprID)
 	return args.Get(0).(models.Task)
 }
 
@@ -90,8 +205,18 @@ func (m *MockStorageAdapter) SaveExpression(expr models.Expression) (uuid.UUID,
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
 
-func (m *MockStorageAdapter) GetExpressions(userID uuid.UUID) ([]*models.Expression, error) {
-	args := m.Called(userID)
+func (m *MockStorageAdapter) SaveExpressions(exprs []models.Expression) ([]uuid.UUID, error) {
+	args := m.Called(exprs)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockStorageAdapter) GetBatchProgress(userID, batchID uuid.UUID) (map[string]int, error) {
+	args := m.Called(userID, batchID)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockStorageAdapter) GetExpressions(userID uuid.UUID, params models.ExpressionsQuery) ([]*models.Expression, error) {
+	args := m.Called(userID, params)
 	return args.Get(0).([]*models.Expression), args.Error(1)
 }
 
@@ -105,15 +230,82 @@ func (m *MockStorageAdapter) UpdateExpression(userID, expressionID uuid.UUID, st
 	return args.Error(0)
 }
 
+func (m *MockStorageAdapter) UpdateExpressionDecimal(userID, expressionID uuid.UUID, status string, result string) error {
+	args := m.Called(userID, expressionID, status, result)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) FailExpression(userID, expressionID uuid.UUID, status, details string) error {
+	args := m.Called(userID, expressionID, status, details)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) StartExpression(expressionID uuid.UUID) error {
+	args := m.Called(expressionID)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) StopExpression(userID, expressionID uuid.UUID, status, details string) error {
+	args := m.Called(userID, expressionID, status, details)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) GetPendingExpressions() ([]*models.Expression, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Expression), args.Error(1)
+}
+
+func (m *MockStorageAdapter) SaveTask(task models.Task) error {
+	args := m.Called(task)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) StartTask(expressionID uuid.UUID, taskID int, agentID string) error {
+	args := m.Called(expressionID, taskID, agentID)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) SaveTaskResult(result models.Result) error {
+	args := m.Called(result)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) GetTasks(expressionID uuid.UUID) ([]*models.Task, error) {
+	args := m.Called(expressionID)
+	return args.Get(0).([]*models.Task), args.Error(1)
+}
+
+func (m *MockStorageAdapter) SaveVariable(variable models.Variable) error {
+	args := m.Called(variable)
+	return args.Error(0)
+}
+
+func (m *MockStorageAdapter) GetVariables(userID uuid.UUID) ([]*models.Variable, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]*models.Variable), args.Error(1)
+}
+
+func (m *MockStorageAdapter) GetVariable(userID uuid.UUID, name string) (*models.Variable, error) {
+	args := m.Called(userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Variable), args.Error(1)
+}
+
+func (m *MockStorageAdapter) DeleteVariable(userID uuid.UUID, name string) error {
+	args := m.Called(userID, name)
+	return args.Error(0)
+}
+
 // setupCommonMocks handles setting up mocks that may be needed across multiple tests due to goroutines
 func setupCommonMocks(taskManager *MockTaskManager, exprManager *MockExpressionManager) {
 	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 
-	taskManager.On("CreateTask", 3.0, 4.0, "+", exprID).Maybe().Return(models.Task{
+	taskManager.On("CreateTask", []float64{3.0, 4.0}, "+", exprID).Maybe().Return(models.Task{
 		ExpressionID:  exprID,
 		TaskID:        42,
-		Arg1:          3,
-		Arg2:          4,
+		Args:          []float64{3, 4},
 		Operation:     "+",
 		OperationTime: time.Second,
 	})
@@ -126,6 +318,7 @@ func setupCommonMocks(taskManager *MockTaskManager, exprManager *MockExpressionM
 
 	// Mock for ExpressionDone
 	exprManager.On("ExpressionDone", exprID, 7.0).Maybe().Return()
+	exprManager.On("ExpressionProgress", exprID, mock.Anything, mock.Anything).Maybe().Return()
 }
 
 func TestCalculate(t *testing.T) {
@@ -147,7 +340,38 @@ func TestCalculate(t *testing.T) {
 				// Set up common mocks that might be needed from goroutines
 				setupCommonMocks(taskManager, exprManager)
 
+				storage.On("GetVariables", userID).Return([]*models.Variable{}, nil)
 				storage.On("SaveExpression", mock.AnythingOfType("models.Expression")).Return(exprID, nil)
+				storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+				storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+				storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+
+				exprManager.On("CreateExpression", mock.AnythingOfType("*models.Expression")).Return(nil)
+				exprManager.On("GetTaskManager", exprID).Return(taskManager, nil)
+			},
+			expectedErr:    false,
+			expectedRespID: "00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name:       "success with variable",
+			expression: "x+4",
+			mockSetup: func(exprManager *MockExpressionManager, taskManager *MockTaskManager, storage *MockStorageAdapter) {
+				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+				userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+				status := "done"
+				result := 7.0
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskManager, exprManager)
+
+				storage.On("GetVariables", userID).Return([]*models.Variable{
+					{UserId: userID, Name: "x", Value: 3},
+				}, nil)
+				// сохраняется и присланный текст, и то, что вычислялось на самом деле
+				storage.On("SaveExpression", mock.MatchedBy(func(expr models.Expression) bool {
+					return expr.Source == "x+4" && expr.Normalized == "3 + 4"
+				})).Return(exprID, nil)
+				storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+				storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
 				storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
 
 				exprManager.On("CreateExpression", mock.AnythingOfType("*models.Expression")).Return(nil)
@@ -159,7 +383,8 @@ func TestCalculate(t *testing.T) {
 		{
 			name:       "invalid RPN expression",
 			expression: "",
-			mockSetup: func(_ *MockExpressionManager, taskManager *MockTaskManager, _ *MockStorageAdapter) {
+			mockSetup: func(_ *MockExpressionManager, taskManager *MockTaskManager, storage *MockStorageAdapter) {
+				storage.On("GetVariables", mock.Anything).Return([]*models.Variable{}, nil)
 			},
 			expectedErr: true,
 		},
@@ -173,7 +398,7 @@ func TestCalculate(t *testing.T) {
 			exprManager.tasks = make(chan models.Task, 1)
 
 			tt.mockSetup(exprManager, taskManager, storage)
-			service := NewOrchestratorService(storage, exprManager)
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
 
 			ctx := context.Background()
 			ctx, _ = logger.New(ctx)
@@ -260,7 +485,7 @@ func TestExpressionByID(t *testing.T) {
 			exprManager.tasks = make(chan models.Task, 1)
 
 			tt.mockSetup(storage, taskManager, exprManager)
-			service := NewOrchestratorService(storage, exprManager)
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
 
 			ctx := context.Background()
 			ctx, _ = logger.New(ctx)
@@ -291,173 +516,781 @@ func TestExpressionByID(t *testing.T) {
 	}
 }
 
-func TestGetTask(t *testing.T) {
+func TestExpressionTasks(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	queuedAt := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+	startedAt := queuedAt.Add(time.Second)
+	agentID := "agent-1"
+	result := 0.3
+	exact := "0.3"
+	failure := errors.ErrDivideByZero.Error()
+
 	tests := []struct {
-		name        string
-		setupMocks  func(taskManager *MockTaskManager, exprManager *MockExpressionManager)
-		setupChan   func() chan models.Task
-		expectedErr error
+		name      string
+		precision string
+		tasks     []*models.Task
+		getErr    error
+		wantErr   error
+		want      []*orchestrator.TaskTrace
 	}{
 		{
-			name: "empty task queue",
-			setupMocks: func(taskManager *MockTaskManager, exprManager *MockExpressionManager) {
-				// Set up common mocks that might be needed from goroutines
-				setupCommonMocks(taskManager, exprManager)
+			name:    "expression of another user",
+			getErr:  errors.ErrExpressionNotFound,
+			wantErr: errors.ErrExpressionNotFound,
+		},
+		{
+			name:      "decimal expression",
+			precision: models.PrecisionDecimal,
+			tasks: []*models.Task{
+				{
+					TaskID: 1, Operation: "+", Args: []float64{0.1, 0.2}, DecimalArgs: []string{"0.1", "0.2"},
+					Status: "done", Result: &result, DecimalResult: &exact, AgentID: &agentID, Attempts: 2,
+					QueuedAt: &queuedAt, StartedAt: &startedAt, FinishedAt: &startedAt,
+				},
+				{
+					TaskID: 2, Operation: "/", Args: []float64{0.3, 0}, DecimalArgs: []string{"0.3", "0"},
+					Status: "failed", Error: &failure, Attempts: 1, QueuedAt: &startedAt, StartedAt: &startedAt,
+				},
 			},
-			setupChan: func() chan models.Task {
-				return make(chan models.Task, 0)
+			want: []*orchestrator.TaskTrace{
+				{
+					Id: 1, Operation: "+", Args: []float64{0.1, 0.2}, DecimalArgs: []string{"0.1", "0.2"},
+					Status: "done", Result: &result, DecimalResult: &exact, AgentId: agentID, Attempts: 2,
+					QueuedAt: timestamppb.New(queuedAt), StartedAt: timestamppb.New(startedAt),
+					FinishedAt: timestamppb.New(startedAt),
+				},
+				{
+					Id: 2, Operation: "/", Args: []float64{0.3, 0}, DecimalArgs: []string{"0.3", "0"},
+					Status: "failed", Error: &failure, Attempts: 1,
+					QueuedAt: timestamppb.New(startedAt), StartedAt: timestamppb.New(startedAt),
+				},
 			},
-			expectedErr: errors.ErrTaskNotFound,
 		},
 		{
-			name: "task available",
-			setupMocks: func(taskManager *MockTaskManager, exprManager *MockExpressionManager) {
-				// Set up common mocks that might be needed from goroutines
-				setupCommonMocks(taskManager, exprManager)
+			name:      "queued float task",
+			precision: models.PrecisionFloat,
+			tasks: []*models.Task{
+				{TaskID: 1, Operation: "+", Args: []float64{1, 2}, DecimalArgs: []string{"1", "2"}, Status: "pending", QueuedAt: &queuedAt},
 			},
-			setupChan: func() chan models.Task {
-				ch := make(chan models.Task, 1)
-				ch <- models.Task{
-					ExpressionID:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
-					TaskID:        42,
-					Arg1:          3,
-					Arg2:          4,
-					Operation:     "+",
-					OperationTime: time.Second,
-				}
-				return ch
+			want: []*orchestrator.TaskTrace{
+				{Id: 1, Operation: "+", Args: []float64{1, 2}, Status: "pending", QueuedAt: timestamppb.New(queuedAt)},
 			},
-			expectedErr: nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exprManager := new(MockExpressionManager)
-			taskManager := new(MockTaskManager)
 			storage := new(MockStorageAdapter)
-
-			exprManager.tasks = tt.setupChan()
-
-			tt.setupMocks(taskManager, exprManager)
-
-			service := NewOrchestratorService(storage, exprManager)
-
-			ctx := context.Background()
-			ctx, _ = logger.New(ctx)
-
-			resp, err := service.GetTask(ctx, &emptypb.Empty{})
-			task := resp.GetTask()
-			assert.ErrorIs(t, err, tt.expectedErr)
-
-			if tt.expectedErr == nil {
-				assert.NotNil(t, task)
-				assert.Equal(t, "00000000-0000-0000-0000-000000000001", task.ExpressionId)
-				assert.Equal(t, int64(42), task.Id)
-				assert.Equal(t, 3.0, task.Arg1)
-				assert.Equal(t, 4.0, task.Arg2)
-				assert.Equal(t, "+", task.Operation)
+			storage.On("GetExpressionById", userID, exprID).Return(&models.Expression{
+				ExpressionID: exprID, UserId: userID, Precision: tt.precision,
+			}, tt.getErr)
+			if tt.getErr == nil {
+				storage.On("GetTasks", exprID).Return(tt.tasks, nil)
 			}
+			service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+			ctx, _ := logger.New(context.Background())
 
-			time.Sleep(100 * time.Millisecond)
+			resp, err := service.ExpressionTasks(ctx, &orchestrator.ExpressionTasksRequest{
+				UserId: userID.String(),
+				Id:     exprID.String(),
+			})
 
-			taskManager.AssertExpectations(t)
-			exprManager.AssertExpectations(t)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Len(t, resp.Tasks, len(tt.want))
+				for i := range tt.want {
+					assert.True(t, proto.Equal(tt.want[i], resp.Tasks[i]), "task %d: %v", i, resp.Tasks[i])
+				}
+			}
+			storage.AssertExpectations(t)
 		})
 	}
 }
 
-func TestResultTask(t *testing.T) {
+func TestCalculate_Rejected(t *testing.T) {
 	tests := []struct {
-		name           string
-		setupMocks     func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager)
-		request        *orchestrator.ResultTaskRequest
-		expectedError  error
-		expectedStatus string
+		name        string
+		priority    int32
+		timeout     *durationpb.Duration
+		queueErr    error
+		expectedErr error
 	}{
 		{
-			name: "task manager not found",
-			setupMocks: func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager) {
-				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000999")
-				exprMgr.On("GetTaskManager", exprID).Return(nil, errors.ErrTaskNotFound)
-
-				// Set up common mocks that might be needed from goroutines
-				setupCommonMocks(taskMgr, exprMgr)
-			},
-			request: &orchestrator.ResultTaskRequest{
-				ExpressionId: "00000000-0000-0000-0000-000000000999",
-				Id:           1,
-				Result:       7.0,
-			},
-			expectedError: errors.ErrTaskNotFound,
+			name:        "priority too high",
+			priority:    models.MaxPriority + 1,
+			expectedErr: errors.ErrInvalidPriority,
 		},
 		{
-			name: "success",
-			setupMocks: func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager) {
-				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-				exprMgr.On("GetTaskManager", exprID).Return(taskMgr, nil)
-				taskMgr.On("AddResult", mock.AnythingOfType("models.Result")).Return()
-
-				// Set up common mocks that might be needed from goroutines
-				setupCommonMocks(taskMgr, exprMgr)
-			},
-			request: &orchestrator.ResultTaskRequest{
-				ExpressionId: "00000000-0000-0000-0000-000000000001",
-				Id:           1,
-				Result:       7.0,
-			},
-			expectedError:  nil,
-			expectedStatus: "task completed",
+			name:        "negative priority",
+			priority:    -1,
+			expectedErr: errors.ErrInvalidPriority,
+		},
+		{
+			name:        "queue is full",
+			priority:    models.MaxPriority,
+			queueErr:    errors.ErrQueueFull,
+			expectedErr: errors.ErrQueueFull,
+		},
+		{
+			name:        "zero timeout",
+			timeout:     durationpb.New(0),
+			expectedErr: errors.ErrInvalidTimeout,
+		},
+		{
+			name:        "negative timeout",
+			timeout:     durationpb.New(-time.Second),
+			expectedErr: errors.ErrInvalidTimeout,
 		},
 	}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exprMgr := new(MockExpressionManager)
-			taskMgr := new(MockTaskManager)
+			// выражение отклоняется до обращения к хранилищу
 			storage := new(MockStorageAdapter)
-			exprMgr.tasks = make(chan models.Task, 1)
-
-			tt.setupMocks(exprMgr, taskMgr)
-
-			ctx := context.Background()
-			ctx, _ = logger.New(ctx)
-
-			service := NewOrchestratorService(storage, exprMgr)
-			resp, err := service.ResultTask(ctx, tt.request)
-
-			if tt.expectedError != nil {
-				assert.ErrorIs(t, err, tt.expectedError)
-				assert.Nil(t, resp)
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedStatus, resp.Status)
-			}
-
-			// Allow some time for goroutines to complete
-			time.Sleep(100 * time.Millisecond)
+			exprManager := &MockExpressionManager{queueErr: tt.queueErr}
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+			ctx, _ := logger.New(context.Background())
+
+			resp, err := service.Calculate(ctx, &orchestrator.CalculateRequest{
+				UserId:     "00000000-0000-0000-0000-000000000002",
+				Expression: "2+2",
+				Priority:   tt.priority,
+				Timeout:    tt.timeout,
+			})
 
-			exprMgr.AssertExpectations(t)
-			taskMgr.AssertExpectations(t)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, resp)
+			storage.AssertExpectations(t)
 		})
 	}
 }
 
-func TestExpressions(t *testing.T) {
-	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+func TestCalculateBatch(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	firstID := uuid.MustParse("00000000-0000-0000-0000-000000000011")
+	secondID := uuid.MustParse("00000000-0000-0000-0000-000000000012")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 2)
+
+	storage.On("GetVariables", userID).Return([]*models.Variable{}, nil)
+	storage.On("SaveExpressions", mock.MatchedBy(func(exprs []models.Expression) bool {
+		// выражения пакета сохраняются одним вызовом с общим BatchID
+		return len(exprs) == 2 && exprs[0].BatchID != nil && exprs[0].BatchID == exprs[1].BatchID &&
+			slices.Equal(exprs[0].RPN, []string{"1", "2", "+"}) &&
+			slices.Equal(exprs[1].RPN, []string{"3", "4", "*"}) &&
+			exprs[1].Priority == 3
+	})).Return([]uuid.UUID{firstID, secondID}, nil)
+	exprManager.On("CreateExpression", mock.AnythingOfType("*models.Expression")).Return(nil).Twice()
+	exprManager.On("GetTaskManager", firstID).Return(taskManager, nil)
+	exprManager.On("GetTaskManager", secondID).Return(taskManager, nil)
+
+	// Process запущенных выражений сразу узнаёт об отмене и завершается
+	taskManager.On("CreateTask", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(models.Task{})
+	taskManager.On("GetResult").Maybe().Return(models.Result{Err: errors.ErrExpressionCancelled})
+	storage.On("SaveTask", mock.Anything).Maybe().Return(nil)
+	exprManager.On("ExpressionProgress", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	resp, err := service.CalculateBatch(ctx, &orchestrator.CalculateBatchRequest{
+		UserId:      userID.String(),
+		Expressions: []string{"1+2", "2(3)", "3*4"},
+		Priority:    3,
+	})
+	assert.NoError(t, err)
+
+	_, err = uuid.Parse(resp.BatchId)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 3)
+	assert.Equal(t, firstID.String(), resp.Items[0].Id)
+	assert.Empty(t, resp.Items[1].Id)
+	assert.Equal(t, int32(2), resp.Items[1].GetError().GetColumn())
+	assert.Equal(t, secondID.String(), resp.Items[2].Id)
+	assert.Nil(t, resp.Items[2].Error)
+	// место в очереди проверяется под оба принятых выражения
+	assert.Equal(t, 2, exprManager.queued)
+
+	time.Sleep(100 * time.Millisecond)
+	storage.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
 
+func TestCalculateBatch_Rejected(t *testing.T) {
 	tests := []struct {
-		name          string
-		mockSetup     func(*MockStorageAdapter, *MockTaskManager, *MockExpressionManager)
-		expectedCount int
-		expectedError error
+		name        string
+		expressions []string
+		expectedErr error
 	}{
 		{
-			name: "get expressions success",
-			mockSetup: func(storage *MockStorageAdapter, taskManager *MockTaskManager, exprManager *MockExpressionManager) {
-				expressions := []*models.Expression{
-					{
-						ExpressionID: uuid.MustParse("00000000-0000-0000-0000-000000000011"),
+			name:        "empty batch",
+			expectedErr: errors.ErrInvalidBatch,
+		},
+		{
+			name:        "too large batch",
+			expressions: make([]string, models.MaxBatchSize+1),
+			expectedErr: errors.ErrInvalidBatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+			ctx, _ := logger.New(context.Background())
+
+			resp, err := service.CalculateBatch(ctx, &orchestrator.CalculateBatchRequest{
+				UserId:      "00000000-0000-0000-0000-000000000002",
+				Expressions: tt.expressions,
+			})
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, resp)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalculateBatch_StartFailed(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	firstID := uuid.MustParse("00000000-0000-0000-0000-000000000011")
+	secondID := uuid.MustParse("00000000-0000-0000-0000-000000000012")
+	startErr := assert.AnError
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 2)
+
+	storage.On("GetVariables", userID).Return([]*models.Variable{}, nil)
+	storage.On("SaveExpressions", mock.Anything).Return([]uuid.UUID{firstID, secondID}, nil)
+	exprManager.On("CreateExpression", mock.MatchedBy(func(expr *models.Expression) bool {
+		return expr.ExpressionID == firstID
+	})).Return(startErr).Once()
+	exprManager.On("CreateExpression", mock.MatchedBy(func(expr *models.Expression) bool {
+		return expr.ExpressionID == secondID
+	})).Return(nil).Once()
+	exprManager.On("GetTaskManager", secondID).Return(taskManager, nil)
+	// незапущенное выражение завершается с ошибкой, а не остаётся pending
+	storage.On("FailExpression", userID, firstID, errors.ErrInternalServerError.Error(), startErr.Error()).
+		Return(nil).Once()
+	exprManager.On("ExpressionError", firstID, errors.ErrInternalServerError).Once()
+
+	taskManager.On("CreateTask", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(models.Task{})
+	taskManager.On("GetResult").Maybe().Return(models.Result{Err: errors.ErrExpressionCancelled})
+	storage.On("SaveTask", mock.Anything).Maybe().Return(nil)
+	exprManager.On("ExpressionProgress", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	resp, err := service.CalculateBatch(ctx, &orchestrator.CalculateBatchRequest{
+		UserId:      userID.String(),
+		Expressions: []string{"1+2", "3*4"},
+	})
+
+	// второе выражение запущено, а ошибка первого видна в его элементе
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, firstID.String(), resp.Items[0].Id)
+	assert.Equal(t, errors.ErrInternalServerError.Error(), resp.Items[0].GetError().GetMessage())
+	assert.Equal(t, secondID.String(), resp.Items[1].Id)
+	assert.Nil(t, resp.Items[1].Error)
+
+	time.Sleep(100 * time.Millisecond)
+	storage.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestCalculateBatch_QueueFull(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	storage := new(MockStorageAdapter)
+	storage.On("GetVariables", userID).Return([]*models.Variable{}, nil)
+	exprManager := &MockExpressionManager{queueErr: errors.ErrQueueFull}
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	resp, err := service.CalculateBatch(ctx, &orchestrator.CalculateBatchRequest{
+		UserId:      userID.String(),
+		Expressions: []string{"1+2", "1+", "3*4", "5-6"},
+	})
+
+	// пакет отклоняется целиком до сохранения выражений
+	assert.ErrorIs(t, err, errors.ErrQueueFull)
+	assert.Nil(t, resp)
+	assert.Equal(t, 3, exprManager.queued)
+	storage.AssertExpectations(t)
+}
+
+func TestCalculateBatch_NothingAccepted(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	storage := new(MockStorageAdapter)
+	storage.On("GetVariables", userID).Return([]*models.Variable{}, nil)
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+	resp, err := service.CalculateBatch(ctx, &orchestrator.CalculateBatchRequest{
+		UserId:      userID.String(),
+		Expressions: []string{"1+", "x*2"},
+	})
+
+	// без принятых выражений пакет не создаётся и в хранилище ничего не пишется
+	assert.NoError(t, err)
+	assert.Empty(t, resp.BatchId)
+	assert.Len(t, resp.Items, 2)
+	for _, item := range resp.Items {
+		assert.Empty(t, item.Id)
+		assert.NotEmpty(t, item.GetError().GetMessage())
+	}
+	storage.AssertExpectations(t)
+}
+
+func TestBatchProgress(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	batchID := uuid.MustParse("00000000-0000-0000-0000-000000000020")
+
+	tests := []struct {
+		name        string
+		statuses    map[string]int
+		storageErr  error
+		expected    *orchestrator.BatchProgressResponse
+		expectedErr error
+	}{
+		{
+			name:     "success",
+			statuses: map[string]int{"pending": 2, "done": 3, "division by zero": 1, "timed out": 1},
+			expected: &orchestrator.BatchProgressResponse{
+				BatchId: batchID.String(),
+				Total:   7,
+				Pending: 2,
+				Done:    3,
+				Failed:  2,
+				Statuses: map[string]int32{
+					"pending": 2, "done": 3, "division by zero": 1, "timed out": 1,
+				},
+			},
+		},
+		{
+			name:        "not found",
+			storageErr:  errors.ErrBatchNotFound,
+			expectedErr: errors.ErrBatchNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			storage.On("GetBatchProgress", userID, batchID).Return(tt.statuses, tt.storageErr)
+			ctx, _ := logger.New(context.Background())
+			service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+
+			resp, err := service.BatchProgress(ctx, &orchestrator.BatchProgressRequest{
+				UserId:  userID.String(),
+				BatchId: batchID.String(),
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.Total, resp.Total)
+			assert.Equal(t, tt.expected.Pending, resp.Pending)
+			assert.Equal(t, tt.expected.Done, resp.Done)
+			assert.Equal(t, tt.expected.Failed, resp.Failed)
+			assert.Equal(t, tt.expected.Statuses, resp.Statuses)
+			assert.Equal(t, tt.expected.BatchId, resp.BatchId)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalculate_SyntaxError(t *testing.T) {
+	storage := new(MockStorageAdapter)
+	storage.On("GetVariables", mock.Anything).Return([]*models.Variable{}, nil)
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+	ctx, _ := logger.New(context.Background())
+
+	_, err := service.Calculate(ctx, &orchestrator.CalculateRequest{
+		UserId:     "00000000-0000-0000-0000-000000000002",
+		Expression: "2(3)",
+	})
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, errors.ErrInvalidExpression.Error(), st.Message())
+	assert.Len(t, st.Details(), 1)
+	details, ok := st.Details()[0].(*orchestrator.SyntaxError)
+	assert.True(t, ok)
+	assert.Equal(t, int32(2), details.Column)
+	assert.Equal(t, "unexpected '(', expected operator", details.Message)
+}
+
+func TestExplain(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	tests := []struct {
+		name          string
+		agents        []models.Agent
+		wantAgents    int32
+		wantSlots     int32
+		wantEstimated *durationpb.Duration
+	}{
+		{
+			name:          "tasks computed in parallel",
+			agents:        []models.Agent{{ID: "a", Capacity: 1}, {ID: "b", Capacity: 1}},
+			wantAgents:    2,
+			wantSlots:     2,
+			wantEstimated: durationpb.New(600 * time.Millisecond),
+		},
+		{
+			name:          "one slot",
+			agents:        []models.Agent{{ID: "a", Capacity: 1}},
+			wantAgents:    1,
+			wantSlots:     1,
+			wantEstimated: durationpb.New(900 * time.Millisecond),
+		},
+		{
+			name:   "no agents",
+			agents: []models.Agent{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// ничего не сохраняется и не отправляется агентам
+			storage := new(MockStorageAdapter)
+			storage.On("GetVariables", userID).Return([]*models.Variable{
+				{UserId: userID, Name: "x", Value: 4},
+			}, nil)
+			exprManager := &MockExpressionManager{durations: map[string]time.Duration{
+				"*": 300 * time.Millisecond, "sqrt": 500 * time.Millisecond, "+": 100 * time.Millisecond,
+			}}
+			agents := new(MockAgentRegistry)
+			agents.On("Agents").Return(tt.agents)
+			service := NewOrchestratorService(storage, exprManager, agents, 0, nil, false)
+			ctx, _ := logger.New(context.Background())
+
+			resp, err := service.Explain(ctx, &orchestrator.ExplainRequest{
+				UserId:     userID.String(),
+				Expression: "2*3+sqrt(x)",
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"2", "3", "*", "4", "sqrt:1", "+"}, resp.Rpn)
+			assert.Len(t, resp.Tasks, 3)
+			last := resp.Tasks[2]
+			assert.Equal(t, "+", last.Operation)
+			assert.Equal(t, []int32{2, 4}, []int32{last.Operands[0].GetTaskId(), last.Operands[1].GetTaskId()})
+			assert.Equal(t, "4", resp.Tasks[1].Operands[0].GetConstant())
+			assert.Equal(t, 500*time.Millisecond, last.EarliestStart.AsDuration())
+			assert.Equal(t, []int32{4, 5}, resp.CriticalPath)
+			assert.Equal(t, 600*time.Millisecond, resp.CriticalPathTime.AsDuration())
+			assert.Equal(t, tt.wantAgents, resp.Agents)
+			assert.Equal(t, tt.wantSlots, resp.Slots)
+			assert.Equal(t, tt.wantEstimated.AsDuration(), resp.EstimatedTime.AsDuration())
+			assert.Equal(t, tt.wantEstimated == nil, resp.EstimatedTime == nil)
+			storage.AssertExpectations(t)
+			agents.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExplain_SyntaxError(t *testing.T) {
+	storage := new(MockStorageAdapter)
+	storage.On("GetVariables", mock.Anything).Return([]*models.Variable{}, nil)
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+	ctx, _ := logger.New(context.Background())
+
+	_, err := service.Explain(ctx, &orchestrator.ExplainRequest{
+		UserId:     "00000000-0000-0000-0000-000000000002",
+		Expression: "2+",
+	})
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
+
+func TestCalculate_Simplify(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 2)
+
+	storage.On("GetVariables", userID).Return([]*models.Variable{
+		{UserId: userID, Name: "x", Value: 3},
+	}, nil)
+	// x * 1 и 2 * 8 не становятся задачами, агентам остаются sqrt и сложение
+	storage.On("SaveExpression", mock.MatchedBy(func(expr models.Expression) bool {
+		return expr.TasksSaved == 2 && slices.Equal(expr.RPN, []string{"3", "16", "sqrt:1", "+"})
+	})).Return(exprID, nil)
+	taskManager.On("CreateTask", []float64{16.0}, "sqrt", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{16}, Operation: "sqrt",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 1, Result: 4}).Once()
+	taskManager.On("CreateTask", []float64{3.0, 4.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 2, Args: []float64{3, 4}, Operation: "+",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 2, Result: 7}).Once()
+
+	status := "done"
+	result := 7.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("CreateExpression", mock.AnythingOfType("*models.Expression")).Return(nil)
+	exprManager.On("GetTaskManager", exprID).Return(taskManager, nil)
+	exprManager.On("ExpressionProgress", exprID, mock.Anything, 2).Return()
+	exprManager.On("ExpressionDone", exprID, 7.0).Return()
+
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, true)
+	ctx, _ := logger.New(context.Background())
+
+	resp, err := service.Calculate(ctx, &orchestrator.CalculateRequest{
+		UserId:     userID.String(),
+		Expression: "x*1+sqrt(2*8)",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, exprID.String(), resp.Id)
+
+	// Allow some time for goroutines to complete
+	time.Sleep(100 * time.Millisecond)
+
+	exprManager.AssertExpectations(t)
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+}
+
+func TestGetTask(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMocks  func(taskManager *MockTaskManager, exprManager *MockExpressionManager, storage *MockStorageAdapter)
+		setupChan   func() chan models.Task
+		expectedErr error
+	}{
+		{
+			name: "empty task queue",
+			setupMocks: func(taskManager *MockTaskManager, exprManager *MockExpressionManager, storage *MockStorageAdapter) {
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskManager, exprManager)
+			},
+			setupChan: func() chan models.Task {
+				return make(chan models.Task, 0)
+			},
+			expectedErr: errors.ErrTaskNotFound,
+		},
+		{
+			name: "task available",
+			setupMocks: func(taskManager *MockTaskManager, exprManager *MockExpressionManager, storage *MockStorageAdapter) {
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskManager, exprManager)
+				// первая выданная задача отмечает начало вычисления выражения
+				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+				exprManager.On("MarkStarted", exprID).Return(true)
+				storage.On("StartExpression", exprID).Return(nil)
+				// задача взята через GetTask, агент неизвестен
+				storage.On("StartTask", exprID, 42, "").Return(nil)
+			},
+			setupChan: func() chan models.Task {
+				ch := make(chan models.Task, 1)
+				ch <- models.Task{
+					ExpressionID:  uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+					TaskID:        42,
+					Args:          []float64{3, 4},
+					Operation:     "+",
+					OperationTime: time.Second,
+				}
+				return ch
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprManager := new(MockExpressionManager)
+			taskManager := new(MockTaskManager)
+			storage := new(MockStorageAdapter)
+
+			exprManager.tasks = tt.setupChan()
+
+			tt.setupMocks(taskManager, exprManager, storage)
+
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+
+			ctx := context.Background()
+			ctx, _ = logger.New(ctx)
+
+			resp, err := service.GetTask(ctx, &emptypb.Empty{})
+			task := resp.GetTask()
+			assert.ErrorIs(t, err, tt.expectedErr)
+
+			if tt.expectedErr == nil {
+				assert.NotNil(t, task)
+				assert.Equal(t, "00000000-0000-0000-0000-000000000001", task.ExpressionId)
+				assert.Equal(t, int64(42), task.Id)
+				assert.Equal(t, []float64{3, 4}, task.Args)
+				assert.Equal(t, "+", task.Operation)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+
+			taskManager.AssertExpectations(t)
+			exprManager.AssertExpectations(t)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResultTask(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager)
+		request        *orchestrator.ResultTaskRequest
+		expectedError  error
+		expectedStatus string
+	}{
+		{
+			name: "task manager not found",
+			setupMocks: func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager) {
+				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000999")
+				exprMgr.On("GetTaskManager", exprID).Return(nil, errors.ErrTaskNotFound)
+				// аренда задачи остановленного выражения снимается
+				exprMgr.On("CompleteTask", exprID, 1).Return(false).Once()
+
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskMgr, exprMgr)
+			},
+			request: &orchestrator.ResultTaskRequest{
+				ExpressionId: "00000000-0000-0000-0000-000000000999",
+				Id:           1,
+				Result:       7.0,
+			},
+			expectedError: errors.ErrTaskNotFound,
+		},
+		{
+			name: "success",
+			setupMocks: func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager) {
+				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+				exprMgr.On("GetTaskManager", exprID).Return(taskMgr, nil)
+				exprMgr.On("CompleteTask", exprID, 1).Return(true)
+				taskMgr.On("AddResult", mock.AnythingOfType("models.Result")).Return()
+
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskMgr, exprMgr)
+			},
+			request: &orchestrator.ResultTaskRequest{
+				ExpressionId: "00000000-0000-0000-0000-000000000001",
+				Id:           1,
+				Result:       7.0,
+			},
+			expectedError:  nil,
+			expectedStatus: "task completed",
+		},
+		{
+			name: "agent failed task",
+			setupMocks: func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager) {
+				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+				exprMgr.On("GetTaskManager", exprID).Return(taskMgr, nil)
+				exprMgr.On("CompleteTask", exprID, 1).Return(true)
+				taskMgr.On("AddResult", models.Result{
+					ExpressionID: exprID, TaskID: 1, Err: errors.ErrDivideByZero,
+				}).Return()
+
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskMgr, exprMgr)
+			},
+			request: &orchestrator.ResultTaskRequest{
+				ExpressionId: "00000000-0000-0000-0000-000000000001",
+				Id:           1,
+				ErrorCode:    orchestrator.TaskErrorCode_DIVISION_BY_ZERO,
+				ErrorMessage: "division by zero",
+			},
+			expectedError:  nil,
+			expectedStatus: "task completed",
+		},
+		{
+			// TaskManager отменённого выражения уже освобождён
+			name:       "agent abandoned task of cancelled expression",
+			setupMocks: func(_ *MockExpressionManager, _ *MockTaskManager) {},
+			request: &orchestrator.ResultTaskRequest{
+				ExpressionId: "00000000-0000-0000-0000-000000000001",
+				Id:           1,
+				ErrorCode:    orchestrator.TaskErrorCode_CANCELLED,
+			},
+			expectedError:  nil,
+			expectedStatus: "task cancelled",
+		},
+		{
+			name: "duplicate result after re-delivery",
+			setupMocks: func(exprMgr *MockExpressionManager, taskMgr *MockTaskManager) {
+				exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+				exprMgr.On("GetTaskManager", exprID).Return(taskMgr, nil)
+				exprMgr.On("CompleteTask", exprID, 1).Return(false)
+
+				// Set up common mocks that might be needed from goroutines
+				setupCommonMocks(taskMgr, exprMgr)
+			},
+			request: &orchestrator.ResultTaskRequest{
+				ExpressionId: "00000000-0000-0000-0000-000000000001",
+				Id:           1,
+				Result:       7.0,
+			},
+			expectedError:  nil,
+			expectedStatus: "task already completed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprMgr := new(MockExpressionManager)
+			taskMgr := new(MockTaskManager)
+			storage := new(MockStorageAdapter)
+			exprMgr.tasks = make(chan models.Task, 1)
+
+			tt.setupMocks(exprMgr, taskMgr)
+
+			ctx := context.Background()
+			ctx, _ = logger.New(ctx)
+
+			service := NewOrchestratorService(storage, exprMgr, new(MockAgentRegistry), 0, nil, false)
+			resp, err := service.ResultTask(ctx, tt.request)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, resp.Status)
+			}
+
+			// Allow some time for goroutines to complete
+			time.Sleep(100 * time.Millisecond)
+
+			exprMgr.AssertExpectations(t)
+			taskMgr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExpressions(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockStorageAdapter, *MockTaskManager, *MockExpressionManager)
+		expectedCount int
+		expectedError error
+	}{
+		{
+			name: "get expressions success",
+			mockSetup: func(storage *MockStorageAdapter, taskManager *MockTaskManager, exprManager *MockExpressionManager) {
+				expressions := []*models.Expression{
+					{
+						ExpressionID: uuid.MustParse("00000000-0000-0000-0000-000000000011"),
 						UserId:       userID,
 						Status:       "done",
 						Result:       func() *float64 { r := 7.0; return &r }(),
@@ -469,7 +1302,7 @@ func TestExpressions(t *testing.T) {
 						Result:       func() *float64 { r := 3.0; return &r }(),
 					},
 				}
-				storage.On("GetExpressions", userID).Return(expressions, nil)
+				storage.On("GetExpressions", userID, mock.Anything).Return(expressions, nil)
 
 				// Set up common mocks that might be needed from goroutines
 				setupCommonMocks(taskManager, exprManager)
@@ -480,7 +1313,7 @@ func TestExpressions(t *testing.T) {
 		{
 			name: "no expressions found",
 			mockSetup: func(storage *MockStorageAdapter, taskManager *MockTaskManager, exprManager *MockExpressionManager) {
-				storage.On("GetExpressions", userID).Return([]*models.Expression{}, errors.ErrExpressionNotFound)
+				storage.On("GetExpressions", userID, mock.Anything).Return([]*models.Expression{}, errors.ErrExpressionNotFound)
 
 				// Set up common mocks that might be needed from goroutines
 				setupCommonMocks(taskManager, exprManager)
@@ -499,7 +1332,7 @@ func TestExpressions(t *testing.T) {
 
 			tt.mockSetup(storage, taskManager, exprMgr)
 
-			service := NewOrchestratorService(storage, exprMgr)
+			service := NewOrchestratorService(storage, exprMgr, new(MockAgentRegistry), 0, nil, false)
 
 			ctx := context.Background()
 			ctx, _ = logger.New(ctx)
@@ -524,3 +1357,1085 @@ func TestExpressions(t *testing.T) {
 		})
 	}
 }
+
+func TestExpressions_Pagination(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	createdAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	finishedAt := createdAt.Add(time.Second)
+	page := []*models.Expression{
+		{ExpressionID: uuid.MustParse("00000000-0000-0000-0000-000000000013"), Status: "pending", CreatedAt: createdAt.Add(2 * time.Minute)},
+		{ExpressionID: uuid.MustParse("00000000-0000-0000-0000-000000000012"), Status: "done", CreatedAt: createdAt.Add(time.Minute), FinishedAt: &finishedAt},
+		// лишнее выражение говорит о следующей странице и в ответ не попадает
+		{ExpressionID: uuid.MustParse("00000000-0000-0000-0000-000000000011"), Status: "done", CreatedAt: createdAt},
+	}
+	from := createdAt.Add(-time.Hour)
+
+	storage := new(MockStorageAdapter)
+	storage.On("GetExpressions", userID, models.ExpressionsQuery{
+		Limit:       3,
+		Statuses:    []string{"pending", "done"},
+		CreatedFrom: &from,
+	}).Return(page, nil).Once()
+	storage.On("GetExpressions", userID, models.ExpressionsQuery{
+		Limit:       3,
+		After:       &models.ExpressionsCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ExpressionID},
+		Statuses:    []string{"pending", "done"},
+		CreatedFrom: &from,
+	}).Return(page[2:], nil).Once()
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+	request := &orchestrator.ExpressionsRequest{
+		UserId:      userID.String(),
+		Limit:       2,
+		Statuses:    []string{"pending", "done"},
+		CreatedFrom: timestamppb.New(from),
+	}
+
+	resp, err := service.Expressions(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Expressions, 2)
+	assert.NotEmpty(t, resp.NextCursor)
+	assert.Equal(t, createdAt.Add(2*time.Minute), resp.Expressions[0].GetCreatedAt().AsTime())
+	assert.Nil(t, resp.Expressions[0].FinishedAt)
+	assert.Equal(t, finishedAt, resp.Expressions[1].GetFinishedAt().AsTime())
+
+	request.Cursor = resp.NextCursor
+	resp, err = service.Expressions(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Expressions, 1)
+	assert.Empty(t, resp.NextCursor)
+	storage.AssertExpectations(t)
+}
+
+func TestExpressions_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *orchestrator.ExpressionsRequest
+		expectedErr error
+	}{
+		{
+			name:        "page too large",
+			request:     &orchestrator.ExpressionsRequest{Limit: models.MaxPageSize + 1},
+			expectedErr: errors.ErrInvalidPageSize,
+		},
+		{
+			name:        "negative page size",
+			request:     &orchestrator.ExpressionsRequest{Limit: -1},
+			expectedErr: errors.ErrInvalidPageSize,
+		},
+		{
+			name:        "malformed cursor",
+			request:     &orchestrator.ExpressionsRequest{Cursor: "not a cursor"},
+			expectedErr: errors.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			ctx, _ := logger.New(context.Background())
+			service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+
+			tt.request.UserId = "00000000-0000-0000-0000-000000000001"
+			resp, err := service.Expressions(ctx, tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, resp)
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProcess_ParallelDispatch(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 3)
+
+	taskManager.On("CreateTask", []float64{1.0, 2.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{1, 2}, Operation: "+",
+	}).Once()
+	taskManager.On("CreateTask", []float64{3.0, 4.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 2, Args: []float64{3, 4}, Operation: "+",
+	}).Once()
+	taskManager.On("CreateTask", []float64{3.0, 7.0}, "*", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 3, Args: []float64{3, 7}, Operation: "*",
+	}).Once()
+
+	// результаты независимых задач приходят в обратном порядке
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 2, Result: 7}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 1, Result: 3}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 3, Result: 21}).Once()
+
+	status := "done"
+	result := 21.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("ExpressionDone", exprID, 21.0).Return()
+	exprManager.On("ExpressionProgress", exprID, 0, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 1, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 2, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 3, 3).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"1", "2", "+", "3", "4", "+", "*"})
+	assert.NoError(t, err)
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	first, second, third := <-exprManager.tasks, <-exprManager.tasks, <-exprManager.tasks
+	assert.Equal(t, []int{1, 2}, []int{first.TaskID, second.TaskID})
+	assert.Equal(t, 3, third.TaskID)
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestProcess_TaskError(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 2)
+
+	taskManager.On("CreateTask", []float64{1.0, 0.0}, "/", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{1, 0}, Operation: "/",
+	}).Once()
+	taskManager.On("CreateTask", []float64{3.0, 4.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 2, Args: []float64{3, 4}, Operation: "+",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{
+		ExpressionID: exprID, TaskID: 1, Err: errors.ErrDivideByZero,
+	}).Once()
+
+	status := "division by zero"
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	// подробности ошибки называют операцию, на которой она произошла
+	storage.On("FailExpression", userID, exprID, status, "division by zero in 1 / 0").Return(nil)
+	// ошибка агента остаётся в истории задач
+	storage.On("SaveTaskResult", models.Result{ExpressionID: exprID, TaskID: 1, Err: errors.ErrDivideByZero}).Return(nil).Once()
+	exprManager.On("ExpressionError", exprID, errors.ErrDivideByZero).Return()
+	exprManager.On("ExpressionProgress", exprID, 0, 3).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"1", "0", "/", "3", "4", "+", "*"})
+	assert.NoError(t, err)
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestProcess_Cancelled(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 1)
+
+	taskManager.On("CreateTask", []float64{1.0, 2.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{1, 2}, Operation: "+",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{
+		ExpressionID: exprID, Err: errors.ErrExpressionCancelled,
+	}).Once()
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	exprManager.On("ExpressionProgress", exprID, 0, 2).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"1", "2", "+", "3", "*"})
+	assert.NoError(t, err)
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	// статус cancelled уже сохранён CancelExpression, планирование просто прекращается
+	storage.AssertNotCalled(t, "UpdateExpression", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestWatchDeadline(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	timedOut := errors.ErrExpressionTimedOut.Error()
+
+	tests := []struct {
+		name       string
+		storageErr error
+		timedOut   bool
+	}{
+		{
+			name:     "pending expression times out",
+			timedOut: true,
+		},
+		{
+			name:       "expression finished in time",
+			storageErr: errors.ErrExpressionFinished,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			exprManager := new(MockExpressionManager)
+			stopped := make(chan struct{})
+			details := mock.MatchedBy(func(details string) bool {
+				return strings.HasPrefix(details, "not calculated within ")
+			})
+			storage.On("StopExpression", userID, exprID, timedOut, details).Return(tt.storageErr).
+				Run(func(mock.Arguments) {
+					if !tt.timedOut {
+						close(stopped)
+					}
+				})
+			if tt.timedOut {
+				exprManager.On("TimeoutExpression", exprID, mock.AnythingOfType("time.Duration")).
+					Return(models.ExpressionEvent{ExpressionID: exprID, Status: timedOut, TasksDone: 1, TasksTotal: 3}, true).
+					Run(func(args mock.Arguments) {
+						// срок истёк, пока оркестратор не работал: elapsed считается с создания выражения
+						assert.GreaterOrEqual(t, args.Get(1).(time.Duration), 3*time.Second)
+						close(stopped)
+					})
+			}
+
+			ctx, _ := logger.New(context.Background())
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+			deadline := time.Now().Add(-time.Second)
+			service.watchDeadline(ctx, &models.Expression{
+				UserId:       userID,
+				ExpressionID: exprID,
+				Deadline:     &deadline,
+				Timeout:      2 * time.Second,
+			})
+
+			select {
+			case <-stopped:
+			case <-time.After(time.Second):
+				t.Fatal("deadline did not fire")
+			}
+			storage.AssertExpectations(t)
+			exprManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_StopsDeadline(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	storage := new(MockStorageAdapter)
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	exprManager.tasks = make(chan models.Task, 1)
+	finished := make(chan struct{})
+
+	// Process сразу узнаёт об отмене и завершается раньше deadline
+	taskManager.On("CreateTask", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(models.Task{})
+	taskManager.On("GetResult").Return(models.Result{Err: errors.ErrExpressionCancelled}).
+		Run(func(mock.Arguments) { close(finished) }).Once()
+	storage.On("SaveTask", mock.Anything).Maybe().Return(nil)
+	exprManager.On("ExpressionProgress", mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	storage.On("StopExpression", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe().
+		Return(errors.ErrExpressionFinished)
+
+	dag, err := helper.BuildDAG([]string{"1", "2", "+"})
+	require.NoError(t, err)
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	deadline := time.Now().Add(100 * time.Millisecond)
+	service.run(ctx, taskManager, dag, &models.Expression{
+		UserId:       userID,
+		ExpressionID: exprID,
+		Deadline:     &deadline,
+		Timeout:      100 * time.Millisecond,
+	})
+
+	<-finished
+	time.Sleep(200 * time.Millisecond)
+	// таймер остановлен вместе с Process и не пытается завершить выражение по сроку
+	storage.AssertNotCalled(t, "StopExpression", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelExpression(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	tests := []struct {
+		name        string
+		mockSetup   func(storage *MockStorageAdapter, exprManager *MockExpressionManager)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			mockSetup: func(storage *MockStorageAdapter, exprManager *MockExpressionManager) {
+				storage.On("StopExpression", userID, exprID, "cancelled", "").Return(nil)
+				exprManager.On("CancelExpression", exprID).Return(true)
+			},
+		},
+		{
+			name: "not found",
+			mockSetup: func(storage *MockStorageAdapter, exprManager *MockExpressionManager) {
+				storage.On("StopExpression", userID, exprID, "cancelled", "").Return(errors.ErrExpressionNotFound)
+			},
+			expectedErr: errors.ErrExpressionNotFound,
+		},
+		{
+			name: "already finished",
+			mockSetup: func(storage *MockStorageAdapter, exprManager *MockExpressionManager) {
+				storage.On("StopExpression", userID, exprID, "cancelled", "").Return(errors.ErrExpressionFinished)
+			},
+			expectedErr: errors.ErrExpressionFinished,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			exprManager := new(MockExpressionManager)
+			tt.mockSetup(storage, exprManager)
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+			ctx, _ := logger.New(context.Background())
+
+			resp, err := service.CancelExpression(ctx, &orchestrator.CancelExpressionRequest{
+				UserId: userID.String(),
+				Id:     exprID.String(),
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+				exprManager.AssertNotCalled(t, "CancelExpression", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, resp)
+			}
+			storage.AssertExpectations(t)
+			exprManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProcess_Decimal(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 1)
+
+	taskManager.On("CreateTask", []float64{0.1, 0.2}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{0.1, 0.2}, Operation: "+",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{
+		ExpressionID: exprID, TaskID: 1, Result: 0.3, Decimal: "0.3",
+	}).Once()
+
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpressionDecimal", userID, exprID, "done", "0.3").Return(nil)
+	exprManager.On("ExpressionDone", exprID, 0.3).Return()
+	exprManager.On("ExpressionProgress", exprID, 0, 1).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 1, 1).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"0.1", "0.2", "+"})
+	assert.NoError(t, err)
+	dag.Decimal = true
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	task := <-exprManager.tasks
+	assert.Equal(t, []string{"0.1", "0.2"}, task.DecimalArgs)
+
+	storage.AssertNotCalled(t, "UpdateExpression", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+// fakeCache кэш результатов в map, Process вызывает его из одной горутины
+type fakeCache struct {
+	results map[string]models.CachedResult
+}
+
+func (c *fakeCache) GetResults(keys []string) ([]*models.CachedResult, error) {
+	results := make([]*models.CachedResult, len(keys))
+	for i, key := range keys {
+		if result, ok := c.results[key]; ok {
+			results[i] = &result
+		}
+	}
+	return results, nil
+}
+
+func (c *fakeCache) SaveResult(key string, result models.CachedResult) error {
+	c.results[key] = result
+	return nil
+}
+
+func TestProcess_Cache(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 1)
+
+	// (4+3)*(2+1) посчитано раньше как (1+2)*(3+4), агентам остаётся только вычитание
+	cache := &fakeCache{results: map[string]models.CachedResult{
+		"float:1 2 + 3 4 + *": {Value: 21},
+	}}
+	taskManager.On("CreateTask", []float64{21.0, 5.0}, "-", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{21, 5}, Operation: "-",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 1, Result: 16}).Once()
+
+	status := "done"
+	result := 16.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("ExpressionDone", exprID, 16.0).Return()
+	exprManager.On("ExpressionProgress", exprID, 3, 4).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 4, 4).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"4", "3", "+", "2", "1", "+", "*", "5", "-"})
+	assert.NoError(t, err)
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, cache, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	assert.Equal(t, 16.0, cache.results["float:1 2 + 3 4 + * 5 -"].Value)
+	stats, err := service.CacheStats(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.True(t, stats.Enabled)
+	assert.Equal(t, []int64{3, 1, 0}, []int64{stats.Hits, stats.Misses, stats.Expressions})
+	assert.Equal(t, 0.75, stats.HitRate)
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestProcess_CachedExpression(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	cache := &fakeCache{results: map[string]models.CachedResult{
+		"decimal:0.1 0.2 +": {Decimal: "0.3"},
+	}}
+
+	// результат всего выражения в кэше: задачи не создаются, выражение сразу вычислено
+	storage.On("UpdateExpressionDecimal", userID, exprID, "done", "0.3").Return(nil)
+	exprManager.On("ExpressionDone", exprID, 0.3).Return()
+	exprManager.On("ExpressionProgress", exprID, 1, 1).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"0.2", "0.1", "+"})
+	assert.NoError(t, err)
+	dag.Decimal = true
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, cache, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	stats, err := service.CacheStats(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 0, 1}, []int64{stats.Hits, stats.Misses, stats.Expressions})
+
+	taskManager.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything, mock.Anything)
+	storage.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestProcess_NoCache(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 1)
+	cache := &fakeCache{results: map[string]models.CachedResult{
+		"float:1 2 +": {Value: 4},
+	}}
+
+	// кэш не читается, но свежий результат в него сохраняется
+	taskManager.On("CreateTask", []float64{1.0, 2.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{1, 2}, Operation: "+",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 1, Result: 3}).Once()
+
+	status := "done"
+	result := 3.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("ExpressionDone", exprID, 3.0).Return()
+	exprManager.On("ExpressionProgress", exprID, 0, 1).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 1, 1).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"1", "2", "+"})
+	assert.NoError(t, err)
+	dag.NoCache = true
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, cache, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	assert.Equal(t, 3.0, cache.results["float:1 2 +"].Value)
+	stats, err := service.CacheStats(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 0}, []int64{stats.Hits, stats.Misses})
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestProcess_Deduplicate(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 2)
+
+	// (1+2)*(2+1): оба сложения ждут одну задачу
+	taskManager.On("CreateTask", []float64{1.0, 2.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{1, 2}, Operation: "+",
+	}).Once()
+	taskManager.On("CreateTask", []float64{3.0, 3.0}, "*", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 2, Args: []float64{3, 3}, Operation: "*",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 1, Result: 3}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 2, Result: 9}).Once()
+
+	status := "done"
+	result := 9.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("ExpressionDone", exprID, 9.0).Return()
+	exprManager.On("ExpressionProgress", exprID, 0, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 2, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 3, 3).Return().Once()
+
+	dag, err := helper.BuildDAG([]string{"1", "2", "+", "2", "1", "+", "*"})
+	assert.NoError(t, err)
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	service.Process(ctx, taskManager, dag, userID, exprID)
+
+	stats, err := service.CacheStats(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.False(t, stats.Enabled)
+	assert.Equal(t, int64(1), stats.Deduplicated)
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestRecover(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	brokenID := uuid.MustParse("00000000-0000-0000-0000-000000000003")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 2)
+
+	expr := &models.Expression{
+		ExpressionID: exprID,
+		UserId:       userID,
+		Status:       "pending",
+		RPN:          []string{"1", "2", "+", "3", "4", "+", "*"},
+	}
+	broken := &models.Expression{
+		ExpressionID: brokenID,
+		UserId:       userID,
+		Status:       "pending",
+	}
+	storage.On("GetPendingExpressions").Return([]*models.Expression{expr, broken}, nil)
+
+	// задача 1+2 уже посчитана, задача 3+4 была выдана агенту до перезапуска
+	done := 3.0
+	storage.On("GetTasks", exprID).Return([]*models.Task{
+		{ExpressionID: exprID, TaskID: 1, NodeID: 2, Args: []float64{1, 2}, Operation: "+", Status: "done", Result: &done},
+		{ExpressionID: exprID, TaskID: 2, NodeID: 5, Args: []float64{3, 4}, Operation: "+", Status: "pending"},
+	}, nil)
+
+	invalid := "invalid expression"
+	storage.On("FailExpression", userID, brokenID, invalid,
+		"cannot re-plan saved expression: invalid expression").Return(nil)
+	exprManager.On("ExpressionError", brokenID, errors.ErrInvalidExpression).Return()
+
+	exprManager.On("RestoreExpression", expr, 2).Return(nil)
+	exprManager.On("GetTaskManager", exprID).Return(taskManager, nil)
+
+	taskManager.On("CreateTask", []float64{3.0, 4.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 3, Args: []float64{3, 4}, Operation: "+",
+	}).Once()
+	taskManager.On("CreateTask", []float64{3.0, 7.0}, "*", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 4, Args: []float64{3, 7}, Operation: "*",
+	}).Once()
+	// опоздавший результат задачи, выданной до перезапуска, игнорируется
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 2, Result: 7}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 3, Result: 7}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 4, Result: 21}).Once()
+
+	status := "done"
+	result := 21.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("ExpressionDone", exprID, 21.0).Return()
+	exprManager.On("ExpressionProgress", exprID, 1, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 2, 3).Return().Once()
+	exprManager.On("ExpressionProgress", exprID, 3, 3).Return().Once()
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+	assert.NoError(t, service.Recover(ctx))
+
+	// Allow some time for goroutines to complete
+	time.Sleep(100 * time.Millisecond)
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+func TestRecover_NoCache(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	storage := new(MockStorageAdapter)
+	exprManager.tasks = make(chan models.Task, 1)
+	cache := &fakeCache{results: map[string]models.CachedResult{
+		"float:1 2 +": {Value: 4},
+	}}
+
+	expr := &models.Expression{
+		ExpressionID: exprID,
+		UserId:       userID,
+		Status:       models.StatusPending,
+		RPN:          []string{"1", "2", "+"},
+		NoCache:      true,
+	}
+	storage.On("GetPendingExpressions").Return([]*models.Expression{expr}, nil)
+	storage.On("GetTasks", exprID).Return([]*models.Task{}, nil)
+	exprManager.On("RestoreExpression", expr, 0).Return(nil)
+	exprManager.On("GetTaskManager", exprID).Return(taskManager, nil)
+
+	// после перезапуска выражение с no_cache по-прежнему не берёт результат из кэша
+	taskManager.On("CreateTask", []float64{1.0, 2.0}, "+", exprID).Return(models.Task{
+		ExpressionID: exprID, TaskID: 1, Args: []float64{1, 2}, Operation: "+",
+	}).Once()
+	taskManager.On("GetResult").Return(models.Result{ExpressionID: exprID, TaskID: 1, Result: 3}).Once()
+
+	status := models.StatusDone
+	result := 3.0
+	storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+	storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil)
+	storage.On("UpdateExpression", userID, exprID, &status, &result).Return(nil)
+	exprManager.On("ExpressionDone", exprID, 3.0).Return()
+	exprManager.On("ExpressionProgress", exprID, mock.Anything, 1).Return()
+
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, cache, false)
+	assert.NoError(t, service.Recover(ctx))
+
+	time.Sleep(100 * time.Millisecond)
+	stats, err := service.CacheStats(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), stats.Hits)
+
+	storage.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	exprManager.AssertExpectations(t)
+}
+
+type mockWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*orchestrator.ExpressionEvent
+}
+
+func (s *mockWatchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockWatchStream) Send(event *orchestrator.ExpressionEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestWatchExpression(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	result := 7.0
+
+	tests := []struct {
+		name           string
+		setupMocks     func(storage *MockStorageAdapter, exprManager *MockExpressionManager)
+		expectedStatus []string
+		expectedErr    error
+	}{
+		{
+			name: "already done",
+			setupMocks: func(storage *MockStorageAdapter, _ *MockExpressionManager) {
+				storage.On("GetExpressionById", userID, exprID).Return(&models.Expression{
+					ExpressionID: exprID, Status: "done", Result: &result,
+				}, nil)
+			},
+			expectedStatus: []string{"done"},
+		},
+		{
+			name: "pending until done",
+			setupMocks: func(storage *MockStorageAdapter, exprManager *MockExpressionManager) {
+				storage.On("GetExpressionById", userID, exprID).Return(&models.Expression{
+					ExpressionID: exprID, Status: "pending",
+				}, nil).Once()
+				storage.On("GetExpressionById", userID, exprID).Return(&models.Expression{
+					ExpressionID: exprID, Status: "done", Result: &result,
+				}, nil).Once()
+
+				events := make(chan models.ExpressionEvent, 2)
+				events <- models.ExpressionEvent{ExpressionID: exprID, Status: "pending", TasksTotal: 1}
+				events <- models.ExpressionEvent{ExpressionID: exprID, Status: "in progress", TasksDone: 1, TasksTotal: 1}
+				close(events)
+				exprManager.On("Watch", exprID).Return(events)
+			},
+			expectedStatus: []string{"pending", "in progress", "done"},
+		},
+		{
+			name: "not found",
+			setupMocks: func(storage *MockStorageAdapter, _ *MockExpressionManager) {
+				storage.On("GetExpressionById", userID, exprID).
+					Return((*models.Expression)(nil), errors.ErrExpressionNotFound)
+			},
+			expectedErr: errors.ErrExpressionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			exprManager := new(MockExpressionManager)
+			tt.setupMocks(storage, exprManager)
+
+			stream := &mockWatchStream{ctx: context.Background()}
+			service := NewOrchestratorService(storage, exprManager, new(MockAgentRegistry), 0, nil, false)
+			err := service.WatchExpression(&orchestrator.WatchExpressionRequest{
+				UserId: userID.String(),
+				Id:     exprID.String(),
+			}, stream)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			var statuses []string
+			for _, event := range stream.events {
+				statuses = append(statuses, event.Status)
+			}
+			assert.Equal(t, tt.expectedStatus, statuses)
+			assert.Equal(t, 7.0, stream.events[len(stream.events)-1].GetResult())
+
+			storage.AssertExpectations(t)
+			exprManager.AssertExpectations(t)
+		})
+	}
+}
+
+type mockWorkStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests chan *orchestrator.WorkRequest
+	tasks    chan *orchestrator.Task
+	cancels  chan string
+}
+
+func (s *mockWorkStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockWorkStream) Recv() (*orchestrator.WorkRequest, error) {
+	request, ok := <-s.requests
+	if !ok {
+		return nil, io.EOF
+	}
+	return request, nil
+}
+
+func (s *mockWorkStream) Send(response *orchestrator.WorkResponse) error {
+	if task := response.GetTask(); task != nil {
+		s.tasks <- task
+		return nil
+	}
+	s.cancels <- response.GetCancelExpressionId()
+	return nil
+}
+
+func TestWork(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	exprManager := new(MockExpressionManager)
+	taskManager := new(MockTaskManager)
+	exprManager.tasks = make(chan models.Task, 2)
+	exprManager.On("GetTaskManager", exprID).Return(taskManager, nil)
+	exprManager.On("CompleteTask", exprID, 1).Return(true)
+	taskManager.On("AddResult", models.Result{ExpressionID: exprID, TaskID: 1, Result: 3}).Return()
+	agents := new(MockAgentRegistry)
+	agents.On("Agent", "agent-1").Return(models.Agent{ID: "agent-1", Capacity: 1, Operations: []string{"*", "+"}}, true)
+	// задачи выбираются по операциям, которые агент указал при регистрации
+	exprManager.On("NextTask", []string{"*", "+"})
+	// начало вычисления сохраняется только по первой задаче выражения
+	exprManager.On("MarkStarted", exprID).Return(true).Once()
+	exprManager.On("MarkStarted", exprID).Return(false).Once()
+	storage := new(MockStorageAdapter)
+	storage.On("StartExpression", exprID).Return(nil).Once()
+	// каждая выданная задача запоминает агента
+	storage.On("StartTask", exprID, 1, "agent-1").Return(nil).Once()
+	storage.On("StartTask", exprID, 2, "agent-1").Return(nil).Once()
+	agents.On("AddBusy", "agent-1", 1).Return().Twice()
+	// вторая задача осталась без ответа, когда агент закрыл поток
+	agents.On("AddBusy", "agent-1", -1).Return().Twice()
+
+	ctx, _ := logger.New(context.Background())
+	stream := &mockWorkStream{
+		ctx:      ctx,
+		requests: make(chan *orchestrator.WorkRequest),
+		tasks:    make(chan *orchestrator.Task, 2),
+	}
+	service := NewOrchestratorService(storage, exprManager, agents, 0, nil, false)
+	done := make(chan error)
+	go func() { done <- service.Work(stream) }()
+
+	exprManager.tasks <- models.Task{ExpressionID: exprID, TaskID: 1, Args: []float64{1, 2}, Operation: "+"}
+	exprManager.tasks <- models.Task{ExpressionID: exprID, TaskID: 2, Args: []float64{3, 4}, Operation: "*"}
+
+	// задачи не отправляются, пока агент не сообщил о свободных вычислителях
+	select {
+	case task := <-stream.tasks:
+		t.Fatalf("task %d sent before agent was ready", task.Id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stream.requests <- &orchestrator.WorkRequest{
+		Payload: &orchestrator.WorkRequest_Ready{Ready: &orchestrator.WorkerReady{Slots: 5, AgentId: "agent-1"}},
+	}
+	task := <-stream.tasks
+	assert.Equal(t, int64(1), task.Id)
+	assert.Equal(t, []float64{1, 2}, task.Args)
+
+	// агент зарегистрирован с одним вычислителем, второй задаче нужен освободившийся
+	select {
+	case task = <-stream.tasks:
+		t.Fatalf("task %d sent to busy agent", task.Id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stream.requests <- &orchestrator.WorkRequest{
+		Payload: &orchestrator.WorkRequest_Result{Result: &orchestrator.ResultTaskRequest{
+			ExpressionId: exprID.String(), Id: 1, Result: 3,
+		}},
+	}
+	task = <-stream.tasks
+	assert.Equal(t, int64(2), task.Id)
+
+	close(stream.requests)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Work did not return after agent closed the stream")
+	}
+	exprManager.AssertExpectations(t)
+	taskManager.AssertExpectations(t)
+	agents.AssertExpectations(t)
+	storage.AssertExpectations(t)
+}
+
+func TestWork_Cancel(t *testing.T) {
+	exprID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	exprManager := &MockExpressionManager{cancels: make(chan uuid.UUID, 1)}
+	exprManager.On("NextTask", []string{"+"})
+	agents := new(MockAgentRegistry)
+	agents.On("Agent", "agent-1").Return(models.Agent{ID: "agent-1", Capacity: 1, Operations: []string{"+"}}, true)
+	// задач агенту не выдавалось, освобождать нечего
+	agents.On("AddBusy", "agent-1", 0).Return()
+
+	ctx, _ := logger.New(context.Background())
+	stream := &mockWorkStream{
+		ctx:      ctx,
+		requests: make(chan *orchestrator.WorkRequest, 1),
+		tasks:    make(chan *orchestrator.Task, 1),
+		cancels:  make(chan string, 1),
+	}
+	stream.requests <- &orchestrator.WorkRequest{
+		Payload: &orchestrator.WorkRequest_Ready{Ready: &orchestrator.WorkerReady{Slots: 1, AgentId: "agent-1"}},
+	}
+	service := NewOrchestratorService(new(MockStorageAdapter), exprManager, agents, 0, nil, false)
+	done := make(chan error)
+	go func() { done <- service.Work(stream) }()
+
+	// отмена выражения доходит до агента по тому же потоку
+	exprManager.cancels <- exprID
+	select {
+	case id := <-stream.cancels:
+		assert.Equal(t, exprID.String(), id)
+	case <-time.After(time.Second):
+		t.Fatal("cancel was not sent to agent")
+	}
+
+	close(stream.requests)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Work did not return after agent closed the stream")
+	}
+	agents.AssertExpectations(t)
+}
+
+func TestWork_UnknownAgent(t *testing.T) {
+	agents := new(MockAgentRegistry)
+	agents.On("Agent", "agent-1").Return(models.Agent{}, false)
+
+	ctx, _ := logger.New(context.Background())
+	stream := &mockWorkStream{
+		ctx:      ctx,
+		requests: make(chan *orchestrator.WorkRequest, 1),
+		tasks:    make(chan *orchestrator.Task, 1),
+	}
+	stream.requests <- &orchestrator.WorkRequest{
+		Payload: &orchestrator.WorkRequest_Ready{Ready: &orchestrator.WorkerReady{Slots: 1, AgentId: "agent-1"}},
+	}
+	service := NewOrchestratorService(new(MockStorageAdapter), new(MockExpressionManager), agents, 0, nil, false)
+
+	err := service.Work(stream)
+	assert.ErrorIs(t, err, errors.ErrAgentNotFound)
+	assert.Empty(t, stream.tasks)
+	agents.AssertExpectations(t)
+}
+
+func TestProcess_StopInFlight(t *testing.T) {
+	tests := []struct {
+		name string
+		stop func(ctx context.Context, service *OrchestratorService, storage *MockStorageAdapter,
+			em *utils.ExpressionManager, userID, exprID uuid.UUID)
+	}{
+		{
+			name: "cancelled",
+			stop: func(_ context.Context, _ *OrchestratorService, _ *MockStorageAdapter,
+				em *utils.ExpressionManager, _, exprID uuid.UUID) {
+				require.True(t, em.CancelExpression(exprID))
+			},
+		},
+		{
+			// так завершает выражение watchDeadline
+			name: "timed out",
+			stop: func(ctx context.Context, service *OrchestratorService, storage *MockStorageAdapter,
+				_ *utils.ExpressionManager, userID, exprID uuid.UUID) {
+				storage.On("StopExpression", userID, exprID, errors.ErrExpressionTimedOut.Error(),
+					"not calculated within 1s").Return(nil).Once()
+				service.timeoutExpression(ctx, userID, exprID, time.Second)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprID, userID := uuid.New(), uuid.New()
+			em := utils.NewExpressionManager(map[string]int{"+": 10, "*": 10}, time.Millisecond, utils.QueueLimits{})
+			require.NoError(t, em.CreateExpression(&models.Expression{
+				ExpressionID: exprID, UserId: userID, Status: "pending",
+			}))
+			taskManager, err := em.GetTaskManager(exprID)
+			require.NoError(t, err)
+
+			// Process разбирает результат первой задачи, пока выражение останавливают
+			saving, release := make(chan struct{}), make(chan struct{})
+			storage := new(MockStorageAdapter)
+			storage.On("SaveTask", mock.AnythingOfType("models.Task")).Return(nil)
+			storage.On("SaveTaskResult", mock.AnythingOfType("models.Result")).Return(nil).Once().
+				Run(func(mock.Arguments) {
+					close(saving)
+					<-release
+				})
+			// (1+2)*3 + (4+5)
+			dag, err := helper.BuildDAG([]string{"1", "2", "+", "3", "*", "4", "5", "+", "+"})
+			require.NoError(t, err)
+
+			ctx, _ := logger.New(context.Background())
+			service := NewOrchestratorService(storage, em, new(MockAgentRegistry), 0, nil, false)
+			processed := make(chan struct{})
+			go func() {
+				service.Process(ctx, taskManager, dag, userID, exprID)
+				close(processed)
+			}()
+
+			// агенты взяли обе независимые задачи
+			leaseCtx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			first, ok := em.NextTask(leaseCtx, nil)
+			require.True(t, ok)
+			second, ok := em.NextTask(leaseCtx, nil)
+			require.True(t, ok)
+
+			_, err = service.ResultTask(ctx, &orchestrator.ResultTaskRequest{
+				ExpressionId: exprID.String(), Id: int64(first.TaskID), Result: 3,
+			})
+			require.NoError(t, err)
+			<-saving
+			tt.stop(ctx, service, storage, em, userID, exprID)
+			// результат второй задачи приходит уже после остановки
+			_, err = service.ResultTask(ctx, &orchestrator.ResultTaskRequest{
+				ExpressionId: exprID.String(), Id: int64(second.TaskID), Result: 9,
+			})
+			require.ErrorIs(t, err, errors.ErrTaskNotFound)
+			// Process дочитывает результат и пытается поставить умножение, ставшее готовым
+			close(release)
+
+			select {
+			case <-processed:
+			case <-time.After(time.Second):
+				t.Fatal("Process did not stop")
+			}
+
+			// ни одна задача остановленного выражения не выдаётся и не возвращается в очередь
+			_, ok = em.LeaseTask()
+			require.False(t, ok)
+			require.Equal(t, 0, em.RequeueExpired(time.Now().Add(time.Hour)))
+			storage.AssertExpectations(t)
+		})
+	}
+}
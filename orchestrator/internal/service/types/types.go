@@ -1,23 +1,46 @@
 package types
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jaam8/web_calculator/orchestrator/internal/models"
 )
 
 type TaskManager interface {
-	CreateTask(arg1 float64, arg2 float64, oper string, ExprID uuid.UUID) models.Task
+	CreateTask(args []float64, oper string, ExprID uuid.UUID) models.Task
 	AddResult(result models.Result)
 	GetResult() models.Result
 }
 
 type ExpressionManager interface {
 	CreateExpression(expression *models.Expression) error
+	RestoreExpression(expression *models.Expression, lastTaskID int) error
 	GetTaskManager(expressionID uuid.UUID) (TaskManager, error)
 	GetExpressions() []*models.Expression
 	GetExpression(expressionID uuid.UUID) (*models.Expression, bool)
-	AddTask(task models.Task)
-	GetTasks() chan models.Task
+	AddTask(task models.Task) bool
+	CheckQueue(userID uuid.UUID, expressions int) error
+	OperationTime(operation string) time.Duration
+	LeaseTask() (models.Task, bool)
+	MarkStarted(expressionID uuid.UUID) bool
+	NextTask(ctx context.Context, operations []string) (models.Task, bool)
+	CompleteTask(expressionID uuid.UUID, taskID int) bool
 	ExpressionDone(expressionID uuid.UUID, result float64)
-	ExpressionError(expressionID uuid.UUID)
+	ExpressionError(expressionID uuid.UUID, err error)
+	CancelExpression(expressionID uuid.UUID) bool
+	TimeoutExpression(expressionID uuid.UUID, elapsed time.Duration) (models.ExpressionEvent, bool)
+	WatchCancels() (<-chan uuid.UUID, func())
+	ExpressionProgress(expressionID uuid.UUID, done, total int)
+	Watch(expressionID uuid.UUID) (<-chan models.ExpressionEvent, func())
+}
+
+type AgentRegistry interface {
+	HeartbeatInterval() time.Duration
+	Register(agent models.Agent)
+	Heartbeat(id string) error
+	Agent(id string) (models.Agent, bool)
+	Agents() []models.Agent
+	AddBusy(id string, delta int)
 }
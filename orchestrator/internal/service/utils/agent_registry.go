@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"context"
+	errs "github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AgentRegistry Хранит зарегистрированных агентов. Агент, не присылавший heartbeat
+// дольше ttl, считается пропавшим и удаляется из реестра
+type AgentRegistry struct {
+	mu                sync.Mutex
+	agents            map[string]*models.Agent
+	heartbeatInterval time.Duration
+	ttl               time.Duration
+}
+
+// NewAgentRegistry Создаёт реестр агентов, heartbeatInterval - как часто агенты
+// должны присылать heartbeat
+func NewAgentRegistry(heartbeatInterval, ttl time.Duration) *AgentRegistry {
+	return &AgentRegistry{
+		agents:            make(map[string]*models.Agent),
+		heartbeatInterval: heartbeatInterval,
+		ttl:               ttl,
+	}
+}
+
+func (r *AgentRegistry) HeartbeatInterval() time.Duration {
+	return r.heartbeatInterval
+}
+
+// Register Добавляет агента в реестр. Повторная регистрация, например после
+// перезапуска агента, заменяет прежние данные
+func (r *AgentRegistry) Register(agent models.Agent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	agent.Operations = slices.Clone(agent.Operations)
+	agent.Busy = 0
+	agent.RegisteredAt = now
+	agent.LastSeen = now
+	r.agents[agent.ID] = &agent
+}
+
+// Heartbeat Отмечает, что агент жив. Незарегистрированному агенту нужно зарегистрироваться заново
+func (r *AgentRegistry) Heartbeat(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agent, ok := r.agents[id]
+	if !ok {
+		return errs.ErrAgentNotFound
+	}
+	agent.LastSeen = time.Now()
+	return nil
+}
+
+// Agent Возвращает копию данных агента
+func (r *AgentRegistry) Agent(id string) (models.Agent, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agent, ok := r.agents[id]
+	if !ok {
+		return models.Agent{}, false
+	}
+	return *agent, true
+}
+
+// Agents Возвращает копии данных всех агентов, отсортированные по ID
+func (r *AgentRegistry) Agents() []models.Agent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agents := make([]models.Agent, 0, len(r.agents))
+	for _, agent := range r.agents {
+		agents = append(agents, *agent)
+	}
+	slices.SortFunc(agents, func(a, b models.Agent) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return agents
+}
+
+// AddBusy Изменяет число задач, которые считает агент
+func (r *AgentRegistry) AddBusy(id string, delta int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if agent, ok := r.agents[id]; ok {
+		agent.Busy = max(agent.Busy+delta, 0)
+	}
+}
+
+// RemoveExpired Удаляет агентов, не присылавших heartbeat дольше ttl.
+// Возвращает их ID
+func (r *AgentRegistry) RemoveExpired(now time.Time) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed []string
+	for id, agent := range r.agents {
+		if now.Sub(agent.LastSeen) > r.ttl {
+			delete(r.agents, id)
+			removed = append(removed, id)
+		}
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+// WatchAgents Периодически удаляет пропавших агентов
+func (r *AgentRegistry) WatchAgents(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.RemoveExpired(now)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestAgentRegistry(t *testing.T) {
+	registry := NewAgentRegistry(time.Second, 3*time.Second)
+	require.ErrorIs(t, registry.Heartbeat("agent-1"), errors.ErrAgentNotFound)
+
+	registry.Register(models.Agent{ID: "agent-2", Capacity: 1})
+	registry.Register(models.Agent{ID: "agent-1", Capacity: 4, Operations: []string{"+"}})
+	require.NoError(t, registry.Heartbeat("agent-1"))
+
+	registry.AddBusy("agent-1", 2)
+	registry.AddBusy("agent-1", -3)
+	agent, ok := registry.Agent("agent-1")
+	require.True(t, ok)
+	require.Equal(t, 4, agent.Capacity)
+	require.Equal(t, 0, agent.Busy)
+
+	agents := registry.Agents()
+	require.Len(t, agents, 2)
+	require.Equal(t, "agent-1", agents[0].ID)
+	require.Equal(t, "agent-2", agents[1].ID)
+
+	// агенты ещё живы
+	require.Empty(t, registry.RemoveExpired(time.Now()))
+	// heartbeat не приходил дольше ttl
+	require.Equal(t, []string{"agent-1", "agent-2"}, registry.RemoveExpired(time.Now().Add(4*time.Second)))
+	_, ok = registry.Agent("agent-1")
+	require.False(t, ok)
+}
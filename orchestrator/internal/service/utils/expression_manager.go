@@ -1,29 +1,80 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"github.com/google/uuid"
+	errs "github.com/jaam8/web_calculator/common-lib/errors"
 	"github.com/jaam8/web_calculator/orchestrator/internal/models"
 	"github.com/jaam8/web_calculator/orchestrator/internal/service/types"
+	"slices"
 	"sync"
+	"time"
 )
 
+// watchBufferSize размер буфера событий подписчика: если подписчик не успевает их читать,
+// промежуточные события пропускаются, ведь каждое содержит полное состояние
+const watchBufferSize = 16
+
+// cancelBufferSize размер буфера отмен подписчика WatchCancels
+const cancelBufferSize = 16
+
+type taskKey struct {
+	expressionID uuid.UUID
+	taskID       int
+}
+
+// taskState состояние ещё не посчитанной задачи: лежит в очереди или выдана агенту до deadline
+type taskState struct {
+	task     models.Task
+	leased   bool
+	deadline time.Time
+}
+
 type ExpressionManager struct {
 	mu           sync.Mutex
 	expressions  map[uuid.UUID]*models.Expression
 	taskManagers map[uuid.UUID]*TaskManager
-	TaskCh       chan models.Task
+	tasks        map[taskKey]*taskState
+	// events последнее состояние ещё не вычисленных выражений, watchers - их подписчики
+	events   map[uuid.UUID]*models.ExpressionEvent
+	watchers map[uuid.UUID][]chan models.ExpressionEvent
+	// cancels подписчики отмены выражений, потоки Work агентов
+	cancels map[chan uuid.UUID]struct{}
+	// queue задачи, ждущие агента. Агент берёт из неё первую по порядку планировщика задачу,
+	// операцию которой он умеет считать
+	queue  *scheduler
+	limits QueueLimits
+	// queued закрывается и заменяется новым каналом при каждом пополнении очереди,
+	// так ждущие в NextTask агенты узнают о новых задачах
+	queued       chan struct{}
 	counter      int
 	durations    map[string]int
+	leaseTimeout time.Duration
+}
+
+// QueueLimits Ограничения очереди задач, 0 - без ограничения. Пока в очереди не меньше
+// MaxTasks задач или не меньше MaxUserTasks задач пользователя, его новые выражения не принимаются
+type QueueLimits struct {
+	MaxTasks     int
+	MaxUserTasks int
 }
 
-// NewExpressionManager Создаёт новый экземпляр ExpressionManager
-func NewExpressionManager(durations map[string]int) *ExpressionManager {
+// NewExpressionManager Создаёт новый экземпляр ExpressionManager,
+// leaseTimeout - сколько сверх OperationTime агент может держать задачу до повторной выдачи
+func NewExpressionManager(durations map[string]int, leaseTimeout time.Duration, limits QueueLimits) *ExpressionManager {
 	return &ExpressionManager{
 		expressions:  make(map[uuid.UUID]*models.Expression),
 		taskManagers: make(map[uuid.UUID]*TaskManager),
-		TaskCh:       make(chan models.Task, 100),
+		tasks:        make(map[taskKey]*taskState),
+		events:       make(map[uuid.UUID]*models.ExpressionEvent),
+		watchers:     make(map[uuid.UUID][]chan models.ExpressionEvent),
+		cancels:      make(map[chan uuid.UUID]struct{}),
+		queue:        newScheduler(),
+		limits:       limits,
+		queued:       make(chan struct{}),
 		durations:    durations,
+		leaseTimeout: leaseTimeout,
 	}
 }
 
@@ -34,11 +85,34 @@ func (em *ExpressionManager) CreateExpression(expression *models.Expression) err
 
 	em.expressions[expression.ExpressionID] = expression
 	em.taskManagers[expression.ExpressionID] = NewTaskManager(em.durations)
+	em.events[expression.ExpressionID] = &models.ExpressionEvent{
+		ExpressionID: expression.ExpressionID,
+		Status:       models.StatusPending,
+	}
+	return nil
+}
+
+// RestoreExpression Восстанавливает выражение после перезапуска: новые задачи получают ID
+// после lastTaskID, чтобы опоздавшие результаты старых задач не перепутались с новыми
+func (em *ExpressionManager) RestoreExpression(expression *models.Expression, lastTaskID int) error {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	taskManager := NewTaskManager(em.durations)
+	taskManager.Counter = lastTaskID
+	em.expressions[expression.ExpressionID] = expression
+	em.taskManagers[expression.ExpressionID] = taskManager
+	em.events[expression.ExpressionID] = &models.ExpressionEvent{
+		ExpressionID: expression.ExpressionID,
+		Status:       models.StatusPending,
+	}
 	return nil
 }
 
 // GetTaskManager Возвращает TaskManager по ExpressionID
 func (em *ExpressionManager) GetTaskManager(expressionID uuid.UUID) (types.TaskManager, error) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
 	taskManager, exists := em.taskManagers[expressionID]
 	if !exists || taskManager == nil {
 		return taskManager, fmt.Errorf("task manager not found for expression ID: %s", expressionID)
@@ -61,14 +135,173 @@ func (em *ExpressionManager) GetExpression(expressionID uuid.UUID) (*models.Expr
 	return expr, exists
 }
 
-// AddTask Добавляет задачу в очередь на вычисление
-func (em *ExpressionManager) AddTask(task models.Task) {
-	em.TaskCh <- task
+// MarkStarted Отмечает, что агент взял задачу выражения. Возвращает true только для первой
+// взятой задачи, восстановленное после перезапуска выражение с StartedAt повторно не отмечается
+func (em *ExpressionManager) MarkStarted(expressionID uuid.UUID) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	expr, exists := em.expressions[expressionID]
+	if !exists || expr.StartedAt != nil {
+		return false
+	}
+	now := time.Now()
+	expr.StartedAt = &now
+	return true
 }
 
-// GetTasks Возвращает канал с задачами
-func (em *ExpressionManager) GetTasks() chan models.Task {
-	return em.TaskCh
+// AddTask Добавляет задачу в очередь на вычисление. Возвращает false и не ставит задачу,
+// если выражение уже не вычисляется: отменено, просрочено или завершилось, пока Process
+// разбирал результаты. Иначе задачу никто не ждал бы, а её аренда истекала бы бесконечно
+func (em *ExpressionManager) AddTask(task models.Task) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if _, active := em.events[task.ExpressionID]; !active {
+		return false
+	}
+	em.tasks[taskKey{task.ExpressionID, task.TaskID}] = &taskState{task: task}
+	em.enqueue(task)
+	em.notifyQueued()
+	return true
+}
+
+// OperationTime Возвращает, сколько агент вычисляет задачу операции
+func (em *ExpressionManager) OperationTime(operation string) time.Duration {
+	return time.Duration(em.durations[operation]) * time.Millisecond
+}
+
+// CheckQueue Возвращает errors.ErrQueueFull, если в очереди задач не хватает места
+// для expressions новых выражений пользователя и их нужно отклонить.
+// Каждое выражение ставит в очередь хотя бы одну задачу
+func (em *ExpressionManager) CheckQueue(userID uuid.UUID, expressions int) error {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if em.limits.MaxTasks > 0 && em.queue.len()+expressions > em.limits.MaxTasks {
+		return errs.ErrQueueFull
+	}
+	if em.limits.MaxUserTasks > 0 && em.queue.userLen(userID)+expressions > em.limits.MaxUserTasks {
+		return errs.ErrQueueFull
+	}
+	return nil
+}
+
+// enqueue ставит задачу в очередь с приоритетом её выражения. Вызывается под em.mu
+func (em *ExpressionManager) enqueue(task models.Task) {
+	var userID uuid.UUID
+	priority := 0
+	if expr, ok := em.expressions[task.ExpressionID]; ok {
+		userID, priority = expr.UserId, expr.Priority
+	}
+	em.queue.push(task, userID, priority)
+}
+
+// LeaseTask Выдаёт агенту первую задачу из очереди до deadline, после которого она вернётся в очередь
+func (em *ExpressionManager) LeaseTask() (models.Task, bool) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return em.take(nil)
+}
+
+// NextTask Ждёт появления в очереди задачи с одной из операций operations и выдаёт её
+// так же, как LeaseTask. operations == nil - любая операция.
+// Возвращает false, если ctx отменён раньше
+func (em *ExpressionManager) NextTask(ctx context.Context, operations []string) (models.Task, bool) {
+	for {
+		em.mu.Lock()
+		task, ok := em.take(operations)
+		queued := em.queued
+		em.mu.Unlock()
+		if ok {
+			return task, true
+		}
+
+		select {
+		case <-queued:
+		case <-ctx.Done():
+			return models.Task{}, false
+		}
+	}
+}
+
+// take достаёт из очереди первую подходящую задачу и отмечает её выданной. Вызывается под em.mu
+func (em *ExpressionManager) take(operations []string) (models.Task, bool) {
+	task, ok := em.queue.pop(func(task models.Task) bool {
+		return operations == nil || slices.Contains(operations, task.Operation)
+	})
+	if !ok {
+		return models.Task{}, false
+	}
+
+	state := em.tasks[taskKey{task.ExpressionID, task.TaskID}]
+	state.leased = true
+	state.deadline = time.Now().Add(task.OperationTime + em.leaseTimeout)
+	if event, exists := em.events[task.ExpressionID]; exists && event.Status == models.StatusPending {
+		event.Status = models.StatusInProgress
+		em.publish(event)
+	}
+	return task, true
+}
+
+// notifyQueued будит агентов, ждущих задачи. Вызывается под em.mu
+func (em *ExpressionManager) notifyQueued() {
+	close(em.queued)
+	em.queued = make(chan struct{})
+}
+
+// CompleteTask Снимает задачу с учёта. Возвращает false, если задача уже посчитана
+// или неизвестна, тогда результат нужно проигнорировать
+func (em *ExpressionManager) CompleteTask(expressionID uuid.UUID, taskID int) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	key := taskKey{expressionID, taskID}
+	state, ok := em.tasks[key]
+	if !ok {
+		return false
+	}
+	delete(em.tasks, key)
+	// результат опоздавшего агента пришёл, когда задача уже вернулась в очередь
+	if !state.leased {
+		em.queue.remove(func(task models.Task) bool {
+			return task.ExpressionID == expressionID && task.TaskID == taskID
+		})
+	}
+	return true
+}
+
+// RequeueExpired Возвращает в очередь задачи, агенты которых не прислали результат до deadline.
+// Возвращает количество повторно выданных задач
+func (em *ExpressionManager) RequeueExpired(now time.Time) int {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	requeued := 0
+	for _, state := range em.tasks {
+		if state.leased && now.After(state.deadline) {
+			state.leased = false
+			em.enqueue(state.task)
+			requeued++
+		}
+	}
+	if requeued > 0 {
+		em.notifyQueued()
+	}
+	return requeued
+}
+
+// WatchLeases Периодически возвращает в очередь задачи с истёкшей арендой
+func (em *ExpressionManager) WatchLeases(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			em.RequeueExpired(now)
+		}
+	}
 }
 
 // ExpressionDone Завершает и обновляет статус задачи
@@ -77,19 +310,174 @@ func (em *ExpressionManager) ExpressionDone(expressionID uuid.UUID, result float
 	defer em.mu.Unlock()
 	expr, exists := em.expressions[expressionID]
 	if exists {
-		expr.Status = "done"
+		expr.Status = models.StatusDone
 		expr.Result = &result
 		em.expressions[expressionID] = expr
 	}
+	em.finish(expressionID)
 }
 
-// ExpressionError ставит ошибку в статусе если вдруг задача прошадшая валидацию, оказалась с ошибкой
-func (em *ExpressionManager) ExpressionError(expressionID uuid.UUID) {
+// ExpressionError ставит ошибку в статусе если вдруг задача прошадшая валидацию, оказалась с ошибкой,
+// оставшиеся задачи выражения больше не выдаются агентам
+func (em *ExpressionManager) ExpressionError(expressionID uuid.UUID, err error) {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 	expr, exists := em.expressions[expressionID]
 	if exists {
-		expr.Status = "invalid expression"
+		expr.Status = err.Error()
 		em.expressions[expressionID] = expr
 	}
+	em.drop(expressionID)
+	em.finish(expressionID)
+}
+
+// CancelExpression Отменяет выражение: его задачи убираются из очереди, результаты выданных
+// задач больше не принимаются, а подписчики WatchCancels узнают, что их можно бросить.
+// Process выражения получает результат с ошибкой errors.ErrExpressionCancelled и завершается.
+// Возвращает false, если выражение уже не вычисляется
+func (em *ExpressionManager) CancelExpression(expressionID uuid.UUID) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	_, ok := em.stop(expressionID, errs.ErrExpressionCancelled, 0)
+	return ok
+}
+
+// TimeoutExpression Завершает выражение, не вычисленное до своего deadline, так же как CancelExpression,
+// но Process получает ошибку errors.ErrExpressionTimedOut. Последним событием подписчики Watch
+// получают статус timed out, elapsed и сколько задач успели посчитать.
+// Возвращает это событие или false, если выражение уже не вычисляется
+func (em *ExpressionManager) TimeoutExpression(expressionID uuid.UUID, elapsed time.Duration) (models.ExpressionEvent, bool) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return em.stop(expressionID, errs.ErrExpressionTimedOut, elapsed)
+}
+
+// stop завершает ещё вычисляемое выражение со статусом reason и освобождает его TaskManager,
+// вызывается под em.mu
+func (em *ExpressionManager) stop(expressionID uuid.UUID, reason error, elapsed time.Duration) (models.ExpressionEvent, bool) {
+	event, active := em.events[expressionID]
+	if !active {
+		return models.ExpressionEvent{}, false
+	}
+	if expr, exists := em.expressions[expressionID]; exists {
+		expr.Status = reason.Error()
+	}
+	event.Status, event.Elapsed = reason.Error(), elapsed
+	em.publish(event)
+	last := *event
+
+	em.drop(expressionID)
+	em.finish(expressionID)
+	for ch := range em.cancels {
+		select {
+		case ch <- expressionID:
+		default:
+		}
+	}
+	// сигнал остановки отправляется сразу, а не через канал результатов: Process не должен
+	// успеть разобрать накопившиеся результаты и поставить новые задачи
+	if taskManager, exists := em.taskManagers[expressionID]; exists {
+		taskManager.Stop(expressionID, reason)
+		delete(em.taskManagers, expressionID)
+	}
+	return last, true
+}
+
+// WatchCancels Подписывает на отмену выражений, в канал приходят ID отменённых выражений.
+// Если подписчик не успевает читать, отмены пропускаются, тогда результаты брошенных
+// агентом задач просто будут проигнорированы. cancel отменяет подписку
+func (em *ExpressionManager) WatchCancels() (<-chan uuid.UUID, func()) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	ch := make(chan uuid.UUID, cancelBufferSize)
+	em.cancels[ch] = struct{}{}
+	return ch, func() {
+		em.mu.Lock()
+		defer em.mu.Unlock()
+		delete(em.cancels, ch)
+	}
+}
+
+// drop снимает с учёта задачи выражения и убирает их из очереди, вызывается под em.mu
+func (em *ExpressionManager) drop(expressionID uuid.UUID) {
+	for key := range em.tasks {
+		if key.expressionID == expressionID {
+			delete(em.tasks, key)
+		}
+	}
+	em.queue.remove(func(task models.Task) bool {
+		return task.ExpressionID == expressionID
+	})
+}
+
+// ExpressionProgress Сообщает подписчикам, сколько задач выражения уже посчитано
+func (em *ExpressionManager) ExpressionProgress(expressionID uuid.UUID, done, total int) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	event, exists := em.events[expressionID]
+	if !exists {
+		return
+	}
+	event.TasksDone, event.TasksTotal = done, total
+	if done > 0 {
+		event.Status = models.StatusInProgress
+	}
+	em.publish(event)
+}
+
+// Watch Подписывает на события выражения, первым приходит текущее состояние. Канал закрывается,
+// когда выражение вычислено или завершилось ошибкой, итог нужно брать из хранилища.
+// Для уже не вычисляемого выражения сразу возвращается закрытый канал. cancel отменяет подписку
+func (em *ExpressionManager) Watch(expressionID uuid.UUID) (<-chan models.ExpressionEvent, func()) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	ch := make(chan models.ExpressionEvent, watchBufferSize)
+	event, exists := em.events[expressionID]
+	if !exists {
+		close(ch)
+		return ch, func() {}
+	}
+	ch <- *event
+	em.watchers[expressionID] = append(em.watchers[expressionID], ch)
+	return ch, func() { em.unwatch(expressionID, ch) }
+}
+
+func (em *ExpressionManager) unwatch(expressionID uuid.UUID, ch chan models.ExpressionEvent) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	watchers := em.watchers[expressionID]
+	for i, watcher := range watchers {
+		if watcher == ch {
+			close(ch)
+			watchers = slices.Delete(watchers, i, i+1)
+			break
+		}
+	}
+	if len(watchers) == 0 {
+		delete(em.watchers, expressionID)
+		return
+	}
+	em.watchers[expressionID] = watchers
+}
+
+// publish рассылает событие подписчикам выражения, вызывается под em.mu
+func (em *ExpressionManager) publish(event *models.ExpressionEvent) {
+	for _, ch := range em.watchers[event.ExpressionID] {
+		select {
+		case ch <- *event:
+		default:
+		}
+	}
+}
+
+// finish закрывает каналы подписчиков завершённого выражения, вызывается под em.mu
+func (em *ExpressionManager) finish(expressionID uuid.UUID) {
+	for _, ch := range em.watchers[expressionID] {
+		close(ch)
+	}
+	delete(em.watchers, expressionID)
+	delete(em.events, expressionID)
 }
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"context"
 	"github.com/google/uuid"
+	"github.com/jaam8/web_calculator/common-lib/errors"
 	"github.com/jaam8/web_calculator/orchestrator/internal/models"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -15,8 +17,10 @@ var durations = map[string]int{
 	"/": 100,
 }
 
+var leaseTimeout = 200 * time.Millisecond
+
 func TestExpressionManager_CreateExpression(t *testing.T) {
-	em := NewExpressionManager(durations)
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
 
 	// Создаем тестовое выражение
 	expr1 := &models.Expression{
@@ -49,31 +53,179 @@ func TestExpressionManager_CreateExpression(t *testing.T) {
 	require.Equal(t, "pending", gotExpr.Status)
 }
 
+func TestExpressionManager_MarkStarted(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	expr := &models.Expression{ExpressionID: uuid.New(), Status: "pending"}
+	require.NoError(t, em.CreateExpression(expr))
+
+	// только первая взятая задача отмечает начало вычисления
+	require.True(t, em.MarkStarted(expr.ExpressionID))
+	require.NotNil(t, expr.StartedAt)
+	require.False(t, em.MarkStarted(expr.ExpressionID))
+	require.False(t, em.MarkStarted(uuid.New()))
+
+	// выражение, начатое до перезапуска, не отмечается повторно
+	startedAt := time.Now().Add(-time.Minute)
+	restored := &models.Expression{ExpressionID: uuid.New(), Status: "pending", StartedAt: &startedAt}
+	require.NoError(t, em.RestoreExpression(restored, 2))
+	require.False(t, em.MarkStarted(restored.ExpressionID))
+}
+
 func TestExpressionManager_AddTask(t *testing.T) {
-	em := NewExpressionManager(durations)
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
 	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
 
 	task := models.Task{
 		ExpressionID:  expressionID,
 		TaskID:        1,
-		Arg1:          2,
-		Arg2:          2,
+		Args:          []float64{2, 2},
 		Operation:     "+",
 		OperationTime: time.Millisecond * time.Duration(durations["+"]),
 	}
 
-	em.AddTask(task)
+	require.True(t, em.AddTask(task))
+	// задачи невычисляемого выражения не принимаются
+	require.False(t, em.AddTask(models.Task{ExpressionID: uuid.New(), TaskID: 1, Operation: "+"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got, ok := em.NextTask(ctx, nil)
+	require.True(t, ok, "did not receive task in time")
+	require.Equal(t, task, got)
+}
 
-	select {
-	case got := <-em.GetTasks():
-		require.Equal(t, task, got)
-	case <-time.After(time.Second):
-		t.Fatal("did not receive task in time")
+func TestExpressionManager_LeaseTask(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+
+	task := models.Task{
+		ExpressionID:  expressionID,
+		TaskID:        1,
+		Args:          []float64{2, 2},
+		Operation:     "+",
+		OperationTime: time.Millisecond * time.Duration(durations["+"]),
 	}
+	em.AddTask(task)
+
+	got, ok := em.LeaseTask()
+	require.True(t, ok)
+	require.Equal(t, task, got)
+
+	_, ok = em.LeaseTask()
+	require.False(t, ok)
+
+	// аренда ещё не истекла
+	require.Equal(t, 0, em.RequeueExpired(time.Now()))
+
+	// агент пропал, задача выдаётся снова
+	require.Equal(t, 1, em.RequeueExpired(time.Now().Add(task.OperationTime+leaseTimeout+time.Millisecond)))
+	got, ok = em.LeaseTask()
+	require.True(t, ok)
+	require.Equal(t, task, got)
+
+	// первый результат засчитывается, повторный игнорируется
+	require.True(t, em.CompleteTask(expressionID, task.TaskID))
+	require.False(t, em.CompleteTask(expressionID, task.TaskID))
+	require.False(t, em.CompleteTask(expressionID, 2))
+}
+
+func TestExpressionManager_LeaseTask_SkipsCompleted(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+
+	task := models.Task{ExpressionID: expressionID, TaskID: 1, Operation: "+"}
+	em.AddTask(task)
+	_, ok := em.LeaseTask()
+	require.True(t, ok)
+
+	require.Equal(t, 1, em.RequeueExpired(time.Now().Add(time.Second)))
+	// опоздавший агент всё же прислал результат, копия в очереди больше не нужна
+	require.True(t, em.CompleteTask(expressionID, task.TaskID))
+
+	_, ok = em.LeaseTask()
+	require.False(t, ok)
+}
+
+func TestExpressionManager_NextTask(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	task := models.Task{ExpressionID: uuid.New(), TaskID: 1, Operation: "+"}
+	_ = em.CreateExpression(&models.Expression{ExpressionID: task.ExpressionID, Status: "pending"})
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		em.AddTask(task)
+	}()
+	got, ok := em.NextTask(context.Background(), nil)
+	require.True(t, ok)
+	require.Equal(t, task, got)
+	// выданная задача вернётся в очередь по истечении аренды
+	require.Equal(t, 1, em.RequeueExpired(time.Now().Add(time.Second)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.True(t, em.CompleteTask(task.ExpressionID, task.TaskID))
+	_, ok = em.NextTask(ctx, nil)
+	require.False(t, ok)
+}
+
+func TestExpressionManager_NextTask_Operations(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+	division := models.Task{ExpressionID: expressionID, TaskID: 1, Operation: "/"}
+	addition := models.Task{ExpressionID: expressionID, TaskID: 2, Operation: "+"}
+	em.AddTask(division)
+	em.AddTask(addition)
+
+	// агент без деления пропускает первую задачу очереди
+	got, ok := em.NextTask(context.Background(), []string{"+", "-"})
+	require.True(t, ok)
+	require.Equal(t, addition, got)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, ok = em.NextTask(ctx, []string{"+", "-"})
+	require.False(t, ok)
+
+	// деление дождалось своего агента
+	got, ok = em.NextTask(context.Background(), []string{"/"})
+	require.True(t, ok)
+	require.Equal(t, division, got)
+}
+
+func TestExpressionManager_CheckQueue(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{MaxTasks: 3, MaxUserTasks: 2})
+	busy, other := uuid.New(), uuid.New()
+	busyExpr := &models.Expression{ExpressionID: uuid.New(), UserId: busy, Status: "pending"}
+	otherExpr := &models.Expression{ExpressionID: uuid.New(), UserId: other, Status: "pending"}
+	_ = em.CreateExpression(busyExpr)
+	_ = em.CreateExpression(otherExpr)
+
+	em.AddTask(models.Task{ExpressionID: busyExpr.ExpressionID, TaskID: 1, Operation: "+"})
+	require.NoError(t, em.CheckQueue(busy, 1))
+	em.AddTask(models.Task{ExpressionID: busyExpr.ExpressionID, TaskID: 2, Operation: "+"})
+
+	// у пользователя исчерпан личный лимит, остальным очередь ещё доступна
+	require.ErrorIs(t, em.CheckQueue(busy, 1), errors.ErrQueueFull)
+	require.NoError(t, em.CheckQueue(other, 1))
+
+	// пакету из двух выражений не хватает единственного свободного места
+	require.ErrorIs(t, em.CheckQueue(other, 2), errors.ErrQueueFull)
+
+	em.AddTask(models.Task{ExpressionID: otherExpr.ExpressionID, TaskID: 1, Operation: "+"})
+	require.ErrorIs(t, em.CheckQueue(other, 1), errors.ErrQueueFull)
+
+	// выданная задача освобождает место в очереди
+	_, ok := em.LeaseTask()
+	require.True(t, ok)
+	require.NoError(t, em.CheckQueue(other, 1))
 }
 
 func TestExpressionManager_ExpressionDone(t *testing.T) {
-	em := NewExpressionManager(durations)
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
 
 	// Создаем тестовое выражение
 	expressionID := uuid.New()
@@ -96,7 +248,7 @@ func TestExpressionManager_ExpressionDone(t *testing.T) {
 }
 
 func TestExpressionManager_ExpressionError(t *testing.T) {
-	em := NewExpressionManager(durations)
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
 
 	// Создаем тестовое выражение
 	expressionID := uuid.New()
@@ -108,16 +260,151 @@ func TestExpressionManager_ExpressionError(t *testing.T) {
 	}
 
 	_ = em.CreateExpression(expr)
+	em.AddTask(models.Task{ExpressionID: expressionID, TaskID: 1, Operation: "/"})
 
-	em.ExpressionError(expressionID)
+	em.ExpressionError(expressionID, errors.ErrDivideByZero)
 
 	gotExpr, exists := em.GetExpression(expressionID)
 	require.True(t, exists)
-	require.Equal(t, "invalid expression", gotExpr.Status)
+	require.Equal(t, "division by zero", gotExpr.Status)
+
+	// задачи упавшего выражения больше не выдаются
+	_, ok := em.LeaseTask()
+	require.False(t, ok)
+}
+
+func TestExpressionManager_CancelExpression(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+	cancels, stop := em.WatchCancels()
+	defer stop()
+	events, _ := em.Watch(expressionID)
+	<-events
+
+	leased := models.Task{ExpressionID: expressionID, TaskID: 1, Operation: "+"}
+	em.AddTask(leased)
+	_, ok := em.LeaseTask()
+	require.True(t, ok)
+	em.AddTask(models.Task{ExpressionID: expressionID, TaskID: 2, Operation: "+"})
+	taskManager, err := em.GetTaskManager(expressionID)
+	require.NoError(t, err)
+
+	require.True(t, em.CancelExpression(expressionID))
+	require.Equal(t, expressionID, <-cancels)
+
+	// задачи отменённого выражения не выдаются, а результат выданной не засчитывается
+	_, ok = em.LeaseTask()
+	require.False(t, ok)
+	require.False(t, em.CompleteTask(expressionID, leased.TaskID))
+	for range events {
+	}
+
+	// Process выражения узнаёт об отмене из результатов, а TaskManager освобождается
+	require.ErrorIs(t, taskManager.GetResult().Err, errors.ErrExpressionCancelled)
+	_, err = em.GetTaskManager(expressionID)
+	require.Error(t, err)
+
+	// повторная отмена ничего не делает
+	require.False(t, em.CancelExpression(expressionID))
+}
+
+func TestExpressionManager_TimeoutExpression(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+	events, _ := em.Watch(expressionID)
+	<-events
+
+	em.AddTask(models.Task{ExpressionID: expressionID, TaskID: 1, Operation: "+"})
+	em.ExpressionProgress(expressionID, 1, 3)
+	<-events
+	taskManager, err := em.GetTaskManager(expressionID)
+	require.NoError(t, err)
+
+	event, ok := em.TimeoutExpression(expressionID, 3*time.Second)
+	require.True(t, ok)
+	want := models.ExpressionEvent{
+		ExpressionID: expressionID,
+		Status:       errors.ErrExpressionTimedOut.Error(),
+		TasksDone:    1,
+		TasksTotal:   3,
+		Elapsed:      3 * time.Second,
+	}
+	require.Equal(t, want, event)
+
+	// последним подписчики получают статус timed out с прогрессом
+	require.Equal(t, want, <-events)
+	_, open := <-events
+	require.False(t, open)
+
+	_, ok = em.LeaseTask()
+	require.False(t, ok)
+	// опоздавший Process не может поставить задачи просроченного выражения
+	require.False(t, em.AddTask(models.Task{ExpressionID: expressionID, TaskID: 2, Operation: "+"}))
+	require.Equal(t, 0, em.RequeueExpired(time.Now().Add(time.Hour)))
+	require.ErrorIs(t, taskManager.GetResult().Err, errors.ErrExpressionTimedOut)
+	_, err = em.GetTaskManager(expressionID)
+	require.Error(t, err)
+
+	_, ok = em.TimeoutExpression(expressionID, 4*time.Second)
+	require.False(t, ok)
+}
+
+func TestExpressionManager_Watch(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+
+	events, cancel := em.Watch(expressionID)
+	defer cancel()
+	require.Equal(t, models.StatusPending, (<-events).Status)
+
+	em.ExpressionProgress(expressionID, 0, 2)
+	require.Equal(t, models.ExpressionEvent{
+		ExpressionID: expressionID, Status: models.StatusPending, TasksTotal: 2,
+	}, <-events)
+
+	// выдача первой задачи агенту переводит выражение в "in progress"
+	em.AddTask(models.Task{ExpressionID: expressionID, TaskID: 1, Operation: "+"})
+	_, ok := em.LeaseTask()
+	require.True(t, ok)
+	require.Equal(t, models.StatusInProgress, (<-events).Status)
+
+	em.ExpressionProgress(expressionID, 1, 2)
+	require.Equal(t, 1, (<-events).TasksDone)
+
+	// по завершении выражения канал закрывается
+	em.ExpressionDone(expressionID, 42.0)
+	_, ok = <-events
+	require.False(t, ok)
+
+	// на завершённое выражение подписаться нельзя
+	events, _ = em.Watch(expressionID)
+	_, ok = <-events
+	require.False(t, ok)
+}
+
+func TestExpressionManager_Watch_Cancel(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+
+	expressionID := uuid.New()
+	_ = em.CreateExpression(&models.Expression{ExpressionID: expressionID, Status: "pending"})
+
+	events, cancel := em.Watch(expressionID)
+	<-events
+	cancel()
+	_, ok := <-events
+	require.False(t, ok)
+
+	// после отписки события и завершение выражения не трогают канал
+	em.ExpressionProgress(expressionID, 1, 1)
+	em.ExpressionError(expressionID, errors.ErrDivideByZero)
 }
 
 func TestExpressionManager_GetExpressions(t *testing.T) {
-	em := NewExpressionManager(durations)
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
 
 	// Создаем два тестовых выражения
 	expr1 := &models.Expression{
@@ -142,7 +429,7 @@ func TestExpressionManager_GetExpressions(t *testing.T) {
 }
 
 func TestExpressionManager_GetTaskManager(t *testing.T) {
-	em := NewExpressionManager(durations)
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
 
 	// Создаем тестовое выражение
 	expressionID := uuid.New()
@@ -165,3 +452,27 @@ func TestExpressionManager_GetTaskManager(t *testing.T) {
 	_, err = em.GetTaskManager(invalidID)
 	require.Error(t, err)
 }
+
+func TestExpressionManager_RestoreExpression(t *testing.T) {
+	em := NewExpressionManager(durations, leaseTimeout, QueueLimits{})
+
+	expressionID := uuid.New()
+	expr := &models.Expression{
+		ExpressionID: expressionID,
+		UserId:       uuid.New(),
+		Status:       "pending",
+	}
+
+	err := em.RestoreExpression(expr, 5)
+	require.NoError(t, err)
+
+	gotExpr, exists := em.GetExpression(expressionID)
+	require.True(t, exists)
+	require.Equal(t, "pending", gotExpr.Status)
+
+	// Новые задачи продолжают нумерацию после уже выданных
+	taskManager, err := em.GetTaskManager(expressionID)
+	require.NoError(t, err)
+	task := taskManager.CreateTask([]float64{1, 2}, "+", expressionID)
+	require.Equal(t, 6, task.TaskID)
+}
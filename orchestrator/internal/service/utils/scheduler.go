@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"github.com/google/uuid"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"slices"
+)
+
+// scheduler очередь задач, ждущих агента. Задачи с большим приоритетом выдаются раньше,
+// при равном приоритете пользователи обслуживаются по кругу, чтобы большое выражение
+// одного пользователя не задерживало остальных, а задачи одного пользователя
+// выдаются в порядке поступления
+type scheduler struct {
+	levels map[int]*priorityLevel
+	// priorities приоритеты, у которых есть задачи, по убыванию
+	priorities []int
+	size       int
+	userSize   map[uuid.UUID]int
+}
+
+// priorityLevel задачи одного приоритета по пользователям
+type priorityLevel struct {
+	tasks map[uuid.UUID][]models.Task
+	// users очередь обхода пользователей, next - с кого начать следующую выдачу
+	users []uuid.UUID
+	next  int
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		levels:   make(map[int]*priorityLevel),
+		userSize: make(map[uuid.UUID]int),
+	}
+}
+
+func (s *scheduler) len() int {
+	return s.size
+}
+
+func (s *scheduler) userLen(userID uuid.UUID) int {
+	return s.userSize[userID]
+}
+
+func (s *scheduler) push(task models.Task, userID uuid.UUID, priority int) {
+	level, ok := s.levels[priority]
+	if !ok {
+		level = &priorityLevel{tasks: make(map[uuid.UUID][]models.Task)}
+		s.levels[priority] = level
+		i, _ := slices.BinarySearchFunc(s.priorities, priority, func(a, b int) int { return b - a })
+		s.priorities = slices.Insert(s.priorities, i, priority)
+	}
+	if _, ok = level.tasks[userID]; !ok {
+		level.users = append(level.users, userID)
+	}
+	level.tasks[userID] = append(level.tasks[userID], task)
+	s.size++
+	s.userSize[userID]++
+}
+
+// pop достаёт первую задачу, для которой eligible возвращает true.
+// Следующая выдача этого приоритета начнётся со следующего пользователя
+func (s *scheduler) pop(eligible func(models.Task) bool) (models.Task, bool) {
+	for _, priority := range s.priorities {
+		level := s.levels[priority]
+		for k := range level.users {
+			i := (level.next + k) % len(level.users)
+			userID := level.users[i]
+			j := slices.IndexFunc(level.tasks[userID], eligible)
+			if j < 0 {
+				continue
+			}
+			task := level.tasks[userID][j]
+			if !s.removeAt(priority, i, j) {
+				level.next = (i + 1) % len(level.users)
+			} else if len(level.users) > 0 {
+				level.next = i % len(level.users)
+			}
+			return task, true
+		}
+	}
+	return models.Task{}, false
+}
+
+// remove убирает из очереди все задачи, для которых match возвращает true
+func (s *scheduler) remove(match func(models.Task) bool) {
+	for _, priority := range slices.Clone(s.priorities) {
+		level := s.levels[priority]
+		for i := 0; i < len(level.users); {
+			j := slices.IndexFunc(level.tasks[level.users[i]], match)
+			if j < 0 {
+				i++
+				continue
+			}
+			s.removeAt(priority, i, j)
+		}
+	}
+}
+
+// removeAt убирает j-ю задачу i-го пользователя уровня priority.
+// Возвращает true, если у пользователя не осталось задач этого приоритета
+func (s *scheduler) removeAt(priority, i, j int) bool {
+	level := s.levels[priority]
+	userID := level.users[i]
+	level.tasks[userID] = slices.Delete(level.tasks[userID], j, j+1)
+	s.size--
+	if s.userSize[userID]--; s.userSize[userID] == 0 {
+		delete(s.userSize, userID)
+	}
+	if len(level.tasks[userID]) > 0 {
+		return false
+	}
+
+	delete(level.tasks, userID)
+	level.users = slices.Delete(level.users, i, i+1)
+	if i < level.next {
+		level.next--
+	}
+	if len(level.users) == 0 {
+		delete(s.levels, priority)
+		s.priorities = slices.DeleteFunc(s.priorities, func(p int) bool { return p == priority })
+	} else if level.next >= len(level.users) {
+		level.next = 0
+	}
+	return true
+}
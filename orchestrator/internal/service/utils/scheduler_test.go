@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"github.com/google/uuid"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func anyTask(models.Task) bool { return true }
+
+func TestScheduler_Priority(t *testing.T) {
+	s := newScheduler()
+	user := uuid.New()
+	low := models.Task{ExpressionID: uuid.New(), TaskID: 1}
+	high := models.Task{ExpressionID: uuid.New(), TaskID: 1}
+	s.push(low, user, 0)
+	s.push(high, user, 9)
+
+	// задача с большим приоритетом выдаётся первой, хотя пришла позже
+	got, ok := s.pop(anyTask)
+	require.True(t, ok)
+	require.Equal(t, high, got)
+	got, ok = s.pop(anyTask)
+	require.True(t, ok)
+	require.Equal(t, low, got)
+
+	_, ok = s.pop(anyTask)
+	require.False(t, ok)
+	require.Equal(t, 0, s.len())
+}
+
+func TestScheduler_RoundRobin(t *testing.T) {
+	s := newScheduler()
+	heavy, light := uuid.New(), uuid.New()
+	heavyExpr, lightExpr := uuid.New(), uuid.New()
+	for i := 1; i <= 3; i++ {
+		s.push(models.Task{ExpressionID: heavyExpr, TaskID: i}, heavy, 0)
+	}
+	s.push(models.Task{ExpressionID: lightExpr, TaskID: 1}, light, 0)
+	s.push(models.Task{ExpressionID: lightExpr, TaskID: 2}, light, 0)
+	require.Equal(t, 5, s.len())
+	require.Equal(t, 3, s.userLen(heavy))
+
+	// пользователи чередуются, задачи каждого идут в порядке поступления
+	expected := []models.Task{
+		{ExpressionID: heavyExpr, TaskID: 1},
+		{ExpressionID: lightExpr, TaskID: 1},
+		{ExpressionID: heavyExpr, TaskID: 2},
+		{ExpressionID: lightExpr, TaskID: 2},
+		{ExpressionID: heavyExpr, TaskID: 3},
+	}
+	for _, want := range expected {
+		got, ok := s.pop(anyTask)
+		require.True(t, ok)
+		require.Equal(t, want, got)
+	}
+	require.Equal(t, 0, s.userLen(heavy))
+	require.Equal(t, 0, s.userLen(light))
+}
+
+func TestScheduler_PopEligible(t *testing.T) {
+	s := newScheduler()
+	user := uuid.New()
+	division := models.Task{ExpressionID: uuid.New(), TaskID: 1, Operation: "/"}
+	addition := models.Task{ExpressionID: uuid.New(), TaskID: 1, Operation: "+"}
+	s.push(division, user, 0)
+	s.push(addition, user, 0)
+
+	got, ok := s.pop(func(task models.Task) bool { return task.Operation == "+" })
+	require.True(t, ok)
+	require.Equal(t, addition, got)
+
+	_, ok = s.pop(func(task models.Task) bool { return task.Operation == "+" })
+	require.False(t, ok)
+	require.Equal(t, 1, s.len())
+}
+
+func TestScheduler_Remove(t *testing.T) {
+	s := newScheduler()
+	first, second := uuid.New(), uuid.New()
+	dropped := uuid.New()
+	kept := models.Task{ExpressionID: uuid.New(), TaskID: 1}
+	s.push(models.Task{ExpressionID: dropped, TaskID: 1}, first, 0)
+	s.push(models.Task{ExpressionID: dropped, TaskID: 2}, first, 0)
+	s.push(models.Task{ExpressionID: dropped, TaskID: 3}, first, 5)
+	s.push(kept, second, 0)
+
+	s.remove(func(task models.Task) bool { return task.ExpressionID == dropped })
+	require.Equal(t, 1, s.len())
+	require.Equal(t, 0, s.userLen(first))
+
+	got, ok := s.pop(anyTask)
+	require.True(t, ok)
+	require.Equal(t, kept, got)
+	_, ok = s.pop(anyTask)
+	require.False(t, ok)
+}
@@ -7,23 +7,32 @@ import (
 	"time"
 )
 
+// resultsBufferSize размер буфера результатов: задачи выражения вычисляются параллельно,
+// поэтому агенты могут прислать несколько результатов до того, как Process их заберёт
+const resultsBufferSize = 100
+
 type TaskManager struct {
 	durations map[string]int
 	mu        sync.Mutex
 	resultCh  chan models.Result
-	Counter   int
+	// stopped закрывается, когда выражение отменено или просрочено, stop - результат с причиной
+	stopped  chan struct{}
+	stop     models.Result
+	stopOnce sync.Once
+	Counter  int
 }
 
 // NewTaskManager Создаёт новый экземпляр TaskManager
 func NewTaskManager(durations map[string]int) *TaskManager {
 	return &TaskManager{
 		durations: durations,
-		resultCh:  make(chan models.Result, 1),
+		resultCh:  make(chan models.Result, resultsBufferSize),
+		stopped:   make(chan struct{}),
 	}
 }
 
 // CreateTask Создаёт новую задачу
-func (tm *TaskManager) CreateTask(arg1, arg2 float64, oper string, ExprID uuid.UUID) models.Task {
+func (tm *TaskManager) CreateTask(args []float64, oper string, ExprID uuid.UUID) models.Task {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 	tm.Counter++
@@ -32,8 +41,7 @@ func (tm *TaskManager) CreateTask(arg1, arg2 float64, oper string, ExprID uuid.U
 	task := models.Task{
 		ExpressionID:  ExprID,
 		TaskID:        taskID,
-		Arg1:          arg1,
-		Arg2:          arg2,
+		Args:          args,
 		Operation:     oper,
 		OperationTime: time.Millisecond * time.Duration(operationTime),
 	}
@@ -45,7 +53,27 @@ func (tm *TaskManager) AddResult(result models.Result) {
 	tm.resultCh <- result
 }
 
-// GetResult Возвращает результат из канала
+// Stop Сообщает Process, что выражение больше не вычисляется. Не блокируется,
+// повторные вызовы ничего не делают
+func (tm *TaskManager) Stop(expressionID uuid.UUID, reason error) {
+	tm.stopOnce.Do(func() {
+		tm.stop = models.Result{ExpressionID: expressionID, Err: reason}
+		close(tm.stopped)
+	})
+}
+
+// GetResult Возвращает результат из канала. После Stop возвращает результат с ошибкой-причиной,
+// даже если в канале остались результаты: разбирать их уже незачем
 func (tm *TaskManager) GetResult() models.Result {
-	return <-tm.resultCh
+	select {
+	case <-tm.stopped:
+		return tm.stop
+	default:
+	}
+	select {
+	case result := <-tm.resultCh:
+		return result
+	case <-tm.stopped:
+		return tm.stop
+	}
 }
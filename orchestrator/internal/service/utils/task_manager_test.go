@@ -22,25 +22,24 @@ func TestTaskManager_CreateTask(t *testing.T) {
 	exprID := uuid.New()
 
 	t.Run("Basic_task_creation", func(t *testing.T) {
-		task := tm.CreateTask(1.5, 2.5, "+", exprID)
+		task := tm.CreateTask([]float64{1.5, 2.5}, "+", exprID)
 		require.Equal(t, exprID, task.ExpressionID)
 		require.Equal(t, 1, task.TaskID)
-		require.Equal(t, 1.5, task.Arg1)
-		require.Equal(t, 2.5, task.Arg2)
+		require.Equal(t, []float64{1.5, 2.5}, task.Args)
 		require.Equal(t, "+", task.Operation)
 		require.Equal(t, time.Millisecond*100, task.OperationTime)
 	})
 
 	t.Run("Sequential_task_IDs", func(t *testing.T) {
-		task1 := tm.CreateTask(1, 2, "+", uuid.New())
-		task2 := tm.CreateTask(1, 2, "+", uuid.New())
+		task1 := tm.CreateTask([]float64{1, 2}, "+", uuid.New())
+		task2 := tm.CreateTask([]float64{1, 2}, "+", uuid.New())
 		require.Equal(t, task1.TaskID+1, task2.TaskID)
 	})
 
 	t.Run("Different_operations", func(t *testing.T) {
 		operations := []string{"+", "-", "*", "/"}
 		for i, op := range operations {
-			task := tm.CreateTask(1, 2, op, uuid.New())
+			task := tm.CreateTask([]float64{1, 2}, op, uuid.New())
 			require.Equal(t, op, task.Operation)
 			require.Equal(t, i+4, task.TaskID) // Changed from i+3 to i+4
 		}
@@ -48,7 +47,7 @@ func TestTaskManager_CreateTask(t *testing.T) {
 
 	t.Run("Counter_increment", func(t *testing.T) {
 		initialCounter := tm.Counter
-		tm.CreateTask(1, 2, "+", uuid.New())
+		tm.CreateTask([]float64{1, 2}, "+", uuid.New())
 		require.Equal(t, initialCounter+1, tm.Counter)
 	})
 }
@@ -56,7 +55,7 @@ func TestTaskManager_CreateTask(t *testing.T) {
 func TestTaskManager_ResultChannel(t *testing.T) {
 	tm := NewTaskManager(durations)
 	exprID := uuid.New()
-	task := tm.CreateTask(1, 2, "+", exprID)
+	task := tm.CreateTask([]float64{1, 2}, "+", exprID)
 
 	t.Run("Add and get result", func(t *testing.T) {
 		expected := models.Result{
@@ -102,7 +101,7 @@ func TestTaskManager_ConcurrentAccess(t *testing.T) {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				task := tm.CreateTask(1, 2, "+", uuid.New())
+				task := tm.CreateTask([]float64{1, 2}, "+", uuid.New())
 				taskIDs <- task.TaskID
 			}()
 		}
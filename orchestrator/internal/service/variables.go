@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	errs "github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
+	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/jaam8/web_calculator/orchestrator/internal/service/helper"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *OrchestratorService) SetVariable(
+	ctx context.Context, request *orchestrator.SetVariableRequest,
+) (*orchestrator.SetVariableResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+	if err = helper.ValidateVariableName(request.Name); err != nil {
+		logger.GetLoggerFromCtx(ctx).Warn(ctx,
+			"invalid variable name",
+			zap.String("userID", request.UserId),
+			zap.String("name", request.Name),
+		)
+		return nil, err
+	}
+
+	variable := models.Variable{UserId: userId, Name: request.Name, Value: request.Value}
+	if err = s.storage.SaveVariable(variable); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to save variable",
+			zap.String("userID", request.UserId),
+			zap.String("name", request.Name),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to save variable: %w", err)
+	}
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("set variable %s", request.Name),
+		zap.String("userID", request.UserId),
+		zap.Float64("value", request.Value),
+	)
+	return &orchestrator.SetVariableResponse{
+		Variable: &orchestrator.Variable{Name: variable.Name, Value: variable.Value},
+	}, nil
+}
+
+func (s *OrchestratorService) Variables(
+	ctx context.Context, request *orchestrator.VariablesRequest,
+) (*orchestrator.VariablesResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+
+	variables, err := s.storage.GetVariables(userId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get variables",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get variables: %w", err)
+	}
+
+	response := &orchestrator.VariablesResponse{
+		Variables: make([]*orchestrator.Variable, len(variables)),
+	}
+	for i, v := range variables {
+		response.Variables[i] = &orchestrator.Variable{Name: v.Name, Value: v.Value}
+	}
+	return response, nil
+}
+
+func (s *OrchestratorService) VariableByName(
+	ctx context.Context, request *orchestrator.VariableByNameRequest,
+) (*orchestrator.VariableByNameResponse, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+
+	variable, err := s.storage.GetVariable(userId, request.Name)
+	if err != nil {
+		if errors.Is(err, errs.ErrVariableNotFound) {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"no variable found",
+				zap.String("userID", request.UserId),
+				zap.String("name", request.Name),
+			)
+			return nil, errs.ErrVariableNotFound
+		}
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to get variable",
+			zap.String("userID", request.UserId),
+			zap.String("name", request.Name),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to get variable: %w", err)
+	}
+	return &orchestrator.VariableByNameResponse{
+		Variable: &orchestrator.Variable{Name: variable.Name, Value: variable.Value},
+	}, nil
+}
+
+func (s *OrchestratorService) DeleteVariable(
+	ctx context.Context, request *orchestrator.DeleteVariableRequest,
+) (*emptypb.Empty, error) {
+	userId, err := uuid.Parse(request.UserId)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to parse user id",
+			zap.String("userID", request.UserId),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	}
+
+	if err = s.storage.DeleteVariable(userId, request.Name); err != nil {
+		if errors.Is(err, errs.ErrVariableNotFound) {
+			logger.GetLoggerFromCtx(ctx).Warn(ctx,
+				"no variable found",
+				zap.String("userID", request.UserId),
+				zap.String("name", request.Name),
+			)
+			return nil, errs.ErrVariableNotFound
+		}
+		logger.GetLoggerFromCtx(ctx).Error(ctx,
+			"failed to delete variable",
+			zap.String("userID", request.UserId),
+			zap.String("name", request.Name),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("failed to delete variable: %w", err)
+	}
+	logger.GetLoggerFromCtx(ctx).Info(ctx,
+		fmt.Sprintf("deleted variable %s", request.Name),
+		zap.String("userID", request.UserId),
+	)
+	return &emptypb.Empty{}, nil
+}
+
+// userVariables возвращает переменные пользователя для подстановки в выражение
+func (s *OrchestratorService) userVariables(userId uuid.UUID) (map[string]float64, error) {
+	variables, err := s.storage.GetVariables(userId)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]float64, len(variables))
+	for _, v := range variables {
+		values[v.Name] = v.Value
+	}
+	return values, nil
+}
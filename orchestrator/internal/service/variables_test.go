@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jaam8/web_calculator/common-lib/errors"
+	"github.com/jaam8/web_calculator/common-lib/gen/orchestrator"
+	"github.com/jaam8/web_calculator/common-lib/logger"
+	"github.com/jaam8/web_calculator/orchestrator/internal/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetVariable(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	tests := []struct {
+		name        string
+		request     *orchestrator.SetVariableRequest
+		setupMocks  func(storage *MockStorageAdapter)
+		expectedErr error
+	}{
+		{
+			name:    "success",
+			request: &orchestrator.SetVariableRequest{UserId: userID.String(), Name: "rate", Value: 0.2},
+			setupMocks: func(storage *MockStorageAdapter) {
+				storage.On("SaveVariable", models.Variable{UserId: userID, Name: "rate", Value: 0.2}).Return(nil)
+			},
+		},
+		{
+			name:        "invalid name",
+			request:     &orchestrator.SetVariableRequest{UserId: userID.String(), Name: "2x", Value: 1},
+			setupMocks:  func(_ *MockStorageAdapter) {},
+			expectedErr: errors.ErrInvalidVariableName,
+		},
+		{
+			name:        "function name",
+			request:     &orchestrator.SetVariableRequest{UserId: userID.String(), Name: "max", Value: 1},
+			setupMocks:  func(_ *MockStorageAdapter) {},
+			expectedErr: errors.ErrInvalidVariableName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := new(MockStorageAdapter)
+			tt.setupMocks(storage)
+			ctx, _ := logger.New(context.Background())
+
+			service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+			resp, err := service.SetVariable(ctx, tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.request.Name, resp.Variable.Name)
+				assert.Equal(t, tt.request.Value, resp.Variable.Value)
+			}
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVariables(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	storage := new(MockStorageAdapter)
+	storage.On("GetVariables", userID).Return([]*models.Variable{
+		{UserId: userID, Name: "a", Value: 1},
+		{UserId: userID, Name: "b", Value: 2},
+	}, nil)
+	ctx, _ := logger.New(context.Background())
+
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+	resp, err := service.Variables(ctx, &orchestrator.VariablesRequest{UserId: userID.String()})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Variables, 2)
+	assert.Equal(t, "b", resp.Variables[1].Name)
+	assert.Equal(t, 2.0, resp.Variables[1].Value)
+}
+
+func TestVariableByName(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	storage := new(MockStorageAdapter)
+	storage.On("GetVariable", userID, "a").Return(&models.Variable{UserId: userID, Name: "a", Value: 1}, nil)
+	storage.On("GetVariable", userID, "missing").Return(nil, errors.ErrVariableNotFound)
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+
+	resp, err := service.VariableByName(ctx, &orchestrator.VariableByNameRequest{UserId: userID.String(), Name: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, resp.Variable.Value)
+
+	_, err = service.VariableByName(ctx, &orchestrator.VariableByNameRequest{UserId: userID.String(), Name: "missing"})
+	assert.ErrorIs(t, err, errors.ErrVariableNotFound)
+}
+
+func TestDeleteVariable(t *testing.T) {
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	storage := new(MockStorageAdapter)
+	storage.On("DeleteVariable", userID, "a").Return(nil)
+	storage.On("DeleteVariable", userID, "missing").Return(errors.ErrVariableNotFound)
+	ctx, _ := logger.New(context.Background())
+	service := NewOrchestratorService(storage, new(MockExpressionManager), new(MockAgentRegistry), 0, nil, false)
+
+	_, err := service.DeleteVariable(ctx, &orchestrator.DeleteVariableRequest{UserId: userID.String(), Name: "a"})
+	assert.NoError(t, err)
+
+	_, err = service.DeleteVariable(ctx, &orchestrator.DeleteVariableRequest{UserId: userID.String(), Name: "missing"})
+	assert.ErrorIs(t, err, errors.ErrVariableNotFound)
+	storage.AssertExpectations(t)
+}